@@ -0,0 +1,127 @@
+package clatext
+
+import (
+	"bytes"
+	"strings"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+type BlockKind int
+
+const (
+	BlockParagraph BlockKind = iota
+	BlockHeading
+	BlockListItem
+	BlockQuote
+	BlockPre
+	BlockRule
+)
+
+// Span is a piece of text with the same style. A "\n" in the text is a hard line break.
+type Span struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	Code   bool
+	Link   string
+}
+
+type Block struct {
+	Kind BlockKind
+
+	// Level is the level of heading which starts from 1,
+	// or the depth of list item which starts from 0.
+	Level int
+
+	// Ordered and Number are only used by the list item.
+	Ordered bool
+	Number  int
+
+	Spans []Span
+}
+
+// Document is the structured cla text which is rendered by the signing page, pdf and email.
+type Document struct {
+	Blocks []Block
+}
+
+func IsValidFormat(format string) bool {
+	return format == FormatPlain || format == FormatMarkdown || format == FormatHTML
+}
+
+// Canonicalize returns the canonical source of the cla text on which the hash is computed.
+// The line endings are normalized and the html is sanitized.
+func Canonicalize(format string, src []byte) []byte {
+	v := bytes.TrimPrefix(src, []byte("\xef\xbb\xbf"))
+	v = bytes.ReplaceAll(v, []byte("\r\n"), []byte("\n"))
+	v = bytes.ReplaceAll(v, []byte("\r"), []byte("\n"))
+
+	if format == FormatHTML {
+		v = Sanitize(v)
+	}
+
+	v = bytes.TrimRight(v, " \t\n")
+	return append(v, '\n')
+}
+
+// Parse parses the canonical source to document. The unknown format is handled as plain text.
+func Parse(format, src string) *Document {
+	switch format {
+	case FormatMarkdown:
+		return parseMarkdown(src)
+	case FormatHTML:
+		return parseHTML(src)
+	default:
+		return parsePlain(src)
+	}
+}
+
+func parsePlain(src string) *Document {
+	doc := &Document{}
+
+	for _, item := range strings.Split(src, "\n\n") {
+		if item = strings.Trim(item, "\n"); strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		doc.Blocks = append(doc.Blocks, Block{
+			Kind:  BlockParagraph,
+			Spans: []Span{{Text: item}},
+		})
+	}
+
+	return doc
+}
+
+func isSafeLink(link string) bool {
+	s := strings.ToLower(strings.TrimSpace(link))
+	return strings.HasPrefix(s, "http://") ||
+		strings.HasPrefix(s, "https://") ||
+		strings.HasPrefix(s, "mailto:")
+}
+
+// trimSpans removes the leading and trailing spaces of block and drops the empty spans.
+func trimSpans(spans []Span) []Span {
+	for len(spans) > 0 {
+		spans[0].Text = strings.TrimLeft(spans[0].Text, " \t\n")
+		if spans[0].Text != "" {
+			break
+		}
+		spans = spans[1:]
+	}
+
+	for n := len(spans); n > 0; n = len(spans) {
+		spans[n-1].Text = strings.TrimRight(spans[n-1].Text, " \t\n")
+		if spans[n-1].Text != "" {
+			break
+		}
+		spans = spans[:n-1]
+	}
+
+	return spans
+}
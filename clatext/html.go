@@ -0,0 +1,366 @@
+package clatext
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// allowedTags is the tags kept by sanitizing and the value is the allowed attributes.
+	allowedTags = map[string][]string{
+		"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+		"p": nil, "div": nil, "br": nil, "hr": nil,
+		"ul": nil, "ol": {"start"}, "li": {"value"},
+		"strong": nil, "b": nil, "em": nil, "i": nil,
+		"code": nil, "pre": nil, "blockquote": nil,
+		"a": {"href"},
+	}
+
+	// droppedTags is the tags whose content is dropped too.
+	droppedTags = map[string]bool{
+		"script": true, "style": true, "iframe": true, "object": true,
+		"embed": true, "noscript": true, "template": true, "head": true,
+		"title": true, "svg": true, "math": true, "form": true,
+	}
+
+	voidTags = map[string]bool{"br": true, "hr": true}
+)
+
+// Sanitize keeps the allowed tags and attributes of html and escapes all the text.
+func Sanitize(src []byte) []byte {
+	z := html.NewTokenizer(bytes.NewReader(src))
+	buf := new(bytes.Buffer)
+	dropping := 0
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// it is io.EOF or the error of reader which is impossible
+			return buf.Bytes()
+		}
+
+		t := z.Token()
+
+		switch tt {
+		case html.TextToken:
+			if dropping == 0 {
+				buf.WriteString(html.EscapeString(t.Data))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[t.Data] {
+				if tt == html.StartTagToken {
+					dropping++
+				}
+				continue
+			}
+
+			attrs, ok := allowedTags[t.Data]
+			if dropping > 0 || !ok {
+				continue
+			}
+
+			buf.WriteString("<" + t.Data)
+			for _, a := range t.Attr {
+				if isAllowedAttr(attrs, a) {
+					buf.WriteString(" " + a.Key + "=\"" + html.EscapeString(a.Val) + "\"")
+				}
+			}
+			buf.WriteString(">")
+
+		case html.EndTagToken:
+			if droppedTags[t.Data] {
+				if dropping > 0 {
+					dropping--
+				}
+				continue
+			}
+
+			if _, ok := allowedTags[t.Data]; ok && dropping == 0 && !voidTags[t.Data] {
+				buf.WriteString("</" + t.Data + ">")
+			}
+		}
+	}
+}
+
+func isAllowedAttr(attrs []string, a html.Attribute) bool {
+	if a.Namespace != "" {
+		return false
+	}
+
+	for _, item := range attrs {
+		if item != a.Key {
+			continue
+		}
+
+		switch a.Key {
+		case "href":
+			return isSafeLink(a.Val)
+		default:
+			_, err := strconv.Atoi(a.Val)
+			return err == nil
+		}
+	}
+	return false
+}
+
+type htmlList struct {
+	ordered bool
+	number  int
+}
+
+type htmlParser struct {
+	doc Document
+
+	current *Block
+	lists   []htmlList
+	quote   int
+	pre     bool
+
+	bold   int
+	italic int
+	code   int
+	links  []string
+}
+
+// parseHTML converts the sanitized html to document.
+func parseHTML(src string) *Document {
+	p := &htmlParser{}
+	z := html.NewTokenizer(strings.NewReader(src))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		t := z.Token()
+
+		switch tt {
+		case html.TextToken:
+			p.text(t.Data)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			p.startTag(&t)
+		case html.EndTagToken:
+			p.endTag(&t)
+		}
+	}
+
+	p.flush()
+
+	return &p.doc
+}
+
+func (p *htmlParser) startTag(t *html.Token) {
+	switch t.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		p.flush()
+		p.current = &Block{Kind: BlockHeading, Level: int(t.Data[1] - '0')}
+
+	case "p", "div":
+		p.flush()
+
+	case "blockquote":
+		p.flush()
+		p.quote++
+
+	case "pre":
+		p.flush()
+		p.pre = true
+		p.current = &Block{Kind: BlockPre}
+
+	case "ul", "ol":
+		p.flush()
+		l := htmlList{ordered: t.Data == "ol", number: 1}
+		if v, err := strconv.Atoi(attrOf(t, "start")); err == nil {
+			l.number = v
+		}
+		p.lists = append(p.lists, l)
+
+	case "li":
+		p.flush()
+
+		b := &Block{Kind: BlockListItem}
+		if n := len(p.lists); n > 0 {
+			l := &p.lists[n-1]
+			if v, err := strconv.Atoi(attrOf(t, "value")); err == nil {
+				l.number = v
+			}
+
+			b.Level = n - 1
+			b.Ordered = l.ordered
+			b.Number = l.number
+			l.number++
+		}
+		p.current = b
+
+	case "br":
+		p.appendText("\n")
+
+	case "hr":
+		p.flush()
+		p.doc.Blocks = append(p.doc.Blocks, Block{Kind: BlockRule})
+
+	case "strong", "b":
+		p.bold++
+
+	case "em", "i":
+		p.italic++
+
+	case "code":
+		p.code++
+
+	case "a":
+		p.links = append(p.links, attrOf(t, "href"))
+	}
+}
+
+func (p *htmlParser) endTag(t *html.Token) {
+	switch t.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6", "p", "div", "li":
+		p.flush()
+
+	case "blockquote":
+		p.flush()
+		if p.quote > 0 {
+			p.quote--
+		}
+
+	case "pre":
+		p.flush()
+		p.pre = false
+
+	case "ul", "ol":
+		p.flush()
+		if n := len(p.lists); n > 0 {
+			p.lists = p.lists[:n-1]
+		}
+
+	case "strong", "b":
+		if p.bold > 0 {
+			p.bold--
+		}
+
+	case "em", "i":
+		if p.italic > 0 {
+			p.italic--
+		}
+
+	case "code":
+		if p.code > 0 {
+			p.code--
+		}
+
+	case "a":
+		if n := len(p.links); n > 0 {
+			p.links = p.links[:n-1]
+		}
+	}
+}
+
+func (p *htmlParser) text(s string) {
+	if p.pre {
+		p.appendText(s)
+		return
+	}
+
+	// collapse the white spaces as browser does
+	v := strings.Join(strings.Fields(s), " ")
+	if v == "" {
+		p.appendSpace()
+		return
+	}
+
+	if isSpace(s[0]) {
+		p.appendSpace()
+	}
+	p.appendText(v)
+	if isSpace(s[len(s)-1]) {
+		p.appendSpace()
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// appendSpace appends a space between two pieces of text of current block.
+func (p *htmlParser) appendSpace() {
+	if c := p.lastChar(); c != 0 && c != ' ' && c != '\n' {
+		p.appendText(" ")
+	}
+}
+
+func (p *htmlParser) lastChar() byte {
+	if p.current == nil || len(p.current.Spans) == 0 {
+		return 0
+	}
+
+	t := p.current.Spans[len(p.current.Spans)-1].Text
+	if t == "" {
+		return 0
+	}
+	return t[len(t)-1]
+}
+
+func (p *htmlParser) appendText(s string) {
+	if p.current == nil {
+		if strings.TrimSpace(s) == "" {
+			return
+		}
+
+		kind := BlockParagraph
+		if p.quote > 0 {
+			kind = BlockQuote
+		}
+		p.current = &Block{Kind: kind}
+	}
+
+	sp := Span{
+		Text:   s,
+		Bold:   p.bold > 0,
+		Italic: p.italic > 0,
+		Code:   p.code > 0 || p.pre,
+	}
+	if n := len(p.links); n > 0 {
+		sp.Link = p.links[n-1]
+	}
+
+	b := p.current
+	if n := len(b.Spans); n > 0 {
+		last := &b.Spans[n-1]
+		if last.Bold == sp.Bold && last.Italic == sp.Italic && last.Code == sp.Code && last.Link == sp.Link {
+			last.Text += s
+			return
+		}
+	}
+	b.Spans = append(b.Spans, sp)
+}
+
+func (p *htmlParser) flush() {
+	b := p.current
+	if b == nil {
+		return
+	}
+	p.current = nil
+
+	if b.Kind != BlockPre {
+		b.Spans = trimSpans(b.Spans)
+	}
+
+	if len(b.Spans) > 0 || b.Kind == BlockListItem {
+		p.doc.Blocks = append(p.doc.Blocks, *b)
+	}
+}
+
+func attrOf(t *html.Token, key string) string {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
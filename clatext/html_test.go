@@ -0,0 +1,77 @@
+package clatext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		src    string
+		expect string
+	}{
+		{
+			src:    `<p onclick="x()">a <b>b</b></p>`,
+			expect: `<p>a <b>b</b></p>`,
+		},
+		{
+			src:    `a<script>alert(1)</script>b<style>p{}</style>c`,
+			expect: `abc`,
+		},
+		{
+			src:    `<a href="javascript:alert(1)">a</a><a href="https://a.com" target="_blank">b</a>`,
+			expect: `<a>a</a><a href="https://a.com">b</a>`,
+		},
+		{
+			src:    `<ol start="3" type="a"><li value="x">a</li></ol>`,
+			expect: `<ol start="3"><li>a</li></ol>`,
+		},
+		{
+			src:    `<img src="x" onerror="alert(1)"><span>a &lt;b&gt;</span>`,
+			expect: `a &lt;b&gt;`,
+		},
+		{
+			src:    `<svg><a href="https://a.com">a</a></svg>b<br/>`,
+			expect: `b<br>`,
+		},
+		{
+			src:    `<a href="https://a.com/?a=1&amp;b=&quot;2&quot;">a</a>`,
+			expect: `<a href="https://a.com/?a=1&amp;b=&#34;2&#34;">a</a>`,
+		},
+	}
+
+	for _, c := range cases {
+		if v := string(Sanitize([]byte(c.src))); v != c.expect {
+			t.Errorf("Sanitize(%q) = %q, expect %q", c.src, v, c.expect)
+		}
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	src := "<h2>Title</h2>\n" +
+		"<p>a  <strong>b</strong>\n<a href=\"https://a.com\">c</a></p>\n" +
+		"<ol start=\"2\"><li>one</li><li>two<ul><li>sub</li></ul></li></ol>\n" +
+		"<blockquote>quoted</blockquote><hr>" +
+		"<pre>x  y\nz</pre>"
+
+	expect := []Block{
+		{Kind: BlockHeading, Level: 2, Spans: []Span{{Text: "Title"}}},
+		{Kind: BlockParagraph, Spans: []Span{
+			{Text: "a "},
+			{Text: "b", Bold: true},
+			{Text: " "},
+			{Text: "c", Link: "https://a.com"},
+		}},
+		{Kind: BlockListItem, Ordered: true, Number: 2, Spans: []Span{{Text: "one"}}},
+		{Kind: BlockListItem, Ordered: true, Number: 3, Spans: []Span{{Text: "two"}}},
+		{Kind: BlockListItem, Level: 1, Number: 1, Spans: []Span{{Text: "sub"}}},
+		{Kind: BlockQuote, Spans: []Span{{Text: "quoted"}}},
+		{Kind: BlockRule},
+		{Kind: BlockPre, Spans: []Span{{Text: "x  y\nz", Code: true}}},
+	}
+
+	doc := parseHTML(string(Sanitize([]byte(src))))
+	if !reflect.DeepEqual(doc.Blocks, expect) {
+		t.Fatalf("parseHTML = %+v, expect %+v", doc.Blocks, expect)
+	}
+}
@@ -0,0 +1,338 @@
+package clatext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// the subset of markdown which is used by the legal documents.
+var (
+	mdHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdSetextH1    = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	mdSetextH2    = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	mdRule        = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdBullet      = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^([ \t]*)(\d{1,9})[.)][ \t]+(.*)$`)
+	mdQuote       = regexp.MustCompile(`^ {0,3}>[ \t]?(.*)$`)
+	mdFence       = regexp.MustCompile("^ {0,3}(```|~~~)")
+	mdIndentation = 2
+)
+
+type mdParser struct {
+	doc Document
+
+	// the lines of current block which is not flushed
+	kind    BlockKind
+	level   int
+	ordered bool
+	number  int
+	lines   []string
+}
+
+func parseMarkdown(src string) *Document {
+	p := &mdParser{}
+
+	lines := strings.Split(src, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := mdFence.FindStringSubmatch(line); m != nil {
+			p.flush()
+
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimLeft(lines[i], " "), m[1]); i++ {
+				code = append(code, lines[i])
+			}
+
+			p.doc.Blocks = append(p.doc.Blocks, Block{
+				Kind:  BlockPre,
+				Spans: []Span{{Text: strings.Join(code, "\n"), Code: true}},
+			})
+			continue
+		}
+
+		p.parseLine(line)
+	}
+
+	p.flush()
+
+	return &p.doc
+}
+
+func (p *mdParser) parseLine(line string) {
+	if strings.TrimSpace(line) == "" {
+		p.flush()
+		return
+	}
+
+	if p.kind == BlockParagraph && len(p.lines) > 0 {
+		if mdSetextH1.MatchString(line) {
+			p.kind, p.level = BlockHeading, 1
+			p.flush()
+			return
+		}
+		if mdSetextH2.MatchString(line) {
+			p.kind, p.level = BlockHeading, 2
+			p.flush()
+			return
+		}
+	}
+
+	if mdRule.MatchString(line) {
+		p.flush()
+		p.doc.Blocks = append(p.doc.Blocks, Block{Kind: BlockRule})
+		return
+	}
+
+	if m := mdHeading.FindStringSubmatch(line); m != nil {
+		p.flush()
+		p.start(BlockHeading, len(m[1]), m[2])
+		p.flush()
+		return
+	}
+
+	if m := mdOrdered.FindStringSubmatch(line); m != nil {
+		p.flush()
+		p.start(BlockListItem, depthOfIndent(m[1]), m[3])
+		p.ordered = true
+		p.number, _ = strconv.Atoi(m[2])
+		return
+	}
+
+	if m := mdBullet.FindStringSubmatch(line); m != nil {
+		p.flush()
+		p.start(BlockListItem, depthOfIndent(m[1]), m[2])
+		return
+	}
+
+	if m := mdQuote.FindStringSubmatch(line); m != nil {
+		if p.kind != BlockQuote {
+			p.flush()
+			p.start(BlockQuote, 0, m[1])
+		} else {
+			p.lines = append(p.lines, m[1])
+		}
+		return
+	}
+
+	// the lazy continuation line of paragraph, list item and quote
+	if len(p.lines) > 0 {
+		p.lines = append(p.lines, strings.TrimLeft(line, " \t"))
+		return
+	}
+
+	p.start(BlockParagraph, 0, strings.TrimLeft(line, " \t"))
+}
+
+func (p *mdParser) start(kind BlockKind, level int, line string) {
+	p.kind = kind
+	p.level = level
+	p.ordered = false
+	p.number = 0
+	p.lines = []string{line}
+}
+
+func (p *mdParser) flush() {
+	if len(p.lines) == 0 {
+		p.kind = BlockParagraph
+		return
+	}
+
+	spans := trimSpans(parseInline(joinMarkdownLines(p.lines)))
+	if len(spans) > 0 || p.kind == BlockListItem {
+		p.doc.Blocks = append(p.doc.Blocks, Block{
+			Kind:    p.kind,
+			Level:   p.level,
+			Ordered: p.ordered,
+			Number:  p.number,
+			Spans:   spans,
+		})
+	}
+
+	p.kind = BlockParagraph
+	p.lines = nil
+}
+
+func depthOfIndent(s string) int {
+	n := len(strings.ReplaceAll(s, "\t", "    "))
+	return n / mdIndentation
+}
+
+// joinMarkdownLines joins the lines of block, a line ending with two spaces or a backslash
+// is a hard line break, otherwise it is a soft break which is rendered as a space.
+func joinMarkdownLines(lines []string) string {
+	var b strings.Builder
+
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		line = strings.TrimRight(line, " \t")
+		if hard {
+			line = strings.TrimSuffix(line, "\\")
+		}
+
+		b.WriteString(line)
+
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// parseInline parses the emphasis, code and link of markdown.
+func parseInline(s string) []Span {
+	var spans []Span
+	var buf strings.Builder
+	bold, italic := false, false
+
+	emit := func(sp Span) {
+		if sp.Text != "" {
+			spans = append(spans, sp)
+		}
+	}
+	flush := func() {
+		emit(Span{Text: buf.String(), Bold: bold, Italic: italic})
+		buf.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_[]()#+-.!>", s[i+1]) >= 0:
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			if j := strings.IndexByte(s[i+1:], '`'); j >= 0 {
+				flush()
+				emit(Span{Text: s[i+1 : i+1+j], Bold: bold, Italic: italic, Code: true})
+				i += j + 2
+				continue
+			}
+
+		case c == '[':
+			if text, link, n := parseMarkdownLink(s[i:]); n > 0 {
+				flush()
+				for _, item := range parseInline(text) {
+					if isSafeLink(link) {
+						item.Link = strings.TrimSpace(link)
+					}
+					item.Bold = item.Bold || bold
+					item.Italic = item.Italic || italic
+					emit(item)
+				}
+				i += n
+				continue
+			}
+
+		case c == '*' || c == '_':
+			n := 1
+			if i+1 < len(s) && s[i+1] == c {
+				n = 2
+			}
+			delim := s[i : i+n]
+
+			if (n == 2 && bold) || (n == 1 && italic) {
+				flush()
+				if n == 2 {
+					bold = false
+				} else {
+					italic = false
+				}
+				i += n
+				continue
+			}
+
+			if canOpenEmphasis(s, i, n) && strings.Contains(s[i+n:], delim) {
+				flush()
+				if n == 2 {
+					bold = true
+				} else {
+					italic = true
+				}
+				i += n
+				continue
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+
+	flush()
+	return spans
+}
+
+// canOpenEmphasis checks that the delimiter is followed by a non-space character and
+// the underscore is not inside a word, such as snake_case.
+func canOpenEmphasis(s string, i, n int) bool {
+	if i+n >= len(s) || s[i+n] == ' ' || s[i+n] == '\t' {
+		return false
+	}
+
+	if s[i] == '_' && i > 0 && isWordChar(s[i-1]) {
+		return false
+	}
+	return true
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parseMarkdownLink parses [text](link) and returns the length of it.
+// The brackets of text and the parentheses of link must be balanced.
+func parseMarkdownLink(s string) (string, string, int) {
+	i := matchBracket(s, '[', ']')
+	if i < 0 || i+1 >= len(s) || s[i+1] != '(' {
+		return "", "", 0
+	}
+
+	j := matchBracket(s[i+1:], '(', ')')
+	if j < 0 {
+		return "", "", 0
+	}
+
+	link := strings.TrimSpace(s[i+2 : i+1+j])
+	if link == "" || strings.ContainsAny(link, " \t\n") {
+		return "", "", 0
+	}
+
+	return s[1:i], link, i + 2 + j
+}
+
+// matchBracket returns the index of the bracket which closes the one at the beginning
+// of s, or -1 if it is not closed. The escaped brackets and the ones in code are skipped.
+func matchBracket(s string, left, right byte) int {
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			i++
+
+		case '`':
+			if j := strings.IndexByte(s[i+1:], '`'); j >= 0 {
+				i += j + 1
+			}
+
+		case left:
+			depth++
+
+		case right:
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,105 @@
+package clatext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkdownLink(t *testing.T) {
+	cases := []struct {
+		src  string
+		text string
+		link string
+		n    int
+	}{
+		{src: "[a](https://a.com) b", text: "a", link: "https://a.com", n: 18},
+		{src: "[a [b] c](https://a.com)", text: "a [b] c", link: "https://a.com", n: 24},
+		{src: "[a](https://a.com/(b)) c", text: "a", link: "https://a.com/(b)", n: 22},
+		{src: "[a\\]](https://a.com)", text: "a\\]", link: "https://a.com", n: 20},
+		{src: "[`]`](https://a.com)", text: "`]`", link: "https://a.com", n: 20},
+		{src: "[a] b](c)"},
+		{src: "[a](b c)"},
+		{src: "[a]()"},
+		{src: "[a](b"},
+		{src: "[a b"},
+	}
+
+	for _, c := range cases {
+		text, link, n := parseMarkdownLink(c.src)
+		if text != c.text || link != c.link || n != c.n {
+			t.Errorf("parseMarkdownLink(%q) = (%q, %q, %d), expect (%q, %q, %d)",
+				c.src, text, link, n, c.text, c.link, c.n)
+		}
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	cases := []struct {
+		src   string
+		spans []Span
+	}{
+		{
+			src: "a **b** *c* `d`",
+			spans: []Span{
+				{Text: "a "},
+				{Text: "b", Bold: true},
+				{Text: " "},
+				{Text: "c", Italic: true},
+				{Text: " "},
+				{Text: "d", Code: true},
+			},
+		},
+		{
+			src:   "[a] b](https://a.com)",
+			spans: []Span{{Text: "[a] b](https://a.com)"}},
+		},
+		{
+			src: "see [**the** terms](https://a.com).",
+			spans: []Span{
+				{Text: "see "},
+				{Text: "the", Bold: true, Link: "https://a.com"},
+				{Text: " terms", Link: "https://a.com"},
+				{Text: "."},
+			},
+		},
+		{
+			src:   "[a](javascript:alert(1))",
+			spans: []Span{{Text: "a"}},
+		},
+		{
+			src:   "snake_case_name \\*a\\*",
+			spans: []Span{{Text: "snake_case_name *a*"}},
+		},
+	}
+
+	for _, c := range cases {
+		if v := parseInline(c.src); !reflect.DeepEqual(v, c.spans) {
+			t.Errorf("parseInline(%q) = %+v, expect %+v", c.src, v, c.spans)
+		}
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	src := "# Title\n\n" +
+		"first line\nsecond line\n\n" +
+		"1. one\n2. two\n   - sub\n\n" +
+		"> quoted\n\n" +
+		"---\n\n" +
+		"```\ncode *a*\n```\n"
+
+	expect := []Block{
+		{Kind: BlockHeading, Level: 1, Spans: []Span{{Text: "Title"}}},
+		{Kind: BlockParagraph, Spans: []Span{{Text: "first line second line"}}},
+		{Kind: BlockListItem, Ordered: true, Number: 1, Spans: []Span{{Text: "one"}}},
+		{Kind: BlockListItem, Ordered: true, Number: 2, Spans: []Span{{Text: "two"}}},
+		{Kind: BlockListItem, Level: 1, Spans: []Span{{Text: "sub"}}},
+		{Kind: BlockQuote, Spans: []Span{{Text: "quoted"}}},
+		{Kind: BlockRule},
+		{Kind: BlockPre, Spans: []Span{{Text: "code *a*", Code: true}}},
+	}
+
+	doc := parseMarkdown(src)
+	if !reflect.DeepEqual(doc.Blocks, expect) {
+		t.Fatalf("parseMarkdown = %+v, expect %+v", doc.Blocks, expect)
+	}
+}
@@ -0,0 +1,204 @@
+package clatext
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTML renders the document as html which is safe to be embedded in the signing page.
+func (doc *Document) HTML() string {
+	var b strings.Builder
+
+	type openList struct {
+		ordered  bool
+		itemOpen bool
+	}
+
+	// the stack of lists which are open
+	var lists []openList
+
+	closeLists := func(depth int) {
+		for len(lists) > depth {
+			top := lists[len(lists)-1]
+			if top.itemOpen {
+				b.WriteString("</li>\n")
+			}
+			if top.ordered {
+				b.WriteString("</ol>\n")
+			} else {
+				b.WriteString("</ul>\n")
+			}
+			lists = lists[:len(lists)-1]
+		}
+	}
+
+	for i := range doc.Blocks {
+		item := &doc.Blocks[i]
+
+		if item.Kind != BlockListItem {
+			closeLists(0)
+		}
+
+		switch item.Kind {
+		case BlockHeading:
+			level := item.Level
+			if level < 1 || level > 6 {
+				level = 1
+			}
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, spansToHTML(item.Spans), level)
+
+		case BlockListItem:
+			depth := item.Level + 1
+			closeLists(depth)
+			if n := len(lists); n == depth && lists[n-1].ordered != item.Ordered {
+				closeLists(depth - 1)
+			}
+
+			if n := len(lists); n == depth && lists[n-1].itemOpen {
+				b.WriteString("</li>\n")
+				lists[n-1].itemOpen = false
+			}
+
+			// the nested list is inside of the item of its parent list
+			for len(lists) < depth {
+				lists = append(lists, openList{ordered: item.Ordered})
+				if item.Ordered {
+					b.WriteString("<ol>\n")
+				} else {
+					b.WriteString("<ul>\n")
+				}
+			}
+
+			if item.Ordered {
+				fmt.Fprintf(&b, "<li value=\"%d\">%s", item.Number, spansToHTML(item.Spans))
+			} else {
+				fmt.Fprintf(&b, "<li>%s", spansToHTML(item.Spans))
+			}
+			lists[depth-1].itemOpen = true
+
+		case BlockQuote:
+			fmt.Fprintf(&b, "<blockquote><p>%s</p></blockquote>\n", spansToHTML(item.Spans))
+
+		case BlockPre:
+			fmt.Fprintf(&b, "<pre><code>%s</code></pre>\n", html.EscapeString(spansToText(item.Spans)))
+
+		case BlockRule:
+			b.WriteString("<hr>\n")
+
+		default:
+			fmt.Fprintf(&b, "<p>%s</p>\n", spansToHTML(item.Spans))
+		}
+	}
+
+	closeLists(0)
+
+	return b.String()
+}
+
+func spansToHTML(spans []Span) string {
+	var b strings.Builder
+
+	for _, sp := range spans {
+		s := strings.ReplaceAll(html.EscapeString(sp.Text), "\n", "<br>\n")
+
+		if sp.Code {
+			s = "<code>" + s + "</code>"
+		}
+		if sp.Italic {
+			s = "<em>" + s + "</em>"
+		}
+		if sp.Bold {
+			s = "<strong>" + s + "</strong>"
+		}
+		if sp.Link != "" {
+			s = fmt.Sprintf(
+				"<a href=\"%s\" target=\"_blank\" rel=\"nofollow noopener noreferrer\">%s</a>",
+				html.EscapeString(sp.Link), s,
+			)
+		}
+
+		b.WriteString(s)
+	}
+
+	return b.String()
+}
+
+func spansToText(spans []Span) string {
+	var b strings.Builder
+	for _, sp := range spans {
+		b.WriteString(sp.Text)
+	}
+	return b.String()
+}
+
+// PlainText renders the document as plain text which is used by the email.
+func (doc *Document) PlainText() string {
+	var links []string
+
+	text := func(spans []Span) string {
+		var b strings.Builder
+		for _, sp := range spans {
+			b.WriteString(sp.Text)
+			if sp.Link != "" && sp.Link != sp.Text {
+				links = append(links, sp.Link)
+				fmt.Fprintf(&b, "[%d]", len(links))
+			}
+		}
+		return b.String()
+	}
+
+	v := make([]string, 0, len(doc.Blocks)+1)
+
+	for i := range doc.Blocks {
+		item := &doc.Blocks[i]
+
+		switch item.Kind {
+		case BlockHeading:
+			s := text(item.Spans)
+			if item.Level <= 2 {
+				c := "="
+				if item.Level == 2 {
+					c = "-"
+				}
+				s += "\n" + strings.Repeat(c, len([]rune(s)))
+			}
+			v = append(v, s)
+
+		case BlockListItem:
+			marker := "-"
+			if item.Ordered {
+				marker = fmt.Sprintf("%d.", item.Number)
+			}
+			indent := strings.Repeat("   ", item.Level)
+			s := strings.ReplaceAll(text(item.Spans), "\n", "\n"+indent+"   ")
+			s = fmt.Sprintf("%s%s %s", indent, marker, s)
+
+			// the items of list are not separated by blank line
+			if n := len(v); i > 0 && doc.Blocks[i-1].Kind == BlockListItem && n > 0 {
+				v[n-1] += "\n" + s
+			} else {
+				v = append(v, s)
+			}
+
+		case BlockQuote:
+			v = append(v, "> "+strings.ReplaceAll(text(item.Spans), "\n", "\n> "))
+
+		case BlockRule:
+			v = append(v, strings.Repeat("-", 20))
+
+		default:
+			v = append(v, text(item.Spans))
+		}
+	}
+
+	if len(links) > 0 {
+		refs := make([]string, 0, len(links))
+		for i, item := range links {
+			refs = append(refs, fmt.Sprintf("[%d]. %s", i+1, item))
+		}
+		v = append(v, strings.Join(refs, "\n"))
+	}
+
+	return strings.Join(v, "\n\n")
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/opensourceways/app-cla-server/util"
 )
@@ -20,25 +22,257 @@ func InitAppConfig(path string) error {
 }
 
 type appConfig struct {
-	PythonBin                string        `json:"python_bin" required:"true"`
-	CLAFieldsNumber          int           `json:"cla_fields_number" required:"true"`
-	MaxSizeOfCorpCLAPDF      int           `json:"max_size_of_corp_cla_pdf"`
-	MaxSizeOfOrgSignaturePDF int           `json:"max_size_of_org_signature_pdf"`
-	MinLengthOfPassword      int           `json:"min_length_of_password"`
-	MaxLengthOfPassword      int           `json:"max_length_of_password"`
-	VerificationCodeExpiry   int64         `json:"verification_code_expiry" required:"true"`
-	APITokenExpiry           int64         `json:"api_token_expiry" required:"true"`
-	APITokenKey              string        `json:"api_token_key" required:"true"`
-	SymmetricEncryptionKey   string        `json:"symmetric_encryption_key" required:"true"`
-	SymmetricEncryptionNonce string        `json:"symmetric_encryption_nonce" required:"true"`
-	PDFOrgSignatureDir       string        `json:"pdf_org_signature_dir" required:"true"`
-	PDFOutDir                string        `json:"pdf_out_dir" required:"true"`
-	CodePlatformConfigFile   string        `json:"code_platforms" required:"true"`
-	EmailPlatformConfigFile  string        `json:"email_platforms" required:"true"`
-	EmployeeManagersNumber   int           `json:"employee_managers_number" required:"true"`
-	CLAPlatformURL           string        `json:"cla_platform_url" required:"true"`
-	Mongodb                  MongodbConfig `json:"mongodb" required:"true"`
-	OBS                      OBS           `json:"obs" required:"true"`
+	// PythonBin is deprecated, the signature page of pdf is merged without python.
+	PythonBin                string           `json:"python_bin"`
+	CLAFieldsNumber          int              `json:"cla_fields_number" required:"true"`
+	MaxSizeOfCorpCLAPDF      int              `json:"max_size_of_corp_cla_pdf"`
+	MaxSizeOfOrgSignaturePDF int              `json:"max_size_of_org_signature_pdf"`
+	MaxSizeOfPDFLogo         int              `json:"max_size_of_pdf_logo"`
+	MaxSizeOfSignatureImage  int              `json:"max_size_of_signature_image"`
+	MinLengthOfPassword      int              `json:"min_length_of_password"`
+	MaxLengthOfPassword      int              `json:"max_length_of_password"`
+	VerificationCodeExpiry   int64            `json:"verification_code_expiry" required:"true"`
+	APITokenExpiry           int64            `json:"api_token_expiry" required:"true"`
+	APITokenKey              string           `json:"api_token_key" required:"true"`
+	SymmetricEncryptionKey   string           `json:"symmetric_encryption_key" required:"true"`
+	SymmetricEncryptionNonce string           `json:"symmetric_encryption_nonce" required:"true"`
+	PDFOrgSignatureDir       string           `json:"pdf_org_signature_dir" required:"true"`
+	PDFOutDir                string           `json:"pdf_out_dir" required:"true"`
+	CodePlatformConfigFile   string           `json:"code_platforms" required:"true"`
+	EmailPlatformConfigFile  string           `json:"email_platforms" required:"true"`
+	PDFLanguagesConfigFile   string           `json:"pdf_languages" required:"true"`
+	EmployeeManagersNumber   int              `json:"employee_managers_number" required:"true"`
+	CLAPlatformURL           string           `json:"cla_platform_url" required:"true"`
+	AuthFailure              AuthFailure      `json:"auth_failure"`
+	ClientIP                 ClientIP         `json:"client_ip"`
+	CorpManagerOIDC          OIDC             `json:"corp_manager_oidc"`
+	EmployeeApproval         EmployeeApproval `json:"employee_approval"`
+	ApprovalReminder         ApprovalReminder `json:"approval_reminder"`
+	RenewalReminder          RenewalReminder  `json:"renewal_reminder"`
+	Retention                Retention        `json:"retention"`
+	PrivacyPolicy            PrivacyPolicy    `json:"privacy_policy"`
+	Mongodb                  MongodbConfig    `json:"mongodb" required:"true"`
+	OBS                      OBS              `json:"obs" required:"true"`
+}
+
+// AuthFailure configures the protection against guessing the password of
+// corporation managers and the verification codes. All the durations are in seconds.
+type AuthFailure struct {
+	MaxFailuresOfAccount          int   `json:"max_failures_of_account"`
+	MaxFailuresOfVerificationCode int   `json:"max_failures_of_verification_code"`
+	MaxFailuresOfIP               int   `json:"max_failures_of_ip"`
+	FailureWindow                 int64 `json:"failure_window"`
+	DelayStep                     int64 `json:"delay_step"`
+	MaxDelay                      int64 `json:"max_delay"`
+	LockoutDuration               int64 `json:"lockout_duration"`
+}
+
+const (
+	// TokenBindingStrict means the token can only be used from the ip where it was issued.
+	TokenBindingStrict = "strict"
+	// TokenBindingSubnet means the token can be used from the same subnet.
+	TokenBindingSubnet = "subnet"
+	// TokenBindingNone means the token is bound to the fingerprint of device instead of ip.
+	TokenBindingNone = "none"
+
+	ClientIPHeaderForwarded     = "forwarded"
+	ClientIPHeaderXForwardedFor = "x-forwarded-for"
+	ClientIPHeaderXRealIP       = "x-real-ip"
+)
+
+// ClientIP configures how to fetch the client ip and how the api token is bound to it.
+// The headers are trusted only if the request comes from the trusted proxies.
+// If no trusted proxies are set, the headers are ignored and the peer address is used.
+type ClientIP struct {
+	TrustedProxies []string     `json:"trusted_proxies"`
+	Headers        []string     `json:"headers"`
+	TokenBinding   TokenBinding `json:"token_binding"`
+
+	trustedProxies []*net.IPNet
+}
+
+type TokenBinding struct {
+	OwnerOfOrg       string `json:"owner_of_org"`
+	IndividualSigner string `json:"individual_signer"`
+	CorpAdmin        string `json:"corp_admin"`
+	EmployeeManager  string `json:"employee_manager"`
+	IPv4SubnetPrefix int    `json:"ipv4_subnet_prefix"`
+	IPv6SubnetPrefix int    `json:"ipv6_subnet_prefix"`
+}
+
+func (cfg *ClientIP) HasTrustedProxies() bool {
+	return len(cfg.trustedProxies) > 0
+}
+
+func (cfg *ClientIP) IsTrustedProxy(ip net.IP) bool {
+	for _, item := range cfg.trustedProxies {
+		if item.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDC configures the login of corporation managers by the identity provider of
+// their corporation. It is disabled if the redirect url is not set.
+type OIDC struct {
+	RedirectURL             string `json:"redirect_url"`
+	WebRedirectDirOnSuccess string `json:"web_redirect_dir_on_success"`
+	WebRedirectDirOnFailure string `json:"web_redirect_dir_on_failure"`
+	StateExpiry             int64  `json:"state_expiry"`
+}
+
+func (cfg *OIDC) Enabled() bool {
+	return cfg.RedirectURL != ""
+}
+
+// EmployeeApproval configures the one-click links in the email notifying the employee
+// managers, which approve or reject the signing of employee directly. It is disabled
+// if the url is not set.
+type EmployeeApproval struct {
+	// URL is the api which the links point to, such as https://cla.example.com/api/v1/employee-approval
+	URL string `json:"url"`
+	// LinkExpiry is the seconds before the links expire.
+	LinkExpiry int64 `json:"link_expiry"`
+	// The links only redirect the manager to the web page of confirmation with the
+	// approval token in the cookie, and the approval takes effect after the manager
+	// confirms it. Reauthentication requires the manager to input the password too.
+	Reauthentication             bool   `json:"reauthentication"`
+	WebRedirectDirOfConfirmation string `json:"web_redirect_dir_of_confirmation"`
+	WebRedirectDirOnFailure      string `json:"web_redirect_dir_on_failure"`
+}
+
+func (cfg *EmployeeApproval) Enabled() bool {
+	return cfg.URL != ""
+}
+
+func (cfg *EmployeeApproval) validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.WebRedirectDirOfConfirmation == "" || cfg.WebRedirectDirOnFailure == "" {
+		return fmt.Errorf("The web redirect directories of employee_approval should be set")
+	}
+
+	return nil
+}
+
+// ApprovalReminder configures the reminders of the employee signings which are waiting
+// for the activation of managers. All the durations are in seconds. It is disabled
+// if the interval is not set.
+type ApprovalReminder struct {
+	// the interval of checking the pending employee signings
+	CheckInterval int64 `json:"check_interval"`
+
+	// the duration between two reminders sent to the employee managers
+	Interval int64 `json:"interval"`
+
+	// the max number of reminders sent to the employee managers, 0 means no limit
+	MaxReminders int `json:"max_reminders"`
+
+	// the duration after the employee signed, then the corporation administrator
+	// is notified. 0 means it is never escalated.
+	EscalateAfter int64 `json:"escalate_after"`
+
+	// NotifyEmployee notifies the employee of the delay when it is escalated.
+	NotifyEmployee bool `json:"notify_employee"`
+}
+
+func (cfg *ApprovalReminder) Enabled() bool {
+	return cfg.Interval > 0
+}
+
+// RenewalReminder configures the reminders of renewing the corporation signings of
+// the links which have a validity period. The interval is in seconds. 0 means the
+// default value and a negative value means the reminders are disabled.
+type RenewalReminder struct {
+	// the interval of checking the corporation signings
+	CheckInterval int64 `json:"check_interval"`
+}
+
+func (cfg *RenewalReminder) Enabled() bool {
+	return cfg.CheckInterval > 0
+}
+
+// Retention configures how long the data is kept before it is purged by the janitor.
+// All the durations are in seconds. 0 means the default value and a negative value
+// means the data of that class is never purged.
+type Retention struct {
+	// the interval of running janitor
+	Interval int64 `json:"interval"`
+
+	// the duration after the verification code is expired
+	VerificationCodes int64 `json:"verification_codes"`
+
+	// the duration after the corporation signing is deleted
+	DeletedSignings int64 `json:"deleted_signings"`
+
+	// the duration after the link is unlinked. The link is archived and can be
+	// restored within it, then all the data of link will be purged.
+	UnlinkedLinks int64 `json:"unlinked_links"`
+
+	// the duration after the temporary pdf is generated in pdf_out_dir
+	TempPDFs int64 `json:"temp_pdfs"`
+
+	// the duration of auth failures, expired sessions and access tokens
+	AuthRecords int64 `json:"auth_records"`
+}
+
+func (cfg *Retention) setDefault() {
+	items := []struct {
+		v   *int64
+		def int64
+	}{
+		{&cfg.Interval, 86400},
+		{&cfg.VerificationCodes, 86400},
+		{&cfg.DeletedSignings, 180 * 86400},
+		{&cfg.UnlinkedLinks, 365 * 86400},
+		{&cfg.TempPDFs, 86400},
+		{&cfg.AuthRecords, 30 * 86400},
+	}
+
+	for _, item := range items {
+		if *item.v == 0 {
+			*item.v = item.def
+		}
+	}
+}
+
+// PrivacyPolicy lists the versions of privacy policy in chronological order.
+// The last one is the current version which the signers must accept.
+type PrivacyPolicy struct {
+	Versions []PrivacyPolicyVersion `json:"versions"`
+}
+
+type PrivacyPolicyVersion struct {
+	Version string `json:"version" required:"true"`
+	Date    string `json:"date"`
+
+	// the key is the language and the value is the path of document
+	Files map[string]string `json:"files" required:"true"`
+}
+
+func (cfg *PrivacyPolicy) validate() error {
+	versions := map[string]bool{}
+	for _, item := range cfg.Versions {
+		if item.Version == "" || versions[item.Version] {
+			return fmt.Errorf("The version:%s of privacy policy is empty or duplicate", item.Version)
+		}
+		versions[item.Version] = true
+
+		if len(item.Files) == 0 {
+			return fmt.Errorf("The privacy policy of version:%s has no document", item.Version)
+		}
+
+		for _, f := range item.Files {
+			if util.IsFileNotExist(f) {
+				return fmt.Errorf("The file:%s is not exist", f)
+			}
+		}
+	}
+
+	return nil
 }
 
 type MongodbConfig struct {
@@ -50,6 +284,12 @@ type MongodbConfig struct {
 	VCCollection                string `json:"verification_code_collection" required:"true"`
 	CorpSigningCollection       string `json:"corp_signing_collection" required:"true"`
 	IndividualSigningCollection string `json:"individual_signing_collection" required:"true"`
+	AuthFailureCollection       string `json:"auth_failure_collection" required:"true"`
+	SessionCollection           string `json:"session_collection" required:"true"`
+	AccessTokenCollection       string `json:"access_token_collection" required:"true"`
+	CorpOIDCCollection          string `json:"corp_oidc_collection" required:"true"`
+	CLATemplateCollection       string `json:"cla_template_collection" required:"true"`
+	LeaseCollection             string `json:"lease_collection" required:"true"`
 }
 
 type OBS struct {
@@ -65,6 +305,12 @@ func (cfg *appConfig) setDefault() {
 	if cfg.MaxSizeOfOrgSignaturePDF <= 0 {
 		cfg.MaxSizeOfOrgSignaturePDF = (1 << 20)
 	}
+	if cfg.MaxSizeOfPDFLogo <= 0 {
+		cfg.MaxSizeOfPDFLogo = (512 << 10)
+	}
+	if cfg.MaxSizeOfSignatureImage <= 0 {
+		cfg.MaxSizeOfSignatureImage = (512 << 10)
+	}
 
 	if cfg.MinLengthOfPassword <= 0 {
 		cfg.MinLengthOfPassword = 6
@@ -73,13 +319,128 @@ func (cfg *appConfig) setDefault() {
 	if cfg.MaxLengthOfPassword <= 0 {
 		cfg.MaxLengthOfPassword = 16
 	}
+
+	cfg.AuthFailure.setDefault()
+	cfg.ClientIP.setDefault()
+
+	cfg.Retention.setDefault()
+
+	if cfg.CorpManagerOIDC.StateExpiry <= 0 {
+		cfg.CorpManagerOIDC.StateExpiry = 300
+	}
+
+	if cfg.EmployeeApproval.LinkExpiry <= 0 {
+		cfg.EmployeeApproval.LinkExpiry = 7 * 86400
+	}
+
+	if cfg.ApprovalReminder.CheckInterval <= 0 {
+		cfg.ApprovalReminder.CheckInterval = 3600
+	}
+
+	if cfg.RenewalReminder.CheckInterval == 0 {
+		cfg.RenewalReminder.CheckInterval = 86400
+	}
 }
 
-func (cfg *appConfig) validate() error {
-	if util.IsFileNotExist(cfg.PythonBin) {
-		return fmt.Errorf("The file:%s is not exist", cfg.PythonBin)
+func (cfg *ClientIP) setDefault() {
+	if len(cfg.Headers) == 0 {
+		cfg.Headers = []string{
+			ClientIPHeaderForwarded, ClientIPHeaderXForwardedFor, ClientIPHeaderXRealIP,
+		}
+	}
+
+	b := &cfg.TokenBinding
+	for _, item := range []*string{&b.OwnerOfOrg, &b.IndividualSigner, &b.CorpAdmin, &b.EmployeeManager} {
+		if *item == "" {
+			*item = TokenBindingStrict
+		}
+	}
+
+	if b.IPv4SubnetPrefix <= 0 {
+		b.IPv4SubnetPrefix = 24
 	}
 
+	if b.IPv6SubnetPrefix <= 0 {
+		b.IPv6SubnetPrefix = 64
+	}
+}
+
+func (cfg *ClientIP) validate() error {
+	cfg.trustedProxies = make([]*net.IPNet, 0, len(cfg.TrustedProxies))
+	for _, item := range cfg.TrustedProxies {
+		s := item
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip != nil && ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return fmt.Errorf("The trusted proxy:%s is invalid, %s", item, err.Error())
+		}
+		cfg.trustedProxies = append(cfg.trustedProxies, n)
+	}
+
+	for i, item := range cfg.Headers {
+		h := strings.ToLower(item)
+		switch h {
+		case ClientIPHeaderForwarded, ClientIPHeaderXForwardedFor, ClientIPHeaderXRealIP:
+			cfg.Headers[i] = h
+		default:
+			return fmt.Errorf("The header:%s of client ip is not supported", item)
+		}
+	}
+
+	b := &cfg.TokenBinding
+	for _, item := range []string{b.OwnerOfOrg, b.IndividualSigner, b.CorpAdmin, b.EmployeeManager} {
+		switch item {
+		case TokenBindingStrict, TokenBindingSubnet, TokenBindingNone:
+		default:
+			return fmt.Errorf("The token binding mode:%s is not supported", item)
+		}
+	}
+
+	if b.IPv4SubnetPrefix > 32 || b.IPv6SubnetPrefix > 128 {
+		return fmt.Errorf("The prefix of subnet is invalid")
+	}
+
+	return nil
+}
+
+func (cfg *AuthFailure) setDefault() {
+	if cfg.MaxFailuresOfAccount <= 0 {
+		cfg.MaxFailuresOfAccount = 5
+	}
+
+	if cfg.MaxFailuresOfVerificationCode <= 0 {
+		cfg.MaxFailuresOfVerificationCode = 5
+	}
+
+	if cfg.MaxFailuresOfIP <= 0 {
+		cfg.MaxFailuresOfIP = 20
+	}
+
+	if cfg.FailureWindow <= 0 {
+		cfg.FailureWindow = 900
+	}
+
+	if cfg.DelayStep <= 0 {
+		cfg.DelayStep = 2
+	}
+
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 30
+	}
+
+	if cfg.LockoutDuration <= 0 {
+		cfg.LockoutDuration = 900
+	}
+}
+
+func (cfg *appConfig) validate() error {
 	if cfg.CLAFieldsNumber <= 0 {
 		return fmt.Errorf("The cla_fields_number:%d should be bigger than 0", cfg.CLAFieldsNumber)
 	}
@@ -120,5 +481,35 @@ func (cfg *appConfig) validate() error {
 		return fmt.Errorf("The file:%s is not exist", cfg.EmailPlatformConfigFile)
 	}
 
+	if util.IsFileNotExist(cfg.PDFLanguagesConfigFile) {
+		return fmt.Errorf("The file:%s is not exist", cfg.PDFLanguagesConfigFile)
+	}
+
+	if err := cfg.ClientIP.validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.PrivacyPolicy.validate(); err != nil {
+		return err
+	}
+
+	if cfg.Retention.Interval < 0 {
+		return fmt.Errorf("The interval of retention should not be negative")
+	}
+
+	if oidc := &cfg.CorpManagerOIDC; oidc.Enabled() {
+		if oidc.WebRedirectDirOnSuccess == "" || oidc.WebRedirectDirOnFailure == "" {
+			return fmt.Errorf("The web redirect directories of corp_manager_oidc should be set")
+		}
+	}
+
+	if err := cfg.EmployeeApproval.validate(); err != nil {
+		return err
+	}
+
+	if r := &cfg.ApprovalReminder; r.Interval < 0 || r.EscalateAfter < 0 || r.MaxReminders < 0 {
+		return fmt.Errorf("The values of approval_reminder should not be negative")
+	}
+
 	return nil
 }
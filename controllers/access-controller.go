@@ -20,10 +20,15 @@ const (
 )
 
 type accessController struct {
-	RemoteAddr string      `json:"remote_addr"`
-	Expiry     int64       `json:"expiry"`
-	Permission string      `json:"permission"`
-	Payload    interface{} `json:"payload"`
+	TokenID     string      `json:"token_id"`
+	RemoteAddr  string      `json:"remote_addr"`
+	Fingerprint string      `json:"fingerprint"`
+	Expiry      int64       `json:"expiry"`
+	Permission  string      `json:"permission"`
+	Payload     interface{} `json:"payload"`
+
+	// it is set when the request is authenticated by a personal access token.
+	personal bool
 }
 
 func (this *accessController) newToken(secret string) (string, error) {
@@ -85,7 +90,7 @@ func (this *accessController) isTokenExpired() bool {
 	return this.Expiry < util.Now()
 }
 
-func (this *accessController) verify(permission []string, addr string) error {
+func (this *accessController) verify(permission []string, addr, fingerprint string) error {
 	bingo := false
 	for _, item := range permission {
 		if this.Permission == item {
@@ -97,9 +102,29 @@ func (this *accessController) verify(permission []string, addr string) error {
 		return fmt.Errorf("Not allowed permission")
 	}
 
-	if this.RemoteAddr != addr {
-		return fmt.Errorf("Unmatched remote address")
+	if this.personal {
+		return nil
 	}
+
+	switch tokenBindingOf(this.Permission) {
+	case config.TokenBindingNone:
+		if this.Fingerprint != fingerprint {
+			return fmt.Errorf("Unmatched device")
+		}
+
+	case config.TokenBindingSubnet:
+		if !isSameSubnet(this.RemoteAddr, addr) {
+			return fmt.Errorf("Unmatched remote address")
+		}
+
+	default:
+		if this.RemoteAddr != addr {
+			return fmt.Errorf("Unmatched remote address")
+		}
+	}
+
+	// record the latest address which will be saved when refreshing token
+	this.RemoteAddr = addr
 	return nil
 }
 
@@ -0,0 +1,303 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+// actionsOfScope lists the actions which a personal access token can call with each scope.
+var actionsOfScope = map[string]map[string]bool{
+	models.ScopeReadSignings: {
+		"CLAController.List":                       true,
+		"LinkController.ListLinks":                 true,
+		"CorporationSigningController.GetAll":      true,
+		"CorporationSigningController.ListDeleted": true,
+		"IndividualSigningController.List":         true,
+		"EmployeeSigningController.List":           true,
+		"EmployeeManagerController.GetAll":         true,
+	},
+	models.ScopeExport: {
+		"CorporationPDFController.Download": true,
+		"CorporationPDFController.Review":   true,
+		"OrgSignatureController.Get":        true,
+	},
+	models.ScopeCheck: {
+		"IndividualSigningController.CheckOfLink": true,
+	},
+}
+
+type AccessTokenController struct {
+	baseController
+}
+
+func (this *AccessTokenController) Prepare() {
+	// the payload is decoded as a map and converted according to the permission
+	this.apiPrepareWithAC(
+		&accessController{},
+		[]string{PermissionOwnerOfOrg, PermissionCorpAdmin},
+	)
+}
+
+// @Title Post
+// @Description create a personal access token
+// @Param	body		body 	models.PersonalAccessTokenCreateOption	true		"body for personal access token"
+// @Success 201 {object} map
+// @Failure 400 missing_token:          token is missing
+// @Failure 401 unknown_token:          token is unknown
+// @Failure 402 expired_token:          token is expired
+// @Failure 403 unauthorized_token:     the permission of token is unmatched
+// @Failure 404 error_parsing_api_body: parse payload of request failed
+// @Failure 405 invalid_access_token:   the parameter of access token is invalid
+// @Failure 406 unknown_link:           unkown link id
+// @Failure 407 not_yours_org:          the link doesn't belong to your community
+// @Failure 500 system_error:           system error
+// @router / [post]
+func (this *AccessTokenController) Post() {
+	action := "create personal access token"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	var info models.PersonalAccessTokenCreateOption
+	if fr := this.fetchInputPayload(&info); fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	linkID, payload, fr := this.payloadOfAccessToken(&info)
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	if merr := info.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	id, token, merr := info.Create(current.Subject, current.Permission, linkID, payload)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(map[string]string{"id": id, "token": token})
+}
+
+// @Title GetAll
+// @Description list the personal access tokens
+// @Success 200 {object} dbmodels.PersonalAccessToken
+// @Failure 400 missing_token:      token is missing
+// @Failure 401 unknown_token:      token is unknown
+// @Failure 402 expired_token:      token is expired
+// @Failure 403 unauthorized_token: the permission of token is unmatched
+// @Failure 500 system_error:       system error
+// @router / [get]
+func (this *AccessTokenController) GetAll() {
+	action := "list personal access tokens"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	v, merr := models.ListPersonalAccessTokens(current.Subject)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// @Title Delete
+// @Description revoke a personal access token
+// @Param	:id	path 	string		true		"token id"
+// @Success 204 {string} "revoke personal access token successfully"
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 invalid_access_token:       the token does not exist
+// @Failure 500 system_error:               system error
+// @router /:id [delete]
+func (this *AccessTokenController) Delete() {
+	action := "revoke personal access token"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	if merr := models.DeletePersonalAccessToken(current.Subject, this.GetString(":id")); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(action + " successfully")
+}
+
+// payloadOfAccessToken builds the payload which only contains the links in the scope
+// and drops the token of code platform.
+func (this *AccessTokenController) payloadOfAccessToken(info *models.PersonalAccessTokenCreateOption) (string, []byte, *failedApiResult) {
+	ac, fr := this.getAccessController()
+	if fr != nil {
+		return "", nil, fr
+	}
+
+	b, err := json.Marshal(ac.Payload)
+	if err != nil {
+		return "", nil, newFailedApiResult(500, errSystemError, err)
+	}
+
+	linkID := ""
+	var payload interface{}
+
+	switch ac.Permission {
+	case PermissionOwnerOfOrg:
+		pl := acForCodePlatformPayload{}
+		if err := json.Unmarshal(b, &pl); err != nil {
+			return "", nil, newFailedApiResult(500, errSystemError, err)
+		}
+
+		// the links are resolved for each request by the orgs of them,
+		// so that the token loses the link which is moved out of the orgs.
+		orgs := make(map[string]bool, len(info.Links))
+		for _, item := range info.Links {
+			if fr := pl.isOwnerOfLink(item); fr != nil {
+				return "", nil, fr
+			}
+			orgs[pl.Links[item].OrgID] = true
+		}
+
+		payload = &acForCodePlatformPayload{
+			User:     pl.User,
+			Email:    pl.Email,
+			Platform: pl.Platform,
+			Orgs:     orgs,
+		}
+
+	case PermissionCorpAdmin:
+		pl := acForCorpManagerPayload{}
+		if err := json.Unmarshal(b, &pl); err != nil {
+			return "", nil, newFailedApiResult(500, errSystemError, err)
+		}
+
+		linkID = pl.LinkID
+		info.Links = []string{pl.LinkID}
+		payload = &pl
+	}
+
+	if b, err = json.Marshal(payload); err != nil {
+		return "", nil, newFailedApiResult(500, errSystemError, err)
+	}
+	return linkID, b, nil
+}
+
+func (this *baseController) checkPersonalAccessToken(ac *accessController, token string, permission []string) *failedApiResult {
+	v, merr := models.CheckPersonalAccessToken(token)
+	if merr != nil {
+		if merr.IsErrorOf(models.ErrInvalidAccessToken) {
+			return newFailedApiResult(401, errUnknownToken, merr)
+		}
+		return parseModelError(merr)
+	}
+
+	ac.TokenID = v.ID
+	ac.Expiry = v.Expiry
+	ac.Permission = v.Permission
+	ac.personal = true
+
+	var err error
+	if ac.Payload != nil {
+		err = json.Unmarshal(v.Payload, ac.Payload)
+	} else {
+		err = json.Unmarshal(v.Payload, &ac.Payload)
+	}
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	if pl, ok := ac.Payload.(*acForCodePlatformPayload); ok {
+		if fr := pl.resolveLinksOfToken(v.Links); fr != nil {
+			return fr
+		}
+	}
+
+	if err := ac.verify(permission, "", ""); err != nil {
+		return newFailedApiResult(403, errUnauthorizedToken, err)
+	}
+
+	controller, action := this.GetControllerAndAction()
+	if !isActionInScopes(controller+"."+action, v.Scopes) {
+		return newFailedApiResult(
+			403, errUnauthorizedToken,
+			fmt.Errorf("the scopes of personal access token don't permit this operation"),
+		)
+	}
+
+	if linkID := this.GetString(":link_id"); linkID != "" {
+		for _, item := range v.Links {
+			if item == linkID {
+				return nil
+			}
+		}
+		return newFailedApiResult(403, errUnauthorizedToken, fmt.Errorf("the link is out of the scope"))
+	}
+
+	return nil
+}
+
+// resolveLinksOfToken rebuilds the links of personal access token by the orgs which
+// the owner had when the token was created. The link is dropped if it is deleted or
+// moved out of the orgs.
+func (this *acForCodePlatformPayload) resolveLinksOfToken(links []string) *failedApiResult {
+	orgs := this.Orgs
+	if len(orgs) == 0 {
+		// the token created before the orgs are saved
+		orgs = make(map[string]bool, len(this.Links))
+		for _, item := range this.Links {
+			orgs[item.OrgID] = true
+		}
+	}
+
+	// the token is limited to its links rather than all the links of the orgs.
+	this.Orgs = nil
+	this.Links = make(map[string]models.OrgInfo, len(links))
+
+	for _, item := range links {
+		orgInfo, merr := models.GetOrgOfLink(item)
+		if merr != nil {
+			if merr.IsErrorOf(models.ErrNoLink) {
+				continue
+			}
+			return parseModelError(merr)
+		}
+
+		if orgs[orgInfo.OrgID] {
+			this.Links[item] = *orgInfo
+		}
+	}
+
+	return nil
+}
+
+func isActionInScopes(action string, scopes []string) bool {
+	for _, item := range scopes {
+		if actionsOfScope[item][action] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+func checkAuthAttempts(attempts ...models.AuthAttempt) *failedApiResult {
+	for _, item := range attempts {
+		if merr := item.Check(); merr != nil {
+			return parseModelError(merr)
+		}
+	}
+	return nil
+}
+
+func failAuthAttemptOfIP(ip string) models.IModelError {
+	attempt := models.NewAuthAttemptOfIP(ip)
+
+	merr := attempt.Fail()
+	if merr != nil && merr.IsErrorOf(models.ErrClientIPLocked) {
+		if b, _ := attempt.MarkNotified(); b {
+			beego.Warn(fmt.Sprintf("client ip:%s is locked for too many failed attempts", ip))
+		}
+	}
+	return merr
+}
+
+// onVerificationCodeError records the failure of the client ip when the code is wrong
+// and notifies the signer when the verification code is locked.
+func onVerificationCodeError(linkID, emailOfSigner, ip string, merr models.IModelError) models.IModelError {
+	switch merr.ErrCode() {
+	case models.ErrWrongVerificationCode:
+		if err := failAuthAttemptOfIP(ip); err != nil {
+			return err
+		}
+
+	case models.ErrVerificationCodeLocked:
+		notifyVerificationCodeLocked(linkID, emailOfSigner)
+	}
+
+	return merr
+}
+
+func notifyVerificationCodeLocked(linkID, emailOfSigner string) {
+	attempt := models.NewAuthAttemptOfVerificationCode(emailOfSigner, linkID)
+	if b, _ := attempt.MarkNotified(); !b {
+		return
+	}
+
+	orgInfo, merr := models.GetOrgOfLink(linkID)
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	sendEmailToIndividual(
+		linkID, emailOfSigner,
+		fmt.Sprintf("Verification code locked on project of \"%s\"", orgInfo.OrgAlias),
+		email.AuthLockout{
+			User:       emailOfSigner,
+			Action:     "entering the verification code for signing CLA",
+			Minutes:    lockoutMinutes(),
+			Org:        orgInfo.OrgAlias,
+			ProjectURL: orgInfo.ProjectURL(),
+		},
+	)
+}
+
+func notifyAccountLocked(user string) {
+	attempt := models.NewAuthAttemptOfAccount(user)
+	if b, _ := attempt.MarkNotified(); !b {
+		return
+	}
+
+	v, merr := models.CorporationManagerAuthentication{User: user}.FindAccounts()
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	for linkID, item := range v {
+		sendEmailToIndividual(
+			linkID, item.Email,
+			fmt.Sprintf("Account locked on project of \"%s\"", item.OrgAlias),
+			email.AuthLockout{
+				User:       item.Name,
+				Action:     "logging in as corporation manager",
+				Minutes:    lockoutMinutes(),
+				Org:        item.OrgAlias,
+				ProjectURL: item.ProjectURL(),
+			},
+		)
+	}
+}
+
+func lockoutMinutes() int64 {
+	return (config.AppConfig.AuthFailure.LockoutDuration + 59) / 60
+}
@@ -13,6 +13,9 @@ import (
 // @Param	body		body 	models.CorporationManagerAuthentication	true		"body for corporation manager info"
 // @Success 201 {int} map
 // @Failure util.ErrNoCLABindingDoc	"no cla binding applied to corporation"
+// @Failure 429 account_locked:         too many failed attempts on the account
+// @Failure 429 client_ip_locked:       too many failed attempts from the client ip
+// @Failure 429 frequent_auth_attempts: try again later after a failed attempt
 // @router /auth [post]
 func (this *CorporationManagerController) Auth() {
 	action := "authenticate as corp/employee manager"
@@ -29,25 +32,41 @@ func (this *CorporationManagerController) Auth() {
 		return
 	}
 
+	accountAttempt := models.NewAuthAttemptOfAccount(info.User)
+	if fr := checkAuthAttempts(models.NewAuthAttemptOfIP(ip), accountAttempt); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
 	v, merr := (&info).Authenticate()
 	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
 	if len(v) == 0 {
-		this.sendFailedResponse(400, errWrongIDOrPassword, fmt.Errorf("wrong id or pw"), action)
+		if merr := onAuthFailureOfCorpManager(ip, info.User); merr != nil {
+			this.sendModelErrorAsResp(merr, action)
+		} else {
+			this.sendFailedResponse(400, errWrongIDOrPassword, fmt.Errorf("wrong id or pw"), action)
+		}
 		return
 	}
 
-	type authInfo struct {
-		models.OrgRepo
+	accountAttempt.Succeed()
 
-		Role             string `json:"role"`
-		Token            string `json:"token"`
-		InitialPWChanged bool   `json:"initial_pw_changed"`
-	}
+	this.sendSuccessResp(this.newAccessTokens(ip, v))
+}
 
-	result := make([]authInfo, 0, len(v))
+type corpManagerAuthInfo struct {
+	models.OrgRepo
+
+	Role             string `json:"role"`
+	Token            string `json:"token"`
+	InitialPWChanged bool   `json:"initial_pw_changed"`
+}
+
+func (this *CorporationManagerController) newAccessTokens(ip string, v map[string]dbmodels.CorporationManagerCheckResult) []corpManagerAuthInfo {
+	result := make([]corpManagerAuthInfo, 0, len(v))
 
 	for linkID, item := range v {
 		token, err := this.newAccessToken(linkID, ip, &item)
@@ -55,7 +74,7 @@ func (this *CorporationManagerController) Auth() {
 			continue
 		}
 
-		result = append(result, authInfo{
+		result = append(result, corpManagerAuthInfo{
 			OrgRepo:          item.OrgRepo,
 			Role:             item.Role,
 			Token:            token,
@@ -63,7 +82,19 @@ func (this *CorporationManagerController) Auth() {
 		})
 	}
 
-	this.sendSuccessResp(result)
+	return result
+}
+
+func onAuthFailureOfCorpManager(ip, user string) models.IModelError {
+	merr := models.NewAuthAttemptOfAccount(user).Fail()
+	if merr != nil && merr.IsErrorOf(models.ErrAccountLocked) {
+		notifyAccountLocked(user)
+	}
+
+	if err := failAuthAttemptOfIP(ip); merr == nil {
+		merr = err
+	}
+	return merr
 }
 
 func (this *CorporationManagerController) newAccessToken(linkID, ip string, info *dbmodels.CorporationManagerCheckResult) (string, error) {
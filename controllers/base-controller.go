@@ -3,7 +3,6 @@ package controllers
 import (
 	"fmt"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/opensourceways/app-cla-server/config"
 	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/pdf"
 	"github.com/opensourceways/app-cla-server/util"
 )
 
@@ -21,6 +21,9 @@ type failedApiResult struct {
 	reason     error
 	errCode    string
 	statusCode int
+
+	// details is the extra information of error, such as the error of each field.
+	details interface{}
 }
 
 func newFailedApiResult(statusCode int, errCode string, err error) *failedApiResult {
@@ -61,7 +64,7 @@ func (this *baseController) sendSuccessResp(body interface{}) {
 
 func (this *baseController) newFuncForSendingFailedResp(action string) func(fr *failedApiResult) {
 	return func(fr *failedApiResult) {
-		this.sendFailedResponse(fr.statusCode, fr.errCode, fr.reason, action)
+		this.sendFailedResultAsResp(fr, action)
 	}
 }
 
@@ -70,23 +73,30 @@ func (this *baseController) sendModelErrorAsResp(err models.IModelError, action
 }
 
 func (this *baseController) sendFailedResultAsResp(fr *failedApiResult, action string) {
-	this.sendFailedResponse(fr.statusCode, fr.errCode, fr.reason, action)
+	this.sendFailedResponseWithDetails(fr.statusCode, fr.errCode, fr.reason, fr.details, action)
 }
 
 func (this *baseController) sendFailedResponse(statusCode int, errCode string, reason error, action string) {
+	this.sendFailedResponseWithDetails(statusCode, errCode, reason, nil, action)
+}
+
+func (this *baseController) sendFailedResponseWithDetails(statusCode int, errCode string, reason error, details interface{}, action string) {
 	if statusCode >= 500 {
 		beego.Error(fmt.Sprintf("Failed to %s, errCode: %s, err: %s", action, errCode, reason.Error()))
 
 		errCode = errSystemError
 		reason = fmt.Errorf("system error")
+		details = nil
 	}
 
 	d := struct {
-		ErrCode string `json:"error_code"`
-		ErrMsg  string `json:"error_message"`
+		ErrCode    string      `json:"error_code"`
+		ErrMsg     string      `json:"error_message"`
+		ErrDetails interface{} `json:"error_details,omitempty"`
 	}{
-		ErrCode: fmt.Sprintf("cla.%s", errCode),
-		ErrMsg:  reason.Error(),
+		ErrCode:    fmt.Sprintf("cla.%s", errCode),
+		ErrMsg:     reason.Error(),
+		ErrDetails: details,
 	}
 
 	this.sendResponse(d, statusCode)
@@ -94,13 +104,23 @@ func (this *baseController) sendFailedResponse(statusCode int, errCode string, r
 
 func (this *baseController) newApiToken(permission, addr string, pl interface{}) (string, error) {
 	ac := &accessController{
-		Expiry:     util.Expiry(config.AppConfig.APITokenExpiry),
-		Permission: permission,
-		Payload:    pl,
-		RemoteAddr: addr,
+		TokenID:     util.RandStr(24, "alphanum"),
+		Expiry:      util.Expiry(config.AppConfig.APITokenExpiry),
+		Permission:  permission,
+		Payload:     pl,
+		RemoteAddr:  addr,
+		Fingerprint: deviceFingerprint(this.Ctx.Request),
+	}
+
+	token, err := ac.newToken(config.AppConfig.APITokenKey)
+	if err != nil {
+		return "", err
 	}
 
-	return ac.newToken(config.AppConfig.APITokenKey)
+	if merr := models.CreateSession(newSession(ac)); merr != nil {
+		return "", merr
+	}
+	return token, nil
 }
 
 func (this *baseController) refreshAccessToken() (string, *failedApiResult) {
@@ -109,11 +129,19 @@ func (this *baseController) refreshAccessToken() (string, *failedApiResult) {
 		return "", fr
 	}
 
+	if ac.personal {
+		return "", newFailedApiResult(400, errUnauthorizedToken, fmt.Errorf("personal access token can't be refreshed"))
+	}
+
 	token, err := ac.refreshToken(config.AppConfig.APITokenExpiry, config.AppConfig.APITokenKey)
-	if err == nil {
-		return token, nil
+	if err != nil {
+		return "", newFailedApiResult(500, errSystemError, err)
 	}
-	return "", newFailedApiResult(500, errSystemError, err)
+
+	if merr := models.RefreshSession(ac.TokenID, ac.RemoteAddr, ac.Expiry); merr != nil {
+		return "", parseModelError(merr)
+	}
+	return token, nil
 }
 
 func (this *baseController) tokenPayloadBasedOnCodePlatform() (*acForCodePlatformPayload, *failedApiResult) {
@@ -228,6 +256,10 @@ func (this *baseController) checkApiReqToken(ac *accessController, permission []
 		}
 	}
 
+	if models.IsPersonalAccessToken(token) {
+		return this.checkPersonalAccessToken(ac, token, permission)
+	}
+
 	if err := ac.parseToken(token, config.AppConfig.APITokenKey); err != nil {
 		return newFailedApiResult(401, errUnknownToken, err)
 	}
@@ -241,11 +273,11 @@ func (this *baseController) checkApiReqToken(ac *accessController, permission []
 		return fr
 	}
 
-	if err := ac.verify(permission, addr); err != nil {
+	if err := ac.verify(permission, addr, deviceFingerprint(this.Ctx.Request)); err != nil {
 		return newFailedApiResult(403, errUnauthorizedToken, err)
 	}
 
-	return nil
+	return checkSession(ac)
 }
 
 func (this *baseController) getAccessController() (*accessController, *failedApiResult) {
@@ -273,7 +305,8 @@ func (this *baseController) isPostRequest() bool {
 	return this.apiRequestMethod() == http.MethodPost
 }
 
-func (this *baseController) readInputFile(fileName string, maxSize int) ([]byte, *failedApiResult) {
+// readUploadedFile reads the uploaded file whose size is not bigger than maxSize.
+func (this *baseController) readUploadedFile(fileName string, maxSize int) ([]byte, *failedApiResult) {
 	f, _, err := this.GetFile(fileName)
 	if err != nil {
 		return nil, newFailedApiResult(400, errReadingFile, err)
@@ -286,7 +319,19 @@ func (this *baseController) readInputFile(fileName string, maxSize int) ([]byte,
 	}
 
 	if maxSize > 0 && len(data) > maxSize {
-		return nil, newFailedApiResult(400, errTooBigPDF, fmt.Errorf("big pdf file"))
+		return nil, newFailedApiResult(400, errTooBigFile, fmt.Errorf("big file"))
+	}
+
+	return data, nil
+}
+
+func (this *baseController) readInputFile(fileName string, maxSize int) ([]byte, *failedApiResult) {
+	data, fr := this.readUploadedFile(fileName, maxSize)
+	if fr != nil {
+		if fr.errCode == errTooBigFile {
+			return nil, newFailedApiResult(400, errTooBigPDF, fmt.Errorf("big pdf file"))
+		}
+		return nil, fr
 	}
 
 	if http.DetectContentType(data) != contentTypeOfPDF {
@@ -296,6 +341,23 @@ func (this *baseController) readInputFile(fileName string, maxSize int) ([]byte,
 	return data, nil
 }
 
+// readOrgSignature reads the org signature pdf and checks that it can be
+// merged into the pdf of corporation signing.
+func (this *baseController) readOrgSignature() ([]byte, *failedApiResult) {
+	data, fr := this.readInputFile(
+		fileNameOfUploadingOrgSignatue, config.AppConfig.MaxSizeOfOrgSignaturePDF,
+	)
+	if fr != nil {
+		return nil, fr
+	}
+
+	if err := pdf.CheckOrgSignature(data); err != nil {
+		return nil, newFailedApiResult(400, errInvalidOrgSignature, err)
+	}
+
+	return data, nil
+}
+
 func (this *baseController) downloadFile(file string) {
 	output := this.Ctx.Output
 
@@ -341,12 +403,16 @@ func (this *baseController) setCookies(value map[string]string, isSensitive bool
 	}
 }
 
+// clearAccessToken stops refreshing the token of current request and removes it from the cookie.
+func (this *baseController) clearAccessToken() {
+	delete(this.Data, apiAccessController)
+
+	this.Ctx.SetCookie(apiAccessToken, "", -1, "/", "", true, true)
+}
+
 func (this *baseController) getRemoteAddr() (string, *failedApiResult) {
-	ips := this.Ctx.Request.Header.Get("x-forwarded-for")
-	for _, item := range strings.Split(ips, ", ") {
-		if net.ParseIP(item) != nil {
-			return item, nil
-		}
+	if ip := clientIP(this.Ctx.Request); ip != "" {
+		return ip, nil
 	}
 
 	return "", newFailedApiResult(400, errCanNotFetchClientIP, fmt.Errorf("can not fetch client ip"))
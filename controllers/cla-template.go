@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/pdf"
+)
+
+type CLATemplateController struct {
+	baseController
+}
+
+func (this *CLATemplateController) Prepare() {
+	this.apiPrepare(PermissionOwnerOfOrg)
+}
+
+type claTemplateFromLink struct {
+	Name string `json:"name"`
+
+	// Org is the org which owns the template, it is owned by the user if empty.
+	Org string `json:"org"`
+}
+
+// @Title Create
+// @Description create a cla template
+// @Param	body		body 	models.CLATemplateCreateOption	true		"body for creating cla template"
+// @Success 201 {string} "id of cla template"
+// @router / [post]
+func (this *CLATemplateController) Create() {
+	action := "create cla template"
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	input := &models.CLATemplateCreateOption{}
+	if fr := this.fetchInputPayloadFromFormData(input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if input.Org != "" {
+		if fr := pl.isOwnerOfOrg(input.Org); fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+	}
+
+	if input.CorpCLA != nil {
+		data, fr := this.readOrgSignature()
+		if fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+		input.CorpCLA.SetOrgSignature(&data)
+	}
+
+	if merr := input.Validate(pdf.GetPDFGenerator().LangSupported()); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	id, merr := input.Create(pl.Platform, pl.User)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(map[string]string{"id": id})
+}
+
+// @Title CreateFromLink
+// @Description save the clas of link as a cla template
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	controllers.claTemplateFromLink	true		"body for creating cla template"
+// @Success 201 {string} "id of cla template"
+// @router /link/:link_id [post]
+func (this *CLATemplateController) CreateFromLink() {
+	action := "create cla template from link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input claTemplateFromLink
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if input.Org != "" {
+		if fr := pl.isOwnerOfOrg(input.Org); fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+	}
+
+	set, merr := models.GetCLASetOfLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	id, merr := set.CreateCLATemplate(input.Name, pl.Platform, input.Org, pl.User)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(map[string]string{"id": id})
+}
+
+// @Title List
+// @Description list the cla templates owned by the user or the orgs of user
+// @Success 200 {object} models.CLATemplate
+// @router / [get]
+func (this *CLATemplateController) List() {
+	action := "list cla templates"
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	orgs := make([]string, 0, len(pl.Orgs))
+	for k := range pl.Orgs {
+		orgs = append(orgs, k)
+	}
+
+	r, merr := models.ListCLATemplates(pl.Platform, pl.User, orgs)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(r)
+}
+
+// @Title Get
+// @Description get the cla template
+// @Param	:id	path 	string		true		"cla template id"
+// @Success 200 {object} models.CLATemplate
+// @Failure 400 no_cla_template:		the cla template doesn't exist
+// @Failure 401 not_yours_cla_template:	the cla template is not owned by the user or the orgs of user
+// @router /:id [get]
+func (this *CLATemplateController) Get() {
+	action := "get cla template"
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, fr := getCLATemplateOfOwner(pl, this.GetString(":id"))
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// @Title Delete
+// @Description delete the cla template
+// @Param	:id	path 	string		true		"cla template id"
+// @Success 204 {string} delete success!
+// @router /:id [delete]
+func (this *CLATemplateController) Delete() {
+	action := "delete cla template"
+	id := this.GetString(":id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if _, fr := getCLATemplateOfOwner(pl, id); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.DeleteCLATemplate(id); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("delete cla template successfully")
+}
+
+// @Title AddCLA
+// @Description add a cla of other language to the cla template and the links which refer to it
+// @Param	:id		path 	string			true		"cla template id"
+// @Param	:apply_to	path 	string			true		"apply to"
+// @Param	body		body 	models.CLACreateOpt	true		"body for cla"
+// @Success 201 {string} "add cla successfully"
+// @router /:id/:apply_to [post]
+func (this *CLATemplateController) AddCLA() {
+	action := "add cla to cla template"
+	id := this.GetString(":id")
+	applyTo := this.GetString(":apply_to")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if _, fr := getCLATemplateOfOwner(pl, id); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	input := &models.CLACreateOpt{}
+	if fr := this.fetchInputPayloadFromFormData(input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if applyTo == dbmodels.ApplyToCorporation {
+		data, fr := this.readOrgSignature()
+		if fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+		input.SetOrgSignature(&data)
+	}
+
+	if merr := input.Validate(applyTo, pdf.GetPDFGenerator().LangSupported()); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := input.AddToCLATemplate(id, applyTo); merr != nil {
+		if merr.IsErrorOf(models.ErrNoCLATemplateOrCLAExists) {
+			this.sendFailedResponse(400, errCLAExists, merr, action)
+		} else {
+			this.sendModelErrorAsResp(merr, action)
+		}
+		return
+	}
+
+	addCLAToLinksOfTemplate(id, applyTo, input)
+
+	this.sendSuccessResp("add cla successfully")
+}
+
+// addCLAToLinksOfTemplate adds the cla to the links which refer to the cla template.
+// The link which has the cla of same language already is skipped.
+func addCLAToLinksOfTemplate(id, applyTo string, input *models.CLACreateOpt) {
+	links, merr := models.ListLinksOfCLATemplate(id)
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	for i := range links {
+		link := &links[i]
+
+		unlock, fr := lockOnRepo(&link.OrgInfo)
+		if fr != nil {
+			beego.Error(fr.reason)
+			continue
+		}
+
+		if fr := addCLA(link.LinkID, applyTo, input); fr != nil && fr.errCode != errCLAExists {
+			beego.Error(fmt.Sprintf(
+				"add cla of template:%s to link:%s failed, err:%s", id, link.LinkID, fr.reason,
+			))
+		}
+
+		unlock()
+	}
+}
+
+// @Title DeleteCLA
+// @Description delete the cla of a language from the cla template.
+// The clas of links which refer to the template are kept, since they may have been signed.
+// @Param	:id		path 	string		true		"cla template id"
+// @Param	:apply_to	path 	string		true		"apply to"
+// @Param	:language	path 	string		true		"cla language"
+// @Success 204 {string} delete success!
+// @router /:id/:apply_to/:language [delete]
+func (this *CLATemplateController) DeleteCLA() {
+	action := "delete cla of cla template"
+	id := this.GetString(":id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if _, fr := getCLATemplateOfOwner(pl, id); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	merr := models.DeleteCLAOfTemplate(id, this.GetString(":apply_to"), this.GetString(":language"))
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("delete cla successfully")
+}
+
+// getCLATemplateOfOwner returns the cla template which is owned by the user
+// or one of the orgs of user.
+func getCLATemplateOfOwner(pl *acForCodePlatformPayload, id string) (*models.CLATemplate, *failedApiResult) {
+	v, merr := models.GetCLATemplate(id)
+	if merr != nil {
+		return nil, parseModelError(merr)
+	}
+
+	notYours := newFailedApiResult(
+		400, errNotYoursCLATemplate, fmt.Errorf("not the cla template of user"),
+	)
+
+	if v.Platform != pl.Platform {
+		return nil, notYours
+	}
+
+	if v.Org == "" {
+		if v.Submitter != pl.User {
+			return nil, notYours
+		}
+		return v, nil
+	}
+
+	if fr := pl.isOwnerOfOrg(v.Org); fr != nil {
+		return nil, notYours
+	}
+	return v, nil
+}
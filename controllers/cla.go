@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/opensourceways/app-cla-server/config"
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/models"
 	"github.com/opensourceways/app-cla-server/pdf"
@@ -24,6 +23,7 @@ func (this *CLAController) Prepare() {
 // @Param	body		body 	models.OrgCLA	true		"body for org-repo content"
 // @Success 201 {int} models.OrgCLA
 // @Failure 403 body is empty
+// @Failure 400 dco_link: the link is in dco mode
 // @router /:link_id/:apply_to [post]
 func (this *CLAController) Add() {
 	action := "add cla"
@@ -40,6 +40,16 @@ func (this *CLAController) Add() {
 		return
 	}
 
+	link, merr := models.GetLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if link.IsDCO() {
+		this.sendFailedResponse(400, errDCOLink, fmt.Errorf("the link is in dco mode"), action)
+		return
+	}
+
 	input := &models.CLACreateOpt{}
 	if fr := this.fetchInputPayloadFromFormData(input); fr != nil {
 		this.sendFailedResultAsResp(fr, action)
@@ -47,9 +57,7 @@ func (this *CLAController) Add() {
 	}
 
 	if applyTo == dbmodels.ApplyToCorporation {
-		data, fr := this.readInputFile(
-			fileNameOfUploadingOrgSignatue, config.AppConfig.MaxSizeOfOrgSignaturePDF,
-		)
+		data, fr := this.readOrgSignature()
 		if fr != nil {
 			this.sendFailedResultAsResp(fr, action)
 			return
@@ -143,6 +151,45 @@ func (this *CLAController) List() {
 	this.sendSuccessResp(clas)
 }
 
+// @Title Preview
+// @Description preview the cla text rendered for the signing page and the email
+// @Param	:link_id	path 	string	true		"link id"
+// @Param	:apply_to	path 	string	true		"apply to"
+// @Param	:language	path 	string	true		"cla language"
+// @Success 200 {object} models.CLAPreview
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 unknown_link:               unkown link id
+// @Failure 406 not_yours_org:              the link doesn't belong to your community
+// @Failure 407 missing_cla:                the cla doesn't exist
+// @Failure 500 system_error:               system error
+// @router /:link_id/:apply_to/:language/preview [get]
+func (this *CLAController) Preview() {
+	action := "preview cla"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, merr := models.PreviewCLA(linkID, this.GetString(":apply_to"), this.GetString(":language"))
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
 func addCLA(linkID, applyTo string, input *models.CLACreateOpt) *failedApiResult {
 	hasCLA, merr := models.HasCLA(linkID, applyTo, input.Language)
 	if merr != nil {
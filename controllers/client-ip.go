@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/config"
+)
+
+// clientIP returns the ip of client. The headers set by proxies are used only when
+// the request comes from a trusted proxy, and the nearest untrusted address is the client.
+// The headers can be forged by anyone, so they are ignored if no proxies are trusted.
+func clientIP(r *http.Request) string {
+	cfg := &config.AppConfig.ClientIP
+
+	peer := parseIPOfHeader(r.RemoteAddr)
+	if !cfg.HasTrustedProxies() || peer == nil || !cfg.IsTrustedProxy(peer) {
+		return ipToString(peer)
+	}
+
+	for _, h := range cfg.Headers {
+		ips := ipsInHeader(r, h)
+		if len(ips) == 0 {
+			continue
+		}
+
+		// each proxy appends the address it receives from, so walk from the right.
+		for i := len(ips) - 1; i >= 0; i-- {
+			if !cfg.IsTrustedProxy(ips[i]) {
+				return ips[i].String()
+			}
+		}
+		return ips[0].String()
+	}
+
+	return ipToString(peer)
+}
+
+func ipsInHeader(r *http.Request, h string) []net.IP {
+	values := r.Header[http.CanonicalHeaderKey(h)]
+	if len(values) == 0 {
+		return nil
+	}
+
+	var items []string
+	switch h {
+	case config.ClientIPHeaderForwarded:
+		items = addrsInForwarded(values)
+	case config.ClientIPHeaderXForwardedFor:
+		for _, v := range values {
+			items = append(items, strings.Split(v, ",")...)
+		}
+	case config.ClientIPHeaderXRealIP:
+		items = values[:1]
+	}
+
+	r1 := make([]net.IP, 0, len(items))
+	for _, item := range items {
+		if ip := parseIPOfHeader(item); ip != nil {
+			r1 = append(r1, ip)
+		}
+	}
+	return r1
+}
+
+// addrsInForwarded fetches the 'for' parameters of Forwarded header, see RFC 7239.
+func addrsInForwarded(values []string) []string {
+	var r []string
+	for _, v := range values {
+		for _, elem := range strings.Split(v, ",") {
+			for _, pair := range strings.Split(elem, ";") {
+				kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+				if len(kv) == 2 && strings.ToLower(kv[0]) == "for" {
+					r = append(r, kv[1])
+				}
+			}
+		}
+	}
+	return r
+}
+
+// parseIPOfHeader parses the address which may be quoted and include port.
+func parseIPOfHeader(s string) net.IP {
+	s = strings.Trim(strings.TrimSpace(s), "\"")
+
+	if ip := net.ParseIP(s); ip != nil {
+		return ip
+	}
+
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	return net.ParseIP(strings.Trim(s, "[]"))
+}
+
+func ipToString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func tokenBindingOf(permission string) string {
+	b := &config.AppConfig.ClientIP.TokenBinding
+
+	switch permission {
+	case PermissionOwnerOfOrg:
+		return b.OwnerOfOrg
+	case PermissionIndividualSigner:
+		return b.IndividualSigner
+	case PermissionCorpAdmin:
+		return b.CorpAdmin
+	case PermissionEmployeeManager:
+		return b.EmployeeManager
+	}
+	return config.TokenBindingStrict
+}
+
+func isSameSubnet(addr1, addr2 string) bool {
+	ip1 := net.ParseIP(addr1)
+	ip2 := net.ParseIP(addr2)
+	if ip1 == nil || ip2 == nil {
+		return false
+	}
+
+	b := &config.AppConfig.ClientIP.TokenBinding
+
+	var mask net.IPMask
+	if ip1.To4() != nil {
+		if ip2.To4() == nil {
+			return false
+		}
+		mask = net.CIDRMask(b.IPv4SubnetPrefix, 32)
+	} else {
+		if ip2.To4() != nil {
+			return false
+		}
+		mask = net.CIDRMask(b.IPv6SubnetPrefix, 128)
+	}
+
+	return ip1.Mask(mask).Equal(ip2.Mask(mask))
+}
+
+// deviceFingerprint identifies the client roughly when the token is not bound to ip.
+func deviceFingerprint(r *http.Request) string {
+	s := r.Header.Get("User-Agent") + "\n" + r.Header.Get("Accept-Language")
+
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:16])
+}
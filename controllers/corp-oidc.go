@@ -0,0 +1,414 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/oidc"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	permissionOIDCState = "oidc state"
+	permissionOIDCLogin = "oidc login"
+
+	// cookieOfOIDCState binds the state of oidc login to the browser which starts it.
+	cookieOfOIDCState = "oidc_state"
+	cookieOfOIDCLogin = "oidc_login_token"
+
+	errOIDCDisabled = "oidc_disabled"
+	errNoCorpOIDC   = string(models.ErrNoCorpOIDC)
+)
+
+type oidcIdentity struct {
+	Email    string `json:"email"`
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"client_id"`
+	Nonce    string `json:"nonce,omitempty"`
+	// Binding is the hash of the value of cookie which binds the state to the browser.
+	Binding string `json:"binding,omitempty"`
+}
+
+// @Title PutOIDC
+// @Description configure the identity provider of corporation
+// @Param	body		body 	models.CorpOIDCSettingOption	true		"body for oidc"
+// @Success 202 {string} "configure oidc successfully"
+// @Failure 400 missing_token:          token is missing
+// @Failure 401 unknown_token:          token is unknown
+// @Failure 402 expired_token:          token is expired
+// @Failure 403 unauthorized_token:     the permission of token is unmatched
+// @Failure 404 error_parsing_api_body: parse payload of request failed
+// @Failure 405 invalid_corp_oidc:      the configuration is invalid or the provider is unavailable
+// @Failure 500 system_error:           system error
+// @router /oidc [put]
+func (this *CorporationManagerController) PutOIDC() {
+	action := "configure oidc of corporation"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	var info models.CorpOIDCSettingOption
+	if fr := this.fetchInputPayload(&info); fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	if merr := info.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	// make sure the provider is available
+	if _, err := oidc.NewProvider(oidc.Config{Issuer: info.Issuer}); err != nil {
+		this.sendFailedResponse(400, string(models.ErrInvalidCorpOIDC), err, action)
+		return
+	}
+
+	if merr := info.Save(pl.LinkID, pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(action + " successfully")
+}
+
+// @Title GetOIDC
+// @Description get the identity provider of corporation
+// @Success 200 {object} dbmodels.CorpOIDC
+// @Failure 400 missing_token:      token is missing
+// @Failure 401 unknown_token:      token is unknown
+// @Failure 402 expired_token:      token is expired
+// @Failure 403 unauthorized_token: the permission of token is unmatched
+// @Failure 404 no_corp_oidc:       oidc is not configured
+// @Failure 500 system_error:       system error
+// @router /oidc [get]
+func (this *CorporationManagerController) GetOIDC() {
+	action := "get oidc of corporation"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, merr := models.GetCorpOIDC(pl.LinkID, pl.Email)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// @Title DeleteOIDC
+// @Description remove the identity provider of corporation
+// @Success 204 {string} "remove oidc successfully"
+// @Failure 400 missing_token:      token is missing
+// @Failure 401 unknown_token:      token is unknown
+// @Failure 402 expired_token:      token is expired
+// @Failure 403 unauthorized_token: the permission of token is unmatched
+// @Failure 404 no_corp_oidc:       oidc is not configured
+// @Failure 500 system_error:       system error
+// @router /oidc [delete]
+func (this *CorporationManagerController) DeleteOIDC() {
+	action := "remove oidc of corporation"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.DeleteCorpOIDC(pl.LinkID, pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(action + " successfully")
+}
+
+// @Title OIDCAuthCodeURL
+// @Description get the url to log in by the identity provider of corporation
+// @Param	:email	path 	string		true		"email of manager"
+// @Success 200 {object} map
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 oidc_disabled:              oidc login is disabled
+// @Failure 402 no_corp_oidc:               oidc is not configured
+// @Failure 403 invalid_corp_oidc:          the provider is unavailable
+// @Failure 500 system_error:               system error
+// @router /oidc/authcodeurl/:email [get]
+func (this *CorporationManagerController) OIDCAuthCodeURL() {
+	action := "fetch oidc auth code url"
+	email := this.GetString(":email")
+
+	if !config.AppConfig.CorpManagerOIDC.Enabled() {
+		this.sendFailedResponse(400, errOIDCDisabled, fmt.Errorf("oidc is disabled"), action)
+		return
+	}
+
+	cfgs, merr := models.ListCorpOIDCs(email)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	// use the latest one if the corporation configured different providers on links
+	cfg := &cfgs[0]
+	for i := range cfgs {
+		if cfgs[i].UpdatedAt > cfg.UpdatedAt {
+			cfg = &cfgs[i]
+		}
+	}
+
+	p, err := newOIDCProvider(cfg)
+	if err != nil {
+		this.sendFailedResponse(400, string(models.ErrInvalidCorpOIDC), err, action)
+		return
+	}
+
+	binding := util.RandStr(32, "alphanum")
+	identity := oidcIdentity{
+		Email:    email,
+		Issuer:   cfg.Issuer,
+		ClientID: cfg.ClientID,
+		Nonce:    util.RandStr(24, "alphanum"),
+		Binding:  hashOfOIDCBinding(binding),
+	}
+	state, err := newOIDCToken(permissionOIDCState, "", &identity)
+	if err != nil {
+		this.sendFailedResponse(500, errSystemError, err, action)
+		return
+	}
+
+	this.setCookies(map[string]string{cookieOfOIDCState: binding}, true)
+
+	this.sendSuccessResp(map[string]string{
+		"url": p.AuthCodeURL(state, identity.Nonce),
+	})
+}
+
+// @Title OIDCCallback
+// @Description callback of authentication by the identity provider of corporation
+// @Failure 400 auth_failed:                 authenticated on identity provider failed
+// @Failure 401 corp_manager_does_not_exist: the email is not a manager of corporation
+// @Failure 500 system_error:                system error
+// @router /oidc/callback [get]
+func (this *CorporationManagerController) OIDCCallback() {
+	cfg := &config.AppConfig.CorpManagerOIDC
+
+	rs := func(errCode string, reason error) {
+		this.setCookies(map[string]string{"error_code": errCode, "error_msg": reason.Error()}, false)
+		this.redirect(cfg.WebRedirectDirOnFailure)
+	}
+
+	if !cfg.Enabled() {
+		rs(errOIDCDisabled, fmt.Errorf("oidc is disabled"))
+		return
+	}
+
+	var state oidcIdentity
+	if err := parseOIDCToken(this.GetString("state"), permissionOIDCState, "", &state); err != nil {
+		rs(errAuthFailed, fmt.Errorf("invalid state: %s", err.Error()))
+		return
+	}
+
+	// the state can only be used by the browser which started the login.
+	binding := this.Ctx.Input.Cookie(cookieOfOIDCState)
+	this.Ctx.SetCookie(cookieOfOIDCState, "", -1, "/", "", true, true)
+	if binding == "" || subtle.ConstantTimeCompare(
+		[]byte(hashOfOIDCBinding(binding)), []byte(state.Binding),
+	) != 1 {
+		rs(errAuthFailed, fmt.Errorf("the state doesn't belong to this browser"))
+		return
+	}
+
+	if err := this.GetString("error"); err != "" {
+		rs(errAuthFailed, fmt.Errorf("%s, %s", err, this.GetString("error_description")))
+		return
+	}
+
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		rs(fr.errCode, fr.reason)
+		return
+	}
+
+	corpOIDC, fr := findCorpOIDC(&state)
+	if fr != nil {
+		rs(fr.errCode, fr.reason)
+		return
+	}
+
+	p, err := newOIDCProvider(corpOIDC)
+	if err != nil {
+		rs(errAuthFailed, err)
+		return
+	}
+
+	claims, err := p.Exchange(this.GetString("code"), state.Nonce)
+	if err != nil {
+		rs(errAuthFailed, err)
+		return
+	}
+
+	if !claims.EmailVerified || claims.Email == "" {
+		rs(errAuthFailed, fmt.Errorf("the email is not verified by the identity provider"))
+		return
+	}
+
+	if !strings.EqualFold(util.EmailSuffix(claims.Email), corpOIDC.CorpID) {
+		rs(errAuthFailed, fmt.Errorf("the email doesn't belong to the corporation"))
+		return
+	}
+
+	v, merr := models.AuthenticateByOIDC(claims.Email, state.Issuer, state.ClientID)
+	if merr != nil {
+		fr := parseModelError(merr)
+		rs(fr.errCode, fr.reason)
+		return
+	}
+	if len(v) == 0 {
+		rs(string(models.ErrCorpManagerDoesNotExist), fmt.Errorf("not a manager of corporation"))
+		return
+	}
+
+	token, err := newOIDCToken(
+		permissionOIDCLogin, ip,
+		&oidcIdentity{Email: claims.Email, Issuer: state.Issuer, ClientID: state.ClientID},
+	)
+	if err != nil {
+		rs(errSystemError, err)
+		return
+	}
+
+	this.setCookies(map[string]string{cookieOfOIDCLogin: token}, true)
+	this.redirect(cfg.WebRedirectDirOnSuccess)
+}
+
+// @Title OIDCAuth
+// @Description exchange the token of oidc login for the access tokens of corporation manager.
+// The token is read from the cookie set by the callback, or from the body.
+// @Param	body		body 	map	false		"body containing the oidc_login_token"
+// @Success 201 {int} map
+// @Failure 400 error_parsing_api_body:      parse payload of request failed
+// @Failure 401 auth_failed:                 the login token is invalid
+// @Failure 402 corp_manager_does_not_exist: the email is not a manager of corporation
+// @Failure 500 system_error:                system error
+// @router /oidc/auth [post]
+func (this *CorporationManagerController) OIDCAuth() {
+	action := "authenticate as corp/employee manager by oidc"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	var info struct {
+		Token string `json:"oidc_login_token"`
+	}
+	if info.Token = this.Ctx.Input.Cookie(cookieOfOIDCLogin); info.Token != "" {
+		this.Ctx.SetCookie(cookieOfOIDCLogin, "", -1, "/", "", true, true)
+	} else if fr := this.fetchInputPayload(&info); fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	var identity oidcIdentity
+	if err := parseOIDCToken(info.Token, permissionOIDCLogin, ip, &identity); err != nil {
+		this.sendFailedResponse(401, errAuthFailed, err, action)
+		return
+	}
+
+	v, merr := models.AuthenticateByOIDC(identity.Email, identity.Issuer, identity.ClientID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if len(v) == 0 {
+		this.sendFailedResponse(
+			400, string(models.ErrCorpManagerDoesNotExist),
+			fmt.Errorf("not a manager of corporation"), action,
+		)
+		return
+	}
+
+	this.sendSuccessResp(this.newAccessTokens(ip, v))
+}
+
+func findCorpOIDC(identity *oidcIdentity) (*models.CorpOIDC, *failedApiResult) {
+	cfgs, merr := models.ListCorpOIDCs(identity.Email)
+	if merr != nil {
+		return nil, parseModelError(merr)
+	}
+
+	for i := range cfgs {
+		if cfgs[i].Issuer == identity.Issuer && cfgs[i].ClientID == identity.ClientID {
+			return &cfgs[i], nil
+		}
+	}
+
+	return nil, newFailedApiResult(400, errNoCorpOIDC, fmt.Errorf("the oidc has been changed"))
+}
+
+func newOIDCProvider(cfg *models.CorpOIDC) (*oidc.Provider, error) {
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  config.AppConfig.CorpManagerOIDC.RedirectURL,
+	})
+}
+
+// newOIDCToken signs the short-lived token which is used during the oidc login.
+// It can't be used as an api token since its permission is unknown to apis.
+func newOIDCToken(permission, ip string, identity *oidcIdentity) (string, error) {
+	ac := &accessController{
+		Expiry:     util.Expiry(config.AppConfig.CorpManagerOIDC.StateExpiry),
+		Permission: permission,
+		Payload:    identity,
+		RemoteAddr: ip,
+	}
+
+	return ac.newToken(config.AppConfig.APITokenKey)
+}
+
+func parseOIDCToken(token, permission, ip string, identity *oidcIdentity) error {
+	if token == "" {
+		return fmt.Errorf("missing token")
+	}
+
+	ac := &accessController{Payload: identity}
+	if err := ac.parseToken(token, config.AppConfig.APITokenKey); err != nil {
+		return err
+	}
+
+	if ac.Permission != permission {
+		return fmt.Errorf("unmatched token")
+	}
+
+	if ac.isTokenExpired() {
+		return fmt.Errorf("token is expired")
+	}
+
+	if ac.RemoteAddr != ip {
+		return fmt.Errorf("unmatched remote address")
+	}
+
+	return nil
+}
+
+func hashOfOIDCBinding(v string) string {
+	h := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(h[:])
+}
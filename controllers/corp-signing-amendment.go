@@ -0,0 +1,392 @@
+package controllers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/pdf"
+	"github.com/opensourceways/app-cla-server/worker"
+)
+
+type CorpSigningAmendmentController struct {
+	baseController
+}
+
+func (this *CorpSigningAmendmentController) Prepare() {
+	if !strings.Contains(this.routerPattern(), ":link_id") {
+		// corp administrator amends the contact information or downloads the amendment page
+		this.apiPrepare(PermissionCorpAdmin)
+	} else {
+		this.apiPrepare(PermissionOwnerOfOrg)
+	}
+}
+
+// @Title Submit
+// @Description corp administrator amends the contact information of corporation signing.
+// It takes effect at once unless the approval of community is required by the link.
+// @Param	body		body 	models.CorpSigningAmendmentOption	true		"body for amendment"
+// @Success 201 {object} models.CorpSigningAmendment
+// @Failure 400 unsigned:             the corporation has not signed
+// @Failure 401 invalid_amendment:    the amendment is invalid
+// @Failure 402 invalid_signing_info: some values of signing info are invalid, see error_details
+// @Failure 403 amendment_pending:    there is a pending amendment
+// @Failure 500 system_error:         system error
+// @router / [post]
+func (this *CorpSigningAmendmentController) Submit() {
+	action := "amend corp signing"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var info models.CorpSigningAmendmentOption
+	if fr := this.fetchInputPayload(&info); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	link, merr := models.GetLink(pl.LinkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	unlock, fr := lockOnRepo(&pl.OrgInfo)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	v, merr := info.Submit(pl.LinkID, pl.Email, pl.Email, link.AmendmentApproval)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v.Amendment)
+
+	notifyCorpSigningAmendment(pl.LinkID, &pl.OrgInfo, v)
+}
+
+// @Title Get
+// @Description corp administrator gets the amendments of corporation signing
+// @Success 200 {object} []models.CorpSigningAmendment
+// @Failure 400 unsigned:     the corporation has not signed
+// @Failure 500 system_error: system error
+// @router / [get]
+func (this *CorpSigningAmendmentController) Get() {
+	action := "list amendments of corp signing"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.listAmendments(pl.LinkID, pl.Email, action)
+}
+
+// @Title List
+// @Description get the amendments of corporation signing
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Success 200 {object} []models.CorpSigningAmendment
+// @Failure 400 unsigned:     the corporation has not signed
+// @Failure 500 system_error: system error
+// @router /:link_id/:email [get]
+func (this *CorpSigningAmendmentController) List() {
+	action := "list amendments of corp signing"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.listAmendments(linkID, this.GetString(":email"), action)
+}
+
+// @Title Review
+// @Description the community approves or rejects the pending amendment of corporation signing
+// @Param	:link_id	path 	string					true		"link id"
+// @Param	:email		path 	string					true		"email of corp"
+// @Param	:id		path 	string					true		"amendment id"
+// @Param	body		body 	models.CorpSigningAmendmentReview	true		"body for review"
+// @Success 202 {object} models.CorpSigningAmendment
+// @Failure 400 no_pending_amendment: the amendment is not pending
+// @Failure 401 invalid_amendment:    the amendment is invalid
+// @Failure 402 invalid_signing_info: the amended values are invalid, see error_details
+// @Failure 500 system_error:         system error
+// @router /:link_id/:email/:id [put]
+func (this *CorpSigningAmendmentController) Review() {
+	action := "review amendment of corp signing"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	orgInfo := pl.orgInfo(linkID)
+
+	var info models.CorpSigningAmendmentReview
+	if fr := this.fetchInputPayload(&info); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := info.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	unlock, fr := lockOnRepo(orgInfo)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	v, merr := info.Review(linkID, this.GetString(":email"), this.GetString(":id"), pl.User)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v.Amendment)
+
+	notifyCorpSigningAmendment(linkID, orgInfo, v)
+}
+
+// @Title Download
+// @Description download the page of amendment which records the previous and amended values
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Param	:id		path 	string		true		"amendment id"
+// @Success 200 {int} map
+// @Failure 400 no_link_or_unuploaded: the amendment has not taken effect
+// @Failure 500 system_error:          system error
+// @router /:link_id/:email/:id/pdf [get]
+func (this *CorpSigningAmendmentController) Download() {
+	action := "download pdf of amendment of corp signing"
+	linkID := this.GetString(":link_id")
+	corpEmail := this.GetString(":email")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	fr = this.downloadAmendmentPDF(linkID, corpEmail, this.GetString(":id"), pl.orgInfo(linkID))
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+// @Title DownloadPDF
+// @Description corp administrator downloads the page of amendment which records the previous and amended values
+// @Param	:id		path 	string		true		"amendment id"
+// @Success 200 {int} map
+// @Failure 400 no_link_or_unuploaded: the amendment has not taken effect
+// @Failure 500 system_error:          system error
+// @router /:id/pdf [get]
+func (this *CorpSigningAmendmentController) DownloadPDF() {
+	action := "download pdf of amendment of corp signing"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	fr = this.downloadAmendmentPDF(pl.LinkID, pl.Email, this.GetString(":id"), &pl.OrgInfo)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+// downloadAmendmentPDF downloads the page of amendment. The page is generated again
+// if it is missing, because it may fail to be generated or saved when the amendment
+// took effect.
+func (this *CorpSigningAmendmentController) downloadAmendmentPDF(linkID, corpEmail, id string, orgInfo *models.OrgInfo) *failedApiResult {
+	path, err := genTempFilePath(linkID, corpEmail)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	merr := models.DownloadCorpSigningAmendmentPDF(linkID, corpEmail, id, path)
+	if merr != nil {
+		if !merr.IsErrorOf(models.ErrNoLinkOrUnuploaed) {
+			return parseModelError(merr)
+		}
+
+		if fr := regenCorpSigningAmendmentPDF(linkID, corpEmail, id, orgInfo, path); fr != nil {
+			return fr
+		}
+	}
+
+	this.downloadFile(path)
+	return nil
+}
+
+func (this *CorpSigningAmendmentController) listAmendments(linkID, corpEmail, action string) {
+	v, merr := models.ListCorpSigningAmendments(linkID, corpEmail)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// notifyCorpSigningAmendment notifies the community and the corporation administrator
+// of the amendment according to its status. The page of amendment is generated, saved
+// and sent to the community when the amendment takes effect.
+func notifyCorpSigningAmendment(linkID string, orgInfo *models.OrgInfo, v *models.AmendedCorpSigning) {
+	amendment := &v.Amendment
+	signing := &v.Signing
+
+	d := email.CorpSigningAmendment{
+		Pending:          !v.IsApplied() && amendment.ReviewedAt == 0,
+		Rejected:         !v.IsApplied() && amendment.ReviewedAt != 0,
+		AdminName:        signing.AdminName,
+		Corporation:      signing.CorporationName,
+		Changes:          amendmentChanges(v),
+		Reason:           amendment.Reason,
+		Org:              orgInfo.OrgAlias,
+		ProjectURL:       orgInfo.ProjectURL(),
+		URLOfCLAPlatform: config.AppConfig.CLAPlatformURL,
+	}
+
+	if d.Pending {
+		sendEmailToIndividual(
+			linkID, orgInfo.OrgEmail,
+			fmt.Sprintf("Amendment of the CLA of %s is waiting for approval", signing.CorporationName),
+			d,
+		)
+		return
+	}
+
+	if v.IsApplied() {
+		sendCorpSigningAmendmentPDF(linkID, orgInfo, v, d)
+	}
+
+	d.ToAdmin = true
+	sendEmailToIndividual(
+		linkID, signing.AdminEmail,
+		fmt.Sprintf("Amendment of the CLA signed on project of \"%s\"", orgInfo.OrgAlias),
+		d,
+	)
+}
+
+func sendCorpSigningAmendmentPDF(linkID string, orgInfo *models.OrgInfo, v *models.AmendedCorpSigning, d email.CorpSigningAmendment) {
+	corpEmail := v.Signing.AdminEmail
+
+	data, err := pdf.GetPDFGenerator().GenPDFForCorpSigningAmendment(linkID, orgInfo, v)
+	if err != nil {
+		beego.Error(fmt.Sprintf("failed to generate amendment pdf, err: %s", err.Error()))
+		return
+	}
+
+	if merr := models.UploadCorpSigningAmendmentPDF(linkID, corpEmail, v.Amendment.ID, data); merr != nil {
+		beego.Error(merr)
+	}
+
+	path, err := genTempFilePath(linkID, corpEmail)
+	if err == nil {
+		err = ioutil.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		beego.Error(fmt.Sprintf("failed to save amendment pdf for email, err: %s", err.Error()))
+		return
+	}
+
+	msg, err := d.GenEmailMsg()
+	if err != nil {
+		os.Remove(path)
+		beego.Error(fmt.Sprintf("failed to generate email msg, err: %s", err.Error()))
+		return
+	}
+	msg.To = []string{orgInfo.OrgEmail}
+	msg.Subject = fmt.Sprintf("The contact information of the CLA of %s is amended", v.Signing.CorporationName)
+
+	worker.GetEmailWorker().SendMessageWithAttachment(linkID, path, msg)
+}
+
+// regenCorpSigningAmendmentPDF generates the page of the amendment which has taken
+// effect, saves it and writes it to the path.
+func regenCorpSigningAmendmentPDF(linkID, corpEmail, id string, orgInfo *models.OrgInfo, path string) *failedApiResult {
+	v, merr := models.GetAppliedCorpSigningAmendment(linkID, corpEmail, id)
+	if merr != nil {
+		return parseModelError(merr)
+	}
+
+	data, err := pdf.GetPDFGenerator().GenPDFForCorpSigningAmendment(linkID, orgInfo, v)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	if merr := models.UploadCorpSigningAmendmentPDF(linkID, corpEmail, id, data); merr != nil {
+		beego.Error(merr)
+	}
+
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+	return nil
+}
+
+// amendmentChanges returns the text of changed fields, one per line.
+func amendmentChanges(v *models.AmendedCorpSigning) string {
+	amendment := &v.Amendment
+	g := pdf.GetPDFGenerator()
+	lang := v.Signing.CLALanguage
+
+	r := make([]string, 0, len(amendment.Changes))
+	for _, item := range pdf.BuildCorpContact(v.Fields) {
+		value, ok := amendment.Changes[item.ID]
+		if !ok {
+			continue
+		}
+
+		if amendment.Previous == nil {
+			r = append(r, fmt.Sprintf("  %s: %s", item.Title, g.ContactValue(lang, &item, value)))
+		} else {
+			r = append(r, fmt.Sprintf(
+				"  %s: %s -> %s", item.Title,
+				g.ContactValue(lang, &item, amendment.Previous[item.ID]),
+				g.ContactValue(lang, &item, value),
+			))
+		}
+	}
+
+	return strings.Join(r, "\n")
+}
@@ -3,6 +3,9 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/astaxie/beego"
 
 	"github.com/opensourceways/app-cla-server/models"
 )
@@ -12,6 +15,19 @@ type CorporationManagerController struct {
 }
 
 func (this *CorporationManagerController) Prepare() {
+	rp := this.routerPattern()
+	if strings.HasSuffix(rp, "/oidc") {
+		// configure the identity provider of corporation
+		this.apiPrepare(PermissionCorpAdmin)
+		return
+	}
+	if strings.Contains(rp, "/oidc/") {
+		if !strings.HasSuffix(rp, "/oidc/callback") {
+			this.apiPrepare("")
+		}
+		return
+	}
+
 	switch this.apiRequestMethod() {
 	case http.MethodPut:
 		// add administrator
@@ -140,5 +156,17 @@ func (this *CorporationManagerController) Patch() {
 		return
 	}
 
+	// the personal access tokens and the other sessions of manager
+	// should be created again with the new password.
+	if merr := models.RevokePersonalAccessTokensOfCorpManagers(pl.LinkID, []string{pl.Email}); merr != nil {
+		beego.Error(merr)
+	}
+	if ac, fr := this.getAccessController(); fr == nil {
+		subject := models.SessionSubjectOfCorpManager(pl.LinkID, pl.Email)
+		if merr := models.RevokeSessions(subject, ac.TokenID); merr != nil {
+			beego.Error(merr)
+		}
+	}
+
 	this.sendSuccessResp("reset password successfully")
 }
@@ -27,16 +27,10 @@ func (this *CorporationPDFController) Prepare() {
 }
 
 func (this *CorporationPDFController) downloadCorpPDF(linkID, corpEmail string) *failedApiResult {
-	dir := util.GenFilePath(config.AppConfig.PDFOutDir, "tmp")
-	s := strings.ReplaceAll(util.EmailSuffix(corpEmail), ".", "_")
-	name := fmt.Sprintf("%s_%s_*.pdf", linkID, s)
-
-	f, err := ioutil.TempFile(dir, name)
+	path, err := genTempFilePath(linkID, corpEmail)
 	if err != nil {
 		return newFailedApiResult(500, errSystemError, err)
 	}
-	path := f.Name()
-	f.Close()
 
 	defer func() {
 		os.Remove(path)
@@ -59,6 +53,8 @@ func (this *CorporationPDFController) downloadCorpPDF(linkID, corpEmail string)
 // @Param	:org_cla_id	path 	string					true		"org cla id"
 // @Param	:email		path 	string					true		"email of corp"
 // @Success 204 {int} map
+// @Failure 400 unsigned:		the corporation has not signed
+// @Failure 400 counter_signed:	the pdf has been counter-signed and can't be replaced
 // @router /:link_id/:email [patch]
 func (this *CorporationPDFController) Upload() {
 	action := "upload corp's signing pdf"
@@ -83,13 +79,14 @@ func (this *CorporationPDFController) Upload() {
 	}
 	defer unlock()
 
-	b, merr := models.IsCorpSigned(linkID, corpEmail)
+	signing, merr := models.GetCorpSigningBasicInfo(linkID, corpEmail)
 	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
-	if !b {
-		this.sendFailedResponse(400, errUnsigned, fmt.Errorf("not signed"), action)
+	// the counter-signed pdf is based on the uploaded one, so it can't be replaced.
+	if signing.CounterSignature != nil {
+		this.sendFailedResponse(400, errCounterSigned, fmt.Errorf("counter-signed"), action)
 		return
 	}
 
@@ -107,6 +104,151 @@ func (this *CorporationPDFController) Upload() {
 	this.sendSuccessResp("upload pdf of signature page successfully")
 }
 
+// @Title Renew
+// @Description upload the pdf of renewed corporation signing. The pdf of previous period is
+// archived by the version of its valid_from and a new validity period starts.
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Success 204 {int} map
+// @Failure 400 unsigned:     the corporation has not signed
+// @Failure 500 system_error: system error
+// @router /:link_id/:email/renewal [post]
+func (this *CorporationPDFController) Renew() {
+	action := "upload pdf of renewed corp signing"
+	linkID := this.GetString(":link_id")
+	corpEmail := this.GetString(":email")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	// lock to avoid conflict with deleting corp signing
+	unlock, fr := lockOnRepo(pl.orgInfo(linkID))
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	signing, merr := models.GetCorpSigningBasicInfo(linkID, corpEmail)
+	if merr != nil {
+		if merr.IsErrorOf(models.ErrUnsigned) {
+			this.sendFailedResponse(400, errUnsigned, merr, action)
+		} else {
+			this.sendModelErrorAsResp(merr, action)
+		}
+		return
+	}
+
+	data, fr := this.readInputFile("pdf", config.AppConfig.MaxSizeOfCorpCLAPDF)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := archiveCorpPDF(linkID, signing); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.UploadCorporationSigningPDF(linkID, corpEmail, data); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := models.RenewCorpSigning(linkID, signing, pl.User); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("renew corp signing successfully")
+}
+
+// archiveCorpPDF keeps the pdf of current validity period. Nothing is
+// archived if the pdf has not been uploaded.
+func archiveCorpPDF(linkID string, signing *models.CorporationSigningBasicInfo) *failedApiResult {
+	path, err := genTempFilePath(linkID, signing.AdminEmail)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	if merr := models.DownloadCorporationSigningPDF(linkID, signing.AdminEmail, path); merr != nil {
+		if merr.IsErrorOf(models.ErrNoLinkOrUnuploaed) {
+			return nil
+		}
+		return parseModelError(merr)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	merr := models.UploadArchivedCorporationSigningPDF(
+		linkID, signing.AdminEmail, models.ArchiveVersionOfCorpSigning(signing), data,
+	)
+	return parseModelError(merr)
+}
+
+// @Title DownloadArchived
+// @Description download the pdf of corporation signing which was replaced by the renewal
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Param	:version	path 	string		true		"previous_valid_from of the renewal"
+// @Success 200 {int} map
+// @Failure 400 unuploaded:   the pdf does not exist
+// @Failure 500 system_error: system error
+// @router /:link_id/:email/archived/:version [get]
+func (this *CorporationPDFController) DownloadArchived() {
+	action := "download archived corp's signing pdf"
+	linkID := this.GetString(":link_id")
+	corpEmail := this.GetString(":email")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	path, err := genTempFilePath(linkID, corpEmail)
+	if err != nil {
+		this.sendFailedResponse(500, errSystemError, err, action)
+		return
+	}
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	merr := models.DownloadArchivedCorporationSigningPDF(
+		linkID, corpEmail, this.GetString(":version"), path,
+	)
+	if merr != nil {
+		if merr.IsErrorOf(models.ErrNoLinkOrUnuploaed) {
+			this.sendFailedResponse(400, errUnuploaded, merr, action)
+		} else {
+			this.sendModelErrorAsResp(merr, action)
+		}
+		return
+	}
+
+	this.downloadFile(path)
+}
+
 // @Title Download
 // @Description download pdf of corporation signing
 // @Param	:org_cla_id	path 	string					true		"org cla id"
@@ -152,10 +294,15 @@ func (this *CorporationPDFController) Review() {
 }
 
 // @Title Preview
-// @Description preview the unsinged pdf of corp
-// @Param	:org_cla_id	path 	string					true		"org cla id"
+// @Description preview the unsinged pdf of corp with the layout of link, or with the layout in body by POST
+// @Param	:link_id	path 	string					true		"link id"
+// @Param	:language	path 	string					true		"cla language"
+// @Param	layout		formData 	file					false		"layout template in yaml which is not saved"
+// @Param	logo		formData 	file					false		"logo in png or jpeg"
+// @Param	remove_logo	formData 	bool					false		"don't use the saved logo if no logo is uploaded"
 // @Success 200 {int} map
-// @router /preview/:linkID/:language [get]
+// @Failure 400 invalid_pdf_layout:	the layout or logo is invalid
+// @router /preview/:link_id/:language [get,post]
 func (this *CorporationPDFController) Preview() {
 	action := "preview blank pdf"
 	linkID := this.GetString(":link_id")
@@ -197,14 +344,28 @@ func (this *CorporationPDFController) Preview() {
 
 	signing := models.CorporationSigning{
 		CorporationSigningBasicInfo: dbmodels.CorporationSigningBasicInfo{
-			AdminEmail: "test@preview_blank_pdf.com",
-			Date:       util.Date(),
+			CLALanguage: claLang,
+			AdminEmail:  "test@preview_blank_pdf.com",
+			Date:        util.Date(),
 		},
 		Info: dbmodels.TypeSigningInfo(value),
 	}
 
-	outFile, err := pdf.GetPDFGenerator().GenPDFForCorporationSigning(
-		linkID, orgSignatureFile, claFile, orgInfo, &signing, claInfo.Fields)
+	var outFile string
+	var err error
+	if this.isPostRequest() {
+		layout, logo, fr := this.readPDFLayout(linkID)
+		if fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+
+		outFile, err = pdf.GetPDFGenerator().PreviewCorporationSigning(
+			layout, logo, linkID, orgSignatureFile, claFile, orgInfo, &signing, claInfo)
+	} else {
+		outFile, err = pdf.GetPDFGenerator().GenPDFForCorporationSigning(
+			linkID, orgSignatureFile, claFile, orgInfo, &signing, claInfo)
+	}
 	if err != nil {
 		this.sendFailedResponse(400, errSystemError, err, action)
 		return
@@ -213,3 +374,134 @@ func (this *CorporationPDFController) Preview() {
 	defer func() { os.Remove(outFile) }()
 	this.downloadFile(outFile)
 }
+
+// @Title UploadLayout
+// @Description upload the layout of corporation signing pdf
+// @Param	:link_id	path 	string					true		"link id"
+// @Param	layout		formData 	file					true		"layout template in yaml"
+// @Param	logo		formData 	file					false		"logo in png or jpeg, the saved one is kept if it is not uploaded"
+// @Param	remove_logo	formData 	bool					false		"remove the saved logo if no logo is uploaded"
+// @Success 201 {int} map
+// @Failure 400 invalid_pdf_layout:	the layout or logo is invalid
+// @router /layout/:link_id [put]
+func (this *CorporationPDFController) UploadLayout() {
+	action := "upload layout of corp's signing pdf"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	layout, logo, fr := this.readPDFLayout(linkID)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.SavePDFLayout(linkID, layout, logo); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("upload layout of pdf successfully")
+}
+
+// @Title GetLayout
+// @Description get the layout of corporation signing pdf
+// @Param	:link_id	path 	string					true		"link id"
+// @Success 200 {object} dbmodels.PDFLayout
+// @Failure 400 no_pdf_layout:	the layout is not customized
+// @router /layout/:link_id [get]
+func (this *CorporationPDFController) GetLayout() {
+	action := "get layout of corp's signing pdf"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, merr := models.GetPDFLayout(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(map[string]interface{}{
+		"layout":     v.Layout,
+		"has_logo":   len(v.Logo) > 0,
+		"updated_at": v.UpdatedAt,
+	})
+}
+
+// @Title DeleteLayout
+// @Description restore the default layout of corporation signing pdf
+// @Param	:link_id	path 	string					true		"link id"
+// @Success 204 {int} map
+// @router /layout/:link_id [delete]
+func (this *CorporationPDFController) DeleteLayout() {
+	action := "delete layout of corp's signing pdf"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.DeletePDFLayout(linkID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("restore the default layout of pdf successfully")
+}
+
+// readPDFLayout reads the uploaded layout and logo, and validates them. The saved
+// logo is used if no logo is uploaded, unless it is asked to be removed.
+func (this *CorporationPDFController) readPDFLayout(linkID string) ([]byte, []byte, *failedApiResult) {
+	layout, fr := this.readUploadedFile(fileNameOfUploadingPDFLayout, maxSizeOfPDFLayout)
+	if fr != nil {
+		return nil, nil, fr
+	}
+
+	var logo []byte
+	if f, _, err := this.GetFile(fileNameOfUploadingPDFLogo); err == nil {
+		f.Close()
+
+		if logo, fr = this.readUploadedFile(
+			fileNameOfUploadingPDFLogo, config.AppConfig.MaxSizeOfPDFLogo,
+		); fr != nil {
+			return nil, nil, fr
+		}
+	} else if remove, _ := this.GetBool(fieldOfRemovingPDFLogo); !remove {
+		v, merr := models.GetPDFLayout(linkID)
+		if merr != nil && !merr.IsErrorOf(models.ErrNoPDFLayout) {
+			return nil, nil, parseModelError(merr)
+		}
+		if v != nil {
+			logo = v.Logo
+		}
+	}
+
+	if err := pdf.GetPDFGenerator().ValidateLayout(layout, logo); err != nil {
+		return nil, nil, newFailedApiResult(400, errInvalidPDFLayout, err)
+	}
+
+	return layout, logo, nil
+}
@@ -17,6 +17,8 @@ type CorporationSigningController struct {
 func (this *CorporationSigningController) Prepare() {
 	if strings.HasSuffix(this.routerPattern(), ":cla_hash") {
 		this.apiPrepare("")
+	} else if strings.HasSuffix(this.routerPattern(), "/renewal") {
+		this.apiPrepare(PermissionCorpAdmin)
 	} else {
 		// not signing
 		this.apiPrepare(PermissionOwnerOfOrg)
@@ -38,6 +40,11 @@ func (this *CorporationSigningController) Prepare() {
 // @Failure 405 no_link:                    the link id is not exists
 // @Failure 406 unmatched_cla:              the cla hash is not equal to the one of backend server
 // @Failure 407 resigned:                   the signer has signed the cla
+// @Failure 408 invalid_signing_info:       some values of signing info are invalid, see error_details
+// @Failure 409 signing_closed:             the link is suspended
+// @Failure 410 dco_link:                   the link is in dco mode
+// @Failure 429 verification_code_locked:   too many failed attempts on the verification code
+// @Failure 429 client_ip_locked:           too many failed attempts from the client ip
 // @Failure 500 system_error:               system error
 // @router /:link_id/:cla_lang/:cla_hash [post]
 func (this *CorporationSigningController) Post() {
@@ -52,18 +59,30 @@ func (this *CorporationSigningController) Post() {
 	}
 	info.CLALanguage = claLang
 
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := checkAuthAttempts(models.NewAuthAttemptOfIP(ip)); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
 	if err := (&info).Validate(linkID); err != nil {
-		this.sendModelErrorAsResp(err, action)
+		this.sendModelErrorAsResp(
+			onVerificationCodeError(linkID, info.AdminEmail, ip, err), action,
+		)
 		return
 	}
 
-	orgInfo, merr := models.GetOrgOfLink(linkID)
-	if merr != nil {
-		this.sendModelErrorAsResp(merr, action)
+	orgInfo, fr := getOrgOfLinkForSigning(linkID)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
 		return
 	}
 
-	fr := signHelper(
+	fr = signHelper(
 		linkID, claLang, dbmodels.ApplyToCorporation,
 		func(claInfo *models.CLAInfo) *failedApiResult {
 			if claInfo.CLAHash != this.GetString(":cla_hash") {
@@ -76,7 +95,11 @@ func (this *CorporationSigningController) Post() {
 				return fr
 			}
 
-			info.Info = getSingingInfo(info.Info, claInfo.Fields)
+			signingInfo, fr := checkSigningInfo(info.Info, claInfo.Fields)
+			if fr != nil {
+				return fr
+			}
+			info.Info = signingInfo
 
 			if err := (&info).Create(linkID); err != nil {
 				if err.IsErrorOf(models.ErrNoLinkOrResigned) {
@@ -87,7 +110,7 @@ func (this *CorporationSigningController) Post() {
 
 			worker.GetEmailWorker().GenCLAPDFForCorporationAndSendIt(
 				linkID, orgSignatureFile, claFile, *orgInfo,
-				info.CorporationSigning, claInfo.Fields,
+				info.CorporationSigning, claInfo,
 			)
 
 			return nil
@@ -197,29 +220,75 @@ func (this *CorporationSigningController) ResendCorpSigningEmail() {
 		return
 	}
 
+	if fr := sendCorpSigningPDF(linkID, corpEmail, pl.orgInfo(linkID), ""); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.sendSuccessResp("resend email successfully")
+}
+
+// @Title Renew
+// @Description the corporation administrator requests to renew the signing. The pdf
+// dated today is sent to the administrator, which should be signed and sent back to
+// the community. The renewal is completed after the community uploads it.
+// @Success 202 {int} map
+// @Failure 400 unsigned:     the corporation has not signed
+// @Failure 500 system_error: system error
+// @router /renewal [post]
+func (this *CorporationSigningController) Renew() {
+	action := "request to renew corp signing"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := sendCorpSigningPDF(pl.LinkID, pl.Email, &pl.OrgInfo, util.Date()); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.sendSuccessResp("the pdf for renewal has been sent")
+}
+
+// sendCorpSigningPDF regenerates the pdf of corporation signing and sends it to the
+// corporation administrator. The pdf is dated by the date of signing if date is empty.
+func sendCorpSigningPDF(linkID, corpEmail string, orgInfo *models.OrgInfo, date string) *failedApiResult {
 	fields, signingInfo, merr := models.GetCorpSigningDetail(linkID, corpEmail)
 	if merr != nil {
-		this.sendModelErrorAsResp(merr, action)
-		return
+		return parseModelError(merr)
 	}
 	if fields == nil {
-		this.sendFailedResponse(400, errUnsigned, fmt.Errorf("no data"), action)
-		return
+		return newFailedApiResult(400, errUnsigned, fmt.Errorf("no data"))
+	}
+
+	claInfo, merr := models.GetCLAInfoSigned(linkID, signingInfo.CLALanguage, dbmodels.ApplyToCorporation)
+	if merr != nil {
+		return parseModelError(merr)
+	}
+	if claInfo == nil {
+		claInfo = &models.CLAInfo{Fields: fields}
+	}
+
+	if date != "" {
+		signingInfo.Date = date
 	}
 
 	claFile := genCLAFilePath(linkID, dbmodels.ApplyToCorporation, signingInfo.CLALanguage)
 	orgSignatureFile := genOrgSignatureFilePath(linkID, signingInfo.CLALanguage)
 
 	worker.GetEmailWorker().GenCLAPDFForCorporationAndSendIt(
-		linkID, orgSignatureFile, claFile, *pl.orgInfo(linkID),
+		linkID, orgSignatureFile, claFile, *orgInfo,
 		models.CorporationSigning{
 			CorporationSigningBasicInfo: signingInfo.CorporationSigningBasicInfo,
 			Info:                        signingInfo.Info,
 		},
-		fields,
+		claInfo,
 	)
 
-	this.sendSuccessResp("resend email successfully")
+	return nil
 }
 
 type corpsSigningResult struct {
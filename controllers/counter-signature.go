@@ -0,0 +1,374 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/pdf"
+	"github.com/opensourceways/app-cla-server/util"
+	"github.com/opensourceways/app-cla-server/worker"
+)
+
+type CounterSignatureController struct {
+	baseController
+}
+
+func (this *CounterSignatureController) Prepare() {
+	if strings.HasSuffix(this.routerPattern(), "/") {
+		// admin downloads the fully executed pdf
+		this.apiPrepare(PermissionCorpAdmin)
+	} else {
+		this.apiPrepare(PermissionOwnerOfOrg)
+	}
+}
+
+// @Title SaveSignatories
+// @Description save the signatories who are authorised to counter-sign corporation CLA
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	models.Signatories		true		"body for signatories"
+// @Success 202 {int} map
+// @Failure 400 invalid_signatory:	the signatory is invalid
+// @router /signatories/:link_id [put]
+func (this *CounterSignatureController) SaveSignatories() {
+	action := "save signatories"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var v models.Signatories
+	if fr := this.fetchInputPayload(&v); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := v.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := v.Save(linkID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("save signatories successfully")
+}
+
+// @Title GetSignatories
+// @Description get the signatories who are authorised to counter-sign corporation CLA
+// @Param	:link_id	path 	string				true		"link id"
+// @Success 200 {object} models.Signatories
+// @router /signatories/:link_id [get]
+func (this *CounterSignatureController) GetSignatories() {
+	action := "get signatories"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, merr := models.GetSignatories(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if v == nil {
+		v = models.Signatories{}
+	}
+	this.sendSuccessResp(v)
+}
+
+// @Title CounterSign
+// @Description the signatory of community counter-signs the pdf uploaded by corporation
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Param	signature	formData	file	false		"image of signature"
+// @Success 201 {int} map
+// @Failure 400 not_signatory:		the user is not the signatory of community
+// @Failure 401 unuploaded:			the pdf of corporation signing is not uploaded
+// @Failure 402 counter_signed:		the corporation signing has been counter-signed
+// @Failure 403 invalid_corp_pdf:		the pdf uploaded can't be counter-signed
+// @Failure 404 invalid_signature_image:	the image of signature is invalid
+// @router /:link_id/:email [post]
+func (this *CounterSignatureController) CounterSign() {
+	action := "counter-sign corp's signing pdf"
+	linkID := this.GetString(":link_id")
+	corpEmail := this.GetString(":email")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	orgInfo := pl.orgInfo(linkID)
+
+	signatory, merr := models.GetSignatory(linkID, pl.User)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	// lock to avoid conflict with deleting corp signing
+	unlock, fr := lockOnRepo(orgInfo)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	signing, merr := models.GetCorpSigningBasicInfo(linkID, corpEmail)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if signing.CounterSignature != nil {
+		this.sendFailedResponse(400, errCounterSigned, fmt.Errorf("counter-signed"), action)
+		return
+	}
+
+	signature, fr := this.readSignatureImage()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	doc, fr := this.readCorpPDF(linkID, corpEmail)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	digest := sha256.Sum256(doc)
+	cs := models.CounterSignature{
+		Signatory: signatory.ID,
+		Name:      signatory.Name,
+		Title:     signatory.Title,
+		Date:      util.Date(),
+		Digest:    hex.EncodeToString(digest[:]),
+	}
+
+	data, err := pdf.GetPDFGenerator().CounterSignCorporationPDF(
+		linkID, orgInfo, signing, &cs, signature, doc,
+	)
+	if err != nil {
+		this.sendFailedResponse(500, errSystemError, err, action)
+		return
+	}
+
+	// record it first, so only the request which wins can save the pdf.
+	if merr := models.CounterSignCorpCLA(linkID, corpEmail, &cs); merr != nil {
+		if merr.IsErrorOf(models.ErrNoLinkOrCounterSigned) {
+			this.sendFailedResponse(400, errCounterSigned, merr, action)
+		} else {
+			this.sendModelErrorAsResp(merr, action)
+		}
+		return
+	}
+
+	if merr := models.UploadCounterSignedPDF(linkID, corpEmail, data); merr != nil {
+		if err := models.CancelCounterSignature(linkID, corpEmail, &cs); err != nil {
+			beego.Error(fmt.Sprintf(
+				"failed to cancel the counter-signature of %s/%s, err: %s",
+				linkID, corpEmail, err.Error(),
+			))
+		}
+
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("counter-sign successfully")
+
+	this.notifyCorpAdmin(linkID, orgInfo, signing, &cs, data)
+}
+
+// @Title Download
+// @Description download the fully executed pdf of corporation signing
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of corp"
+// @Success 200 {int} map
+// @Failure 400 not_counter_signed:	the corporation signing is not counter-signed
+// @router /:link_id/:email [get]
+func (this *CounterSignatureController) Download() {
+	action := "download counter-signed pdf"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := this.downloadCounterSignedPDF(linkID, this.GetString(":email")); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+// @Title Review
+// @Description corp administrator downloads the fully executed pdf of corporation signing
+// @Success 200 {int} map
+// @Failure 400 not_counter_signed:	the corporation signing is not counter-signed
+// @router / [get]
+func (this *CounterSignatureController) Review() {
+	action := "download counter-signed pdf"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := this.downloadCounterSignedPDF(pl.LinkID, pl.Email); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+func (this *CounterSignatureController) readSignatureImage() ([]byte, *failedApiResult) {
+	f, _, err := this.GetFile(fileNameOfSignatureImage)
+	if err != nil {
+		// the name of signatory will be printed instead
+		return nil, nil
+	}
+	f.Close()
+
+	data, fr := this.readUploadedFile(
+		fileNameOfSignatureImage, config.AppConfig.MaxSizeOfSignatureImage,
+	)
+	if fr != nil {
+		return nil, fr
+	}
+
+	if err := pdf.CheckSignatureImage(data); err != nil {
+		return nil, newFailedApiResult(400, errInvalidSignatureImage, err)
+	}
+
+	return data, nil
+}
+
+func (this *CounterSignatureController) readCorpPDF(linkID, corpEmail string) ([]byte, *failedApiResult) {
+	path, err := genTempFilePath(linkID, corpEmail)
+	if err != nil {
+		return nil, newFailedApiResult(500, errSystemError, err)
+	}
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	if merr := models.DownloadCorporationSigningPDF(linkID, corpEmail, path); merr != nil {
+		if merr.IsErrorOf(models.ErrNoLinkOrUnuploaed) {
+			return nil, newFailedApiResult(400, errUnuploaded, merr)
+		}
+		return nil, parseModelError(merr)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, newFailedApiResult(500, errSystemError, err)
+	}
+
+	if err := pdf.CheckCorpPDF(data); err != nil {
+		return nil, newFailedApiResult(400, errInvalidCorpPDF, err)
+	}
+
+	return data, nil
+}
+
+func (this *CounterSignatureController) downloadCounterSignedPDF(linkID, corpEmail string) *failedApiResult {
+	path, err := genTempFilePath(linkID, corpEmail)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	if merr := models.DownloadCounterSignedPDF(linkID, corpEmail, path); merr != nil {
+		return parseModelError(merr)
+	}
+
+	this.downloadFile(path)
+	return nil
+}
+
+func (this *CounterSignatureController) notifyCorpAdmin(
+	linkID string, orgInfo *models.OrgInfo, signing *models.CorporationSigningBasicInfo,
+	cs *models.CounterSignature, data []byte,
+) {
+	path, err := genTempFilePath(linkID, signing.AdminEmail)
+	if err == nil {
+		err = ioutil.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		beego.Error(fmt.Sprintf("failed to save counter-signed pdf for email, err: %s", err.Error()))
+		return
+	}
+
+	d := email.CounterSigning{
+		AdminName:   signing.AdminName,
+		Corporation: signing.CorporationName,
+		Signatory:   cs.Name,
+		Date:        cs.Date,
+		Digest:      cs.Digest,
+		Org:         orgInfo.OrgAlias,
+		ProjectURL:  orgInfo.ProjectURL(),
+	}
+	msg, err := d.GenEmailMsg()
+	if err != nil {
+		os.Remove(path)
+		beego.Error(fmt.Sprintf("failed to generate email msg, err: %s", err.Error()))
+		return
+	}
+	msg.To = []string{signing.AdminEmail}
+	msg.Subject = fmt.Sprintf("The CLA of %s is counter-signed by %s", signing.CorporationName, orgInfo.OrgAlias)
+
+	worker.GetEmailWorker().SendMessageWithAttachment(linkID, path, msg)
+}
+
+// genTempFilePath creates an empty temporary file for the pdf of corporation signing.
+func genTempFilePath(linkID, corpEmail string) (string, error) {
+	dir := util.GenFilePath(config.AppConfig.PDFOutDir, "tmp")
+	s := strings.ReplaceAll(util.EmailSuffix(corpEmail), ".", "_")
+
+	f, err := ioutil.TempFile(dir, fmt.Sprintf("%s_%s_*.pdf", linkID, s))
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	f.Close()
+
+	return path, nil
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/dco"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+const maxNumOfCommitsToCheck = 250
+
+type DCOController struct {
+	baseController
+}
+
+func (this *DCOController) Prepare() {
+	this.apiPrepare("")
+}
+
+type dcoCheckOption struct {
+	Commits []dco.Commit `json:"commits"`
+}
+
+// @Title Check
+// @Description check whether the commits are signed off by their authors
+// @Param	:platform	path 	string				true		"code platform"
+// @Param	:org_repo	path 	string				true		"org:repo"
+// @Param	body		body 	controllers.dcoCheckOption	true		"body for commits"
+// @Success 200 {object} map
+// @Failure 400 no_link:		there is not link for this org and repo
+// @Failure 401 not_dco_link:		the link is in cla mode, check the signing instead
+// @Failure 402 too_many_commits:	the number of commits exceeds the limit
+// @Failure 500 system_error:		system error
+// @router /:platform/:org_repo [post]
+func (this *DCOController) Check() {
+	action := "check dco"
+	org, repo := parseOrgAndRepo(this.GetString(":org_repo"))
+
+	var input dcoCheckOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if n := len(input.Commits); n == 0 || n > maxNumOfCommitsToCheck {
+		this.sendFailedResponse(
+			400, errTooManyCommits,
+			fmt.Errorf("the number of commits should be between 1 and %d", maxNumOfCommitsToCheck),
+			action,
+		)
+		return
+	}
+
+	link, merr := models.ResolveLink(buildOrgRepo(this.GetString(":platform"), org, repo))
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if !link.IsDCO() {
+		this.sendFailedResponse(400, errNotDCOLink, fmt.Errorf("check signing instead"), action)
+		return
+	}
+
+	if link.IsSuspended() {
+		this.sendSuccessResp(map[string]bool{
+			"signed":    link.Suspension.CheckResult,
+			"suspended": true,
+		})
+		return
+	}
+
+	r := dco.CheckCommits(input.Commits)
+
+	this.sendSuccessResp(struct {
+		Signed   bool         `json:"signed"`
+		Unsigned []dco.Result `json:"unsigned_commits,omitempty"`
+	}{
+		Signed:   len(r) == 0,
+		Unsigned: r,
+	})
+}
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+type EmployeeAllowListController struct {
+	baseController
+}
+
+func (this *EmployeeAllowListController) Prepare() {
+	this.apiPrepare(PermissionCorpAdmin)
+}
+
+// @Title GetAll
+// @Description list the employees on the allow-list of corporation
+// @Success 200 {object} dbmodels.AllowedEmployeeInfo
+// @Failure 400 missing_token:      token is missing
+// @Failure 401 unknown_token:      token is unknown
+// @Failure 402 expired_token:      token is expired
+// @Failure 403 unauthorized_token: the permission of token is unmatched
+// @Failure 500 system_error:       system error
+// @router / [get]
+func (this *EmployeeAllowListController) GetAll() {
+	action := "list employee allow-list"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	r, merr := models.ListAllowedEmployees(pl.LinkID, pl.Email)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(r)
+}
+
+// @Title Add
+// @Description add employee emails, logins of code platform or domains to the allow-list
+// @Param	body		body 	models.AllowListOption	true		"body for allow-list"
+// @Success 201 {string} "add successfully"
+// @Failure 400 error_parsing_api_body:     parse payload of request failed
+// @Failure 401 invalid_allow_list:         the allow-list is invalid
+// @Failure 500 system_error:               system error
+// @router / [post]
+func (this *EmployeeAllowListController) Add() {
+	action := "add employee allow-list"
+
+	var opt models.AllowListOption
+	if fr := this.fetchInputPayload(&opt); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.add(&opt, action)
+}
+
+// @Title AddByCSV
+// @Description upload the allow-list in csv
+// @Param	allow_list	formData 	file	true	"csv file of allow-list"
+// @Success 201 {string} "add successfully"
+// @Failure 400 too_big_file:               the file is too big
+// @Failure 401 invalid_allow_list:         the allow-list is invalid
+// @Failure 500 system_error:               system error
+// @router /csv [post]
+func (this *EmployeeAllowListController) AddByCSV() {
+	action := "upload employee allow-list"
+
+	data, fr := this.readUploadedFile(fileNameOfAllowList, maxSizeOfAllowList)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	opt, merr := models.ParseAllowListCSV(data)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.add(&opt, action)
+}
+
+func (this *EmployeeAllowListController) add(opt *models.AllowListOption, action string) {
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := opt.Validate(pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := opt.Add(pl.LinkID, pl.Email, pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("add successfully")
+}
+
+// @Title Delete
+// @Description remove entries from the allow-list. The signings of employees which are
+// no longer on the allow-list will be inactivated and the employees will be notified.
+// @Param	body		body 	models.AllowListOption	true		"body for allow-list"
+// @Success 204 {string} "remove successfully"
+// @Failure 400 error_parsing_api_body:     parse payload of request failed
+// @Failure 401 invalid_allow_list:         the allow-list is invalid
+// @Failure 500 system_error:               system error
+// @router / [delete]
+func (this *EmployeeAllowListController) Delete() {
+	action := "remove employee allow-list"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var opt models.AllowListOption
+	if fr := this.fetchInputPayload(&opt); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := opt.Validate(pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := opt.Remove(pl.LinkID, pl.Email); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("remove successfully")
+
+	this.inactivateEmployees(pl, &opt)
+}
+
+// inactivateEmployees inactivates the employees which were activated by the allow-list,
+// are matched by the removed entries and are not matched by the remaining allow-list.
+// The signings enabled by the managers are kept.
+func (this *EmployeeAllowListController) inactivateEmployees(pl *acForCorpManagerPayload, removed *models.AllowListOption) {
+	remaining, merr := models.GetAllowList(pl.LinkID, pl.Email)
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	signings, merr := models.ListIndividualSigning(pl.LinkID, pl.Email, "")
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	info := models.EmployeeSigningUdateInfo{Enabled: false}
+	for i := range signings {
+		item := &signings[i]
+
+		if !item.Enabled || !item.ActivatedByAllowList || !removed.IsAllowed(item.Email, item.ID) ||
+			remaining.IsAllowed(item.Email, item.ID) {
+			continue
+		}
+
+		if merr := info.Update(pl.LinkID, item.Email); merr != nil {
+			beego.Error(merr)
+			continue
+		}
+
+		msg := newEmployeeNotification(pl, item.Email)
+		msg.Inactive = true
+		sendEmailToIndividual(pl.LinkID, item.Email, "Inactivate CLA signing", msg)
+	}
+}
@@ -0,0 +1,289 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	permissionEmployeeApproval = "employee approval"
+
+	errEmployeeApprovalDisabled = "employee_approval_disabled"
+	errInvalidApprovalToken     = "invalid_approval_token"
+	errNotPendingEmployee       = "not_pending_employee"
+)
+
+// employeeApproval is the payload of the one-click link sent to an employee manager.
+// SigningID binds the link to the signing, so it can't be used on a signing of
+// the employee signed again.
+type employeeApproval struct {
+	LinkID    string `json:"link_id"`
+	Employee  string `json:"employee"`
+	SigningID string `json:"signing_id"`
+	Manager   string `json:"manager"`
+	Approved  bool   `json:"approved"`
+}
+
+// newEmployeeApprovalToken signs the token of the one-click link. It can't be
+// used as an api token since its permission is unknown to apis.
+func newEmployeeApprovalToken(v *employeeApproval) (string, error) {
+	ac := &accessController{
+		Expiry:     util.Expiry(config.AppConfig.EmployeeApproval.LinkExpiry),
+		Permission: permissionEmployeeApproval,
+		Payload:    v,
+	}
+
+	return ac.newToken(config.AppConfig.APITokenKey)
+}
+
+func parseEmployeeApprovalToken(token string) (*employeeApproval, error) {
+	if token == "" {
+		return nil, fmt.Errorf("missing token")
+	}
+
+	v := new(employeeApproval)
+	ac := &accessController{Payload: v}
+	if err := ac.parseToken(token, config.AppConfig.APITokenKey); err != nil {
+		return nil, err
+	}
+
+	if ac.Permission != permissionEmployeeApproval {
+		return nil, fmt.Errorf("unmatched token")
+	}
+
+	if ac.isTokenExpired() {
+		return nil, fmt.Errorf("token is expired")
+	}
+
+	return v, nil
+}
+
+// genEmployeeApprovalURLs generates the links to approve and reject the signing
+// of employee for the manager. They are empty if the approval is disabled.
+func genEmployeeApprovalURLs(linkID, employee, signingID, manager string) (string, string, error) {
+	cfg := &config.AppConfig.EmployeeApproval
+	if !cfg.Enabled() {
+		return "", "", nil
+	}
+
+	v := employeeApproval{
+		LinkID:    linkID,
+		Employee:  employee,
+		SigningID: signingID,
+		Manager:   manager,
+	}
+
+	urls := [2]string{}
+	for i, approved := range []bool{true, false} {
+		v.Approved = approved
+
+		token, err := newEmployeeApprovalToken(&v)
+		if err != nil {
+			return "", "", err
+		}
+		urls[i] = fmt.Sprintf("%s?token=%s", cfg.URL, token)
+	}
+
+	return urls[0], urls[1], nil
+}
+
+func employeeApprovalExpiryHours() int64 {
+	return (config.AppConfig.EmployeeApproval.LinkExpiry + 3599) / 3600
+}
+
+type EmployeeApprovalController struct {
+	baseController
+}
+
+func (this *EmployeeApprovalController) Prepare() {
+	// the request is authenticated by the approval token
+	this.apiPrepare("")
+}
+
+// @Title Get
+// @Description the one-click link to approve or reject the signing of employee.
+// It only redirects the manager to the web page of confirmation, because the links
+// in emails may be opened by the scanners of mail. The decision takes effect on Post.
+// @Param	token	query 	string	true	"approval token"
+// @Failure 400 employee_approval_disabled:  the approval by link is disabled
+// @Failure 401 invalid_approval_token:      the token is invalid or expired
+// @router / [get]
+func (this *EmployeeApprovalController) Get() {
+	cfg := &config.AppConfig.EmployeeApproval
+
+	rs := func(errCode string, reason error) {
+		this.setCookies(map[string]string{"error_code": errCode, "error_msg": reason.Error()}, false)
+		this.redirect(cfg.WebRedirectDirOnFailure)
+	}
+
+	if !cfg.Enabled() {
+		rs(errEmployeeApprovalDisabled, fmt.Errorf("employee approval is disabled"))
+		return
+	}
+
+	token := this.GetString("token")
+	v, err := parseEmployeeApprovalToken(token)
+	if err != nil {
+		rs(errInvalidApprovalToken, err)
+		return
+	}
+
+	this.setCookies(
+		map[string]string{
+			"approval_token":   token,
+			"employee":         v.Employee,
+			"approved":         fmt.Sprint(v.Approved),
+			"reauthentication": fmt.Sprint(cfg.Reauthentication),
+		}, false,
+	)
+	this.redirect(cfg.WebRedirectDirOfConfirmation)
+}
+
+type employeeApprovalOption struct {
+	Token string `json:"approval_token"`
+	// Password is required if the reauthentication is enabled.
+	Password string `json:"password"`
+}
+
+// @Title Post
+// @Description approve or reject the signing of employee after the manager confirms it
+// @Param	body		body 	controllers.employeeApprovalOption	true		"body for approval"
+// @Success 202 {int} map
+// @Failure 400 error_parsing_api_body:      parse payload of request failed
+// @Failure 401 employee_approval_disabled:  the approval by link is disabled
+// @Failure 402 invalid_approval_token:      the token is invalid or expired
+// @Failure 403 wrong_id_or_pw:              the password is wrong
+// @Failure 404 corp_manager_does_not_exist: the manager has been removed
+// @Failure 405 not_pending_employee:        the signing has been approved or rejected
+// @Failure 429 account_locked:              too many failed attempts on the account
+// @Failure 429 client_ip_locked:            too many failed attempts from the client ip
+// @Failure 500 system_error:                system error
+// @router / [post]
+func (this *EmployeeApprovalController) Post() {
+	action := "approve employee signing"
+	cfg := &config.AppConfig.EmployeeApproval
+
+	if !cfg.Enabled() {
+		this.sendFailedResponse(
+			400, errEmployeeApprovalDisabled, fmt.Errorf("employee approval is disabled"), action,
+		)
+		return
+	}
+
+	var input employeeApprovalOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, err := parseEmployeeApprovalToken(input.Token)
+	if err != nil {
+		this.sendFailedResponse(400, errInvalidApprovalToken, err, action)
+		return
+	}
+
+	if cfg.Reauthentication {
+		if fr := this.reauthenticate(v, input.Password); fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+	}
+
+	if fr := approveEmployee(v); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if v.Approved {
+		this.sendSuccessResp("approve employee successfully")
+	} else {
+		this.sendSuccessResp("reject employee successfully")
+	}
+}
+
+func (this *EmployeeApprovalController) reauthenticate(v *employeeApproval, password string) *failedApiResult {
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		return fr
+	}
+
+	accountAttempt := models.NewAuthAttemptOfAccount(v.Manager)
+	if fr := checkAuthAttempts(models.NewAuthAttemptOfIP(ip), accountAttempt); fr != nil {
+		return fr
+	}
+
+	info := models.CorporationManagerAuthentication{User: v.Manager, Password: password}
+	r, merr := info.Authenticate()
+	if merr != nil {
+		return parseModelError(merr)
+	}
+	if _, ok := r[v.LinkID]; !ok {
+		if merr := onAuthFailureOfCorpManager(ip, v.Manager); merr != nil {
+			return parseModelError(merr)
+		}
+		return newFailedApiResult(400, errWrongIDOrPassword, fmt.Errorf("wrong id or pw"))
+	}
+
+	accountAttempt.Succeed()
+	return nil
+}
+
+// approveEmployee activates or deletes the pending signing of employee and notifies the employee.
+// The signing is resolved only if it is still pending, so the link can't be used again
+// after the signing has been approved, rejected, enabled or disabled.
+func approveEmployee(v *employeeApproval) *failedApiResult {
+	managers, merr := models.ListCorporationManagers(v.LinkID, v.Employee, dbmodels.RoleManager)
+	if merr != nil {
+		return parseModelError(merr)
+	}
+
+	isManager := false
+	for i := range managers {
+		if strings.EqualFold(managers[i].Email, v.Manager) {
+			isManager = true
+			break
+		}
+	}
+	if !isManager {
+		return newFailedApiResult(
+			400, string(models.ErrCorpManagerDoesNotExist), fmt.Errorf("not a manager of corporation"),
+		)
+	}
+
+	orgInfo, merr := models.GetOrgOfLink(v.LinkID)
+	if merr != nil {
+		return parseModelError(merr)
+	}
+
+	pl := &acForCorpManagerPayload{Email: v.Manager, LinkID: v.LinkID, OrgInfo: *orgInfo}
+	msg := newEmployeeNotification(pl, v.Employee)
+
+	if merr := models.ResolveEmployeeApproval(v.LinkID, v.Employee, v.SigningID, v.Approved); merr != nil {
+		if merr.IsErrorOf(models.ErrNoLinkOrUnsigned) {
+			return newFailedApiResult(
+				400, errNotPendingEmployee, fmt.Errorf("the signing has been approved or rejected"),
+			)
+		}
+		return parseModelError(merr)
+	}
+
+	if v.Approved {
+		msg.Active = true
+		sendEmailToIndividual(v.LinkID, v.Employee, "Activate CLA signing", msg)
+	} else {
+		// the signing has been disabled, so the link can't approve it any more.
+		if merr := models.DeleteEmployeeSigning(v.LinkID, v.Employee); merr != nil {
+			return parseModelError(merr)
+		}
+
+		msg.Removing = true
+		sendEmailToIndividual(v.LinkID, v.Employee, "Remove employee", msg)
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package controllers
 import (
 	"fmt"
 
+	"github.com/astaxie/beego"
+
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/email"
 	"github.com/opensourceways/app-cla-server/models"
@@ -87,6 +89,17 @@ func (this *EmployeeManagerController) Delete() {
 
 	this.sendSuccessResp(action + "successfully")
 
+	emails := make([]string, 0, len(deleted))
+	for _, item := range deleted {
+		emails = append(emails, item.Email)
+	}
+	if merr := models.RevokeSessionsOfCorpManagers(pl.LinkID, emails); merr != nil {
+		beego.Error(merr)
+	}
+	if merr := models.RevokePersonalAccessTokensOfCorpManagers(pl.LinkID, emails); merr != nil {
+		beego.Error(merr)
+	}
+
 	subject := fmt.Sprintf("Revoking the authorization on project of \"%s\"", pl.OrgAlias)
 
 	for _, item := range deleted {
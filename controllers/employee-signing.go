@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/astaxie/beego"
+
 	"github.com/opensourceways/app-cla-server/config"
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/email"
 	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/worker"
 )
 
 type EmployeeSigningController struct {
@@ -49,6 +52,12 @@ func (this *EmployeeSigningController) Prepare() {
 // @Failure 411 no_employee_manager:        there is not any employee managers for the corresponding corp
 // @Failure 412 unmatched_cla:              the cla hash is not equal to the one of backend server
 // @Failure 413 resigned:                   the signer has signed the cla
+// @Failure 414 invalid_signing_info:       some values of signing info are invalid, see error_details
+// @Failure 415 signing_closed:             the link is suspended
+// @Failure 416 corp_signing_expired:       the corporation should renew the signing
+// @Failure 417 dco_link:                   the link is in dco mode
+// @Failure 429 verification_code_locked:   too many failed attempts on the verification code
+// @Failure 429 client_ip_locked:           too many failed attempts from the client ip
 // @Failure 500 system_error:               system error
 // @router /:link_id/:cla_lang/:cla_hash [post]
 func (this *EmployeeSigningController) Post() {
@@ -69,25 +78,59 @@ func (this *EmployeeSigningController) Post() {
 	}
 	info.CLALanguage = claLang
 
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := checkAuthAttempts(models.NewAuthAttemptOfIP(ip)); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
 	if err := (&info).Validate(linkID, pl.User, pl.Email); err != nil {
-		this.sendModelErrorAsResp(err, action)
+		this.sendModelErrorAsResp(
+			onVerificationCodeError(linkID, info.Email, ip, err), action,
+		)
+		return
+	}
+
+	orgInfo, fr := getOrgOfLinkForSigning(linkID)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
 		return
 	}
 
-	orgInfo, merr := models.GetOrgOfLink(linkID)
+	expired, merr := models.IsCorpSigningExpired(linkID, info.Email)
 	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
+	if expired {
+		this.sendFailedResponse(
+			400, errCorpSigningExpired, fmt.Errorf("the signing of corporation is expired"), action,
+		)
+		return
+	}
 
-	managers, merr := models.ListCorporationManagers(linkID, info.Email, dbmodels.RoleManager)
+	// the employee on the allow-list is activated once signed.
+	allowed, merr := models.IsEmployeeAllowed(linkID, info.Email, pl.User)
 	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
-	if len(managers) <= 0 {
-		this.sendFailedResponse(400, errNoCorpEmployeeManager, fmt.Errorf("no managers"), action)
-		return
+
+	var managers []dbmodels.CorporationManagerListResult
+	if !allowed {
+		managers, merr = models.ListCorporationManagers(linkID, info.Email, dbmodels.RoleManager)
+		if merr != nil {
+			this.sendModelErrorAsResp(merr, action)
+			return
+		}
+		if len(managers) <= 0 {
+			this.sendFailedResponse(400, errNoCorpEmployeeManager, fmt.Errorf("no managers"), action)
+			return
+		}
 	}
 
 	fr = signHelper(
@@ -97,14 +140,26 @@ func (this *EmployeeSigningController) Post() {
 				return newFailedApiResult(400, errUnmatchedCLA, fmt.Errorf("invalid cla"))
 			}
 
-			info.Info = getSingingInfo(info.Info, claInfo.Fields)
+			signingInfo, fr := checkSigningInfo(info.Info, claInfo.Fields)
+			if fr != nil {
+				return fr
+			}
+			info.Info = signingInfo
+
+			claText, fr := getIndividualCLAText(linkID, claLang)
+			if fr != nil {
+				return fr
+			}
 
-			if err := (&info).Create(linkID, false); err != nil {
+			info.ActivatedByAllowList = allowed
+			if err := (&info).Create(linkID, allowed); err != nil {
 				if err.IsErrorOf(models.ErrNoLinkOrResigned) {
 					return newFailedApiResult(400, errResigned, err)
 				}
 				return parseModelError(err)
 			}
+
+			this.notifyManagers(linkID, managers, &info, orgInfo, claInfo, claText, allowed)
 			return nil
 		},
 	)
@@ -112,7 +167,6 @@ func (this *EmployeeSigningController) Post() {
 		this.sendFailedResultAsResp(fr, action)
 	} else {
 		this.sendSuccessResp("sign successfully")
-		this.notifyManagers(linkID, managers, &info, orgInfo)
 	}
 }
 
@@ -143,6 +197,33 @@ func (this *EmployeeSigningController) GetAll() {
 	this.sendSuccessResp(r)
 }
 
+// @Title ListPending
+// @Description get the employees which are waiting for the activation
+// @Success 200 {object} dbmodels.IndividualSigningBasicInfo
+// @Failure 400 missing_token:      token is missing
+// @Failure 401 unknown_token:      token is unknown
+// @Failure 402 expired_token:      token is expired
+// @Failure 403 unauthorized_token: the permission of token is unmatched
+// @Failure 500 system_error:       system error
+// @router /pending [get]
+func (this *EmployeeSigningController) ListPending() {
+	action := "list pending employees"
+
+	pl, fr := this.tokenPayloadBasedOnCorpManager()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	r, merr := models.ListPendingEmployeeSigning(pl.LinkID, pl.Email)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(r)
+}
+
 // @Title List
 // @Description get all the employees by community manager
 // @Param	:link_id	path 	string		true		"link id"
@@ -219,7 +300,7 @@ func (this *EmployeeSigningController) Update() {
 
 	this.sendSuccessResp("enabled employee successfully")
 
-	msg := this.newEmployeeNotification(pl, employeeEmail)
+	msg := newEmployeeNotification(pl, employeeEmail)
 	if info.Enabled {
 		msg.Active = true
 		sendEmailToIndividual(pl.LinkID, employeeEmail, "Activate CLA signing", msg)
@@ -256,12 +337,14 @@ func (this *EmployeeSigningController) Delete() {
 
 	this.sendSuccessResp("delete employee successfully")
 
-	msg := this.newEmployeeNotification(pl, employeeEmail)
+	msg := newEmployeeNotification(pl, employeeEmail)
 	msg.Removing = true
 	sendEmailToIndividual(pl.LinkID, employeeEmail, "Remove employee", msg)
 }
 
-func (this *EmployeeSigningController) notifyManagers(linkID string, managers []dbmodels.CorporationManagerListResult, info *models.EmployeeSigning, orgInfo *models.OrgInfo) {
+// notifyManagers sends the receipt of signing to the employee and notifies the managers to activate it.
+// The managers are not notified if the signing has been activated by the allow-list.
+func (this *EmployeeSigningController) notifyManagers(linkID string, managers []dbmodels.CorporationManagerListResult, info *models.EmployeeSigning, orgInfo *models.OrgInfo, claInfo *models.CLAInfo, claText string, activated bool) {
 	ms := make([]string, 0, len(managers))
 	to := make([]string, 0, len(managers))
 	for _, item := range managers {
@@ -272,25 +355,49 @@ func (this *EmployeeSigningController) notifyManagers(linkID string, managers []
 	msg := email.EmployeeSigning{
 		Name:       info.Name,
 		Org:        orgInfo.OrgAlias,
+		Date:       info.Date,
+		SigningID:  info.SigningID,
 		ProjectURL: orgInfo.ProjectURL(),
 		Managers:   "  " + strings.Join(ms, "\n  "),
+		Activated:  activated,
 	}
-	sendEmailToIndividual(
-		linkID, info.Email,
+	worker.GetEmailWorker().GenCLAPDFForIndividualAndSendIt(
+		linkID, claText, *orgInfo, info.IndividualSigning, claInfo,
 		fmt.Sprintf("Signing CLA on project of \"%s\"", msg.Org),
 		msg,
 	)
 
+	if activated {
+		return
+	}
+
 	msg1 := email.NotifyingManager{
 		Org:              orgInfo.OrgAlias,
 		EmployeeEmail:    info.Email,
 		ProjectURL:       orgInfo.ProjectURL(),
 		URLOfCLAPlatform: config.AppConfig.CLAPlatformURL,
 	}
-	sendEmail(linkID, to, "An employee has signed CLA", msg1)
+
+	if !config.AppConfig.EmployeeApproval.Enabled() {
+		sendEmail(linkID, to, "An employee has signed CLA", msg1)
+		return
+	}
+
+	// each manager receives the approval links of its own
+	msg1.LinkExpiryHours = employeeApprovalExpiryHours()
+	for _, item := range to {
+		approve, reject, err := genEmployeeApprovalURLs(linkID, info.Email, info.SigningID, item)
+		if err != nil {
+			beego.Error(err)
+		}
+		msg1.ApproveURL = approve
+		msg1.RejectURL = reject
+
+		sendEmailToIndividual(linkID, item, "An employee has signed CLA", msg1)
+	}
 }
 
-func (this *EmployeeSigningController) newEmployeeNotification(pl *acForCorpManagerPayload, employeeName string) *email.EmployeeNotification {
+func newEmployeeNotification(pl *acForCorpManagerPayload, employeeName string) *email.EmployeeNotification {
 	return &email.EmployeeNotification{
 		Name:       employeeName,
 		Manager:    pl.Email,
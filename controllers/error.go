@@ -41,6 +41,20 @@ const (
 	errFrequentOperation        = "frequent_operation"
 	errCanNotFetchClientIP      = "can_not_fetch_client_ip"
 	errNotPDFFile               = "not_pdf_file"
+	errInvalidOrgSignature      = "invalid_org_signature"
+	errTooBigFile               = "too_big_file"
+	errInvalidPDFLayout         = "invalid_pdf_layout"
+	errRevokedToken             = "revoked_token"
+	errInvalidSignatureImage    = "invalid_signature_image"
+	errInvalidCorpPDF           = "invalid_corp_pdf"
+	errCounterSigned            = "counter_signed"
+	errNotYoursCLATemplate      = "not_yours_cla_template"
+	errDCOLink                  = "dco_link"
+	errNotDCOLink               = "not_dco_link"
+	errTooManyCommits           = "too_many_commits"
+	errNoRepo                   = "no_repo"
+	errSigningClosed            = "signing_closed"
+	errCorpSigningExpired       = "corp_signing_expired"
 )
 
 func parseModelError(err models.IModelError) *failedApiResult {
@@ -59,9 +73,18 @@ func parseModelError(err models.IModelError) *failedApiResult {
 		sc = 500
 		code = errSystemError
 
+	case models.ErrAccountLocked, models.ErrVerificationCodeLocked,
+		models.ErrClientIPLocked, models.ErrFrequentAuthAttempts:
+		sc = 429
+		code = string(err.ErrCode())
+
 	default:
 		code = string(err.ErrCode())
 	}
 
-	return newFailedApiResult(sc, code, err)
+	fr := newFailedApiResult(sc, code, err)
+	if v, ok := err.(models.FieldErrors); ok {
+		fr.details = v
+	}
+	return fr
 }
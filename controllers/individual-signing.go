@@ -2,11 +2,16 @@ package controllers
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 
+	"github.com/opensourceways/app-cla-server/config"
 	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/email"
 	"github.com/opensourceways/app-cla-server/models"
 	"github.com/opensourceways/app-cla-server/util"
+	"github.com/opensourceways/app-cla-server/worker"
 )
 
 type IndividualSigningController struct {
@@ -18,8 +23,12 @@ func (this *IndividualSigningController) Prepare() {
 	if this.isPostRequest() {
 		this.apiPrepare(PermissionIndividualSigner)
 	} else {
-		if strings.HasSuffix(this.routerPattern(), "/:platform/:org_repo") {
+		rp := this.routerPattern()
+		if strings.HasSuffix(rp, "/:platform/:org_repo") {
 			this.apiPrepare("")
+		} else if strings.HasSuffix(rp, "/receipt/:link_id") {
+			// the signer downloads the receipt of signing
+			this.apiPrepare(PermissionIndividualSigner)
 		} else {
 			this.apiPrepare(PermissionOwnerOfOrg)
 		}
@@ -45,6 +54,9 @@ func (this *IndividualSigningController) Prepare() {
 // @Failure 409 resigned:                   the signer has signed the cla
 // @Failure 410 no_link:                    the link id is not exists
 // @Failure 411 go_to_sign_employee_cla:    should sign employee cla instead
+// @Failure 412 invalid_signing_info:       some values of signing info are invalid, see error_details
+// @Failure 413 signing_closed:             the link is suspended
+// @Failure 414 dco_link:                   the link is in dco mode
 // @Failure 500 system_error:               system error
 // @router /:link_id/:cla_lang/:cla_hash [post]
 func (this *IndividualSigningController) Post() {
@@ -70,6 +82,12 @@ func (this *IndividualSigningController) Post() {
 		return
 	}
 
+	orgInfo, fr := getOrgOfLinkForSigning(linkID)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
 	b, merr := models.IsCorpSigned(linkID, info.Email)
 	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
@@ -87,7 +105,16 @@ func (this *IndividualSigningController) Post() {
 				return newFailedApiResult(400, errUnmatchedCLA, fmt.Errorf("invalid cla"))
 			}
 
-			info.Info = getSingingInfo(info.Info, claInfo.Fields)
+			signingInfo, fr := checkSigningInfo(info.Info, claInfo.Fields)
+			if fr != nil {
+				return fr
+			}
+			info.Info = signingInfo
+
+			claText, fr := getIndividualCLAText(linkID, claLang)
+			if fr != nil {
+				return fr
+			}
 
 			if err := (&info).Create(linkID, true); err != nil {
 				if err.IsErrorOf(models.ErrNoLinkOrResigned) {
@@ -95,6 +122,19 @@ func (this *IndividualSigningController) Post() {
 				}
 				return parseModelError(err)
 			}
+
+			worker.GetEmailWorker().GenCLAPDFForIndividualAndSendIt(
+				linkID, claText, *orgInfo, info, claInfo,
+				fmt.Sprintf("Signing CLA on project of \"%s\"", orgInfo.OrgAlias),
+				email.IndividualSigning{
+					Name:       info.Name,
+					Org:        orgInfo.OrgAlias,
+					Date:       info.Date,
+					SigningID:  info.SigningID,
+					ProjectURL: orgInfo.ProjectURL(),
+				},
+			)
+
 			return nil
 		},
 	)
@@ -106,29 +146,112 @@ func (this *IndividualSigningController) Post() {
 }
 
 // @Title Check
-// @Description check whether contributor has signed cla
+// @Description check whether contributor has signed cla. The corp_signing_status is
+// returned for the employee if the corporation signing has a validity period.
 // @Param	platform	path 	string	true		"code platform"
 // @Param	org_repo	path 	string	true		"org:repo"
 // @Param	email		query 	string	true		"email of contributor"
+// @Param	login		query 	string	false		"login of contributor on code platform"
 // @Success 200 {object} map
 // @Failure 400 no_link:      there is not link for this org and repo
+// @Failure 401 dco_link:     the link is in dco mode, check the commits instead
 // @Failure 500 system_error: system error
 // @router /:platform/:org_repo [get]
 func (this *IndividualSigningController) Check() {
 	action := "check individual signing"
 	org, repo := parseOrgAndRepo(this.GetString(":org_repo"))
 
-	linkID, err := models.GetLinkID(buildOrgRepo(this.GetString(":platform"), org, repo))
+	link, err := models.ResolveLink(buildOrgRepo(this.GetString(":platform"), org, repo))
 	if err != nil {
 		this.sendModelErrorAsResp(err, action)
 		return
 	}
 
-	if v, merr := models.IsIndividualSigned(linkID, this.GetString("email")); merr != nil {
+	this.checkSigning(link, action)
+}
+
+// @Title CheckOfLink
+// @Description the community manager checks whether contributor has signed cla of the link.
+// It is the same as Check, but can be called with the personal access token of check scope.
+// @Param	:link_id	path 	string	true		"link id"
+// @Param	email		query 	string	true		"email of contributor"
+// @Param	login		query 	string	false		"login of contributor on code platform"
+// @Success 200 {object} map
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 unknown_link:               unkown link id
+// @Failure 406 not_yours_org:              the link doesn't belong to your community
+// @Failure 407 dco_link:                   the link is in dco mode, check the commits instead
+// @Failure 500 system_error:               system error
+// @router /check/:link_id [get]
+func (this *IndividualSigningController) CheckOfLink() {
+	action := "check individual signing of link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	link, merr := models.GetLink(linkID)
+	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
-	} else {
+		return
+	}
+
+	this.checkSigning(link, action)
+}
+
+func (this *IndividualSigningController) checkSigning(link *models.LinkInfo, action string) {
+	if link.IsDCO() {
+		this.sendFailedResponse(400, errDCOLink, fmt.Errorf("check commits instead"), action)
+		return
+	}
+	if link.IsSuspended() {
+		this.sendSuccessResp(map[string]bool{
+			"signed":    link.Suspension.CheckResult,
+			"suspended": true,
+		})
+		return
+	}
+
+	contributor := this.GetString("email")
+	v, merr := models.IsIndividualSigned(link.LinkID, contributor)
+	if merr == nil && !v && link.IsEmployeePreauthorized() {
+		v, merr = models.IsEmployeePreauthorized(link.LinkID, contributor, this.GetString("login"))
+	}
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	status := ""
+	if v {
+		if status, merr = models.CorpSigningStatusOfEmployee(link, contributor); merr != nil {
+			this.sendModelErrorAsResp(merr, action)
+			return
+		}
+	}
+
+	if status == "" {
 		this.sendSuccessResp(map[string]bool{"signed": v})
+		return
 	}
+
+	// the employee is not covered once the signing of corporation
+	// is expired and the grace period ends.
+	this.sendSuccessResp(map[string]interface{}{
+		"signed":              status != dbmodels.CorpSigningExpired,
+		"corp_signing_status": status,
+	})
 }
 
 // @Title List
@@ -185,3 +308,99 @@ func (this *IndividualSigningController) List() {
 	}
 	this.sendSuccessResp(result)
 }
+
+// @Title DownloadReceipt
+// @Description the signer downloads the receipt of individual or employee signing
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 200 {file} pdf
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 no_link:                    the link id is not exists
+// @Failure 406 unsigned:                   the signer has not signed the cla
+// @Failure 407 no_signing_receipt:         the receipt of signing is not generated
+// @Failure 500 system_error:               system error
+// @router /receipt/:link_id [get]
+func (this *IndividualSigningController) DownloadReceipt() {
+	action := "download receipt of individual signing"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := this.downloadReceipt(linkID, pl.Email); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+// @Title DownloadReceiptByOwner
+// @Description the community manager downloads the receipt of individual or employee signing
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:email		path 	string		true		"email of signer"
+// @Success 200 {file} pdf
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 unknown_link:               unkown link id
+// @Failure 406 not_yours_org:              the link doesn't belong to your community
+// @Failure 407 unsigned:                   the signer has not signed the cla
+// @Failure 408 no_signing_receipt:         the receipt of signing is not generated
+// @Failure 500 system_error:               system error
+// @router /receipt/:link_id/:email [get]
+func (this *IndividualSigningController) DownloadReceiptByOwner() {
+	action := "download receipt of individual signing"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := this.downloadReceipt(linkID, this.GetString(":email")); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+	}
+}
+
+func (this *IndividualSigningController) downloadReceipt(linkID, signerEmail string) *failedApiResult {
+	signing, merr := models.GetIndividualSigning(linkID, signerEmail)
+	if merr != nil {
+		return parseModelError(merr)
+	}
+	if signing.SigningID == "" {
+		// the signing was made before the receipt was supported
+		return newFailedApiResult(
+			400, string(models.ErrNoSigningReceipt), fmt.Errorf("no receipt"),
+		)
+	}
+
+	dir := util.GenFilePath(config.AppConfig.PDFOutDir, "tmp")
+	f, err := ioutil.TempFile(dir, fmt.Sprintf("%s_%s_*.pdf", linkID, signing.SigningID))
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+	path := f.Name()
+	f.Close()
+
+	defer func() {
+		os.Remove(path)
+	}()
+
+	if merr := models.DownloadIndividualSigningPDF(linkID, signing.SigningID, path); merr != nil {
+		return parseModelError(merr)
+	}
+
+	this.downloadFile(path)
+	return nil
+}
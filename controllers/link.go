@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/opensourceways/app-cla-server/config"
+	"github.com/astaxie/beego"
+
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/models"
 	"github.com/opensourceways/app-cla-server/pdf"
@@ -43,9 +44,7 @@ func (this *LinkController) Link() {
 	}
 
 	if input.CorpCLA != nil {
-		data, fr := this.readInputFile(
-			fileNameOfUploadingOrgSignatue, config.AppConfig.MaxSizeOfOrgSignaturePDF,
-		)
+		data, fr := this.readOrgSignature()
 		if fr != nil {
 			sendResp(fr)
 			return
@@ -63,54 +62,164 @@ func (this *LinkController) Link() {
 		return
 	}
 
-	filePath := genOrgFileLockPath(input.Platform, input.OrgID, input.RepoID)
-	if err := util.CreateLockedFile(filePath); err != nil {
-		this.sendFailedResponse(500, errSystemError, err, action)
+	if fr := this.createLink(input, pl.User); fr != nil {
+		sendResp(fr)
 		return
 	}
 
-	unlock, err := util.Lock(filePath)
-	if err != nil {
-		this.sendFailedResponse(500, errSystemError, err, action)
+	this.sendResponse("create org cla successfully", 0)
+}
+
+// @Title Clone
+// @Description create a new link with the clas of an existing link
+// @Param	:link_id	path 	string				true		"the link whose clas will be copied"
+// @Param	body		body 	models.LinkCreateOption	true		"body for creating link, the clas in it are ignored"
+// @Success 201 {string} "create org cla successfully"
+// @router /clone/:link_id [post]
+func (this *LinkController) Clone() {
+	action := "clone link"
+	sendResp := this.newFuncForSendingFailedResp(action)
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		sendResp(fr)
 		return
 	}
-	defer unlock()
-
-	orgRepo := buildOrgRepo(input.Platform, input.OrgID, input.RepoID)
-	_, merr := models.GetLinkID(orgRepo)
-	if merr == nil {
-		this.sendFailedResponse(400, errLinkExists, fmt.Errorf("recreate link"), action)
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		sendResp(fr)
 		return
 	}
-	if !merr.IsErrorOf(models.ErrNoLink) {
+
+	set, merr := models.GetCLASetOfLink(linkID)
+	if merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
 
-	linkID := genLinkID(orgRepo)
-	if fr := saveCorpCLAAtLocal(input.CorpCLA, linkID); fr != nil {
+	this.linkWithCLASet(pl, set, "", action)
+}
+
+// @Title LinkWithTemplate
+// @Description create a new link with the clas of a cla template
+// @Param	:template_id	path 	string				true		"cla template id"
+// @Param	reference	query 	bool				false		"the link refers to the template and gets the clas added to it later"
+// @Param	body		body 	models.LinkCreateOption	true		"body for creating link, the clas in it are ignored"
+// @Success 201 {string} "create org cla successfully"
+// @router /template/:template_id [post]
+func (this *LinkController) LinkWithTemplate() {
+	action := "create link with cla template"
+	sendResp := this.newFuncForSendingFailedResp(action)
+	id := this.GetString(":template_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
 		sendResp(fr)
 		return
 	}
-
-	if fr := this.initializeSigning(input, linkID, orgRepo); fr != nil {
+	if _, fr := getCLATemplateOfOwner(pl, id); fr != nil {
 		sendResp(fr)
 		return
 	}
 
-	if merr := input.Create(linkID, pl.User); merr != nil {
+	set, merr := models.GetCLASetOfTemplate(id)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	templateID := ""
+	if reference, _ := this.GetBool("reference"); reference {
+		templateID = id
+	}
+
+	this.linkWithCLASet(pl, set, templateID, action)
+}
+
+// linkWithCLASet creates the link with the cla set. The link refers to the
+// cla template if templateID is not empty.
+func (this *LinkController) linkWithCLASet(pl *acForCodePlatformPayload, set *models.CLASet, templateID, action string) {
+	input := &models.LinkCreateOption{}
+	if fr := this.fetchInputPayload(input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := input.ValidateCLASet(set, pdf.GetPDFGenerator().LangSupported()); merr != nil {
 		this.sendModelErrorAsResp(merr, action)
 		return
 	}
+	input.ReferToCLATemplate(templateID)
+
+	if fr := pl.isOwnerOfOrg(input.OrgID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if fr := this.createLink(input, pl.User); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
 
 	this.sendResponse("create org cla successfully", 0)
 }
 
+// createLink creates the link with all the clas of the option. The link is
+// saved at last with all the clas, so it is never seen with part of them.
+// The signings are purged if it fails halfway.
+func (this *LinkController) createLink(input *models.LinkCreateOption, submitter string) *failedApiResult {
+	filePath := genOrgFileLockPath(input.Platform, input.OrgID, input.RepoID)
+	if err := util.CreateLockedFile(filePath); err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+
+	unlock, err := util.Lock(filePath)
+	if err != nil {
+		return newFailedApiResult(500, errSystemError, err)
+	}
+	defer unlock()
+
+	orgRepo := buildOrgRepo(input.Platform, input.OrgID, input.RepoID)
+	_, merr := models.GetLinkID(orgRepo)
+	if merr == nil {
+		return newFailedApiResult(400, errLinkExists, fmt.Errorf("recreate link"))
+	}
+	if !merr.IsErrorOf(models.ErrNoLink) {
+		return parseModelError(merr)
+	}
+
+	linkID := genLinkID(orgRepo)
+
+	corpCLAs := input.CLAs(dbmodels.ApplyToCorporation)
+	for i := range corpCLAs {
+		if fr := saveCorpCLAAtLocal(&corpCLAs[i], linkID); fr != nil {
+			return fr
+		}
+	}
+
+	fr := this.initializeSigning(input, linkID, orgRepo)
+	if fr == nil {
+		if merr := input.Create(linkID, submitter); merr != nil {
+			fr = parseModelError(merr)
+		}
+	}
+
+	if fr != nil {
+		if merr := models.PurgeDataOfLink(linkID); merr != nil {
+			beego.Error(merr)
+		}
+	}
+
+	return fr
+}
+
 func (this *LinkController) initializeSigning(input *models.LinkCreateOption, linkID string, orgRepo *dbmodels.OrgRepo) *failedApiResult {
-	var info *dbmodels.CLAInfo
+	individualCLAs := input.CLAs(dbmodels.ApplyToIndividual)
+	corpCLAs := input.CLAs(dbmodels.ApplyToCorporation)
 
-	if input.IndividualCLA != nil {
-		info = input.IndividualCLA.GenCLAInfo()
+	var info *dbmodels.CLAInfo
+	if len(individualCLAs) > 0 {
+		info = individualCLAs[0].GenCLAInfo()
 	}
 	if merr := models.InitializeIndividualSigning(linkID, info); merr != nil {
 		return parseModelError(merr)
@@ -122,18 +231,31 @@ func (this *LinkController) initializeSigning(input *models.LinkCreateOption, li
 		OrgAlias: input.OrgAlias,
 	}
 	info = nil
-	if input.CorpCLA != nil {
-		info = input.CorpCLA.GenCLAInfo()
+	if len(corpCLAs) > 0 {
+		info = corpCLAs[0].GenCLAInfo()
 	}
 	if merr := models.InitializeCorpSigning(linkID, &orgInfo, info); merr != nil {
 		return parseModelError(merr)
 	}
 
+	// the rest clas of set
+	for i := 1; i < len(individualCLAs); i++ {
+		if merr := individualCLAs[i].AddCLAInfo(linkID, dbmodels.ApplyToIndividual); merr != nil {
+			return parseModelError(merr)
+		}
+	}
+
+	for i := 1; i < len(corpCLAs); i++ {
+		if merr := corpCLAs[i].AddCLAInfo(linkID, dbmodels.ApplyToCorporation); merr != nil {
+			return parseModelError(merr)
+		}
+	}
+
 	return nil
 }
 
 // @Title Unlink
-// @Description unlink cla
+// @Description unlink cla. The link is archived and can be restored until it is purged after the retention.
 // @Param	uid		path 	string	true		"The uid of binding"
 // @Success 204 {string} delete success!
 // @Failure 403 uid is empty
@@ -159,9 +281,531 @@ func (this *LinkController) Unlink() {
 		return
 	}
 
+	if err := models.RevokeSessionsOfLink(linkID); err != nil {
+		beego.Error(err)
+	}
+	if err := models.DeletePersonalAccessTokensOfLink(linkID); err != nil {
+		beego.Error(err)
+	}
+
 	this.sendSuccessResp(action + "successfully")
 }
 
+type excludedRepos struct {
+	Repos []string `json:"repos"`
+}
+
+// @Title MoveRepo
+// @Description move the repo of org to the link, the signings of links are kept
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:repo		path 	string		true		"repo"
+// @Success 202 {int} map
+// @Failure 400 invalid_repo:	the repo name is invalid
+// @Failure 401 repo_has_link:	the repo is bound to a link of itself
+// @router /:link_id/repos/:repo [put]
+func (this *LinkController) MoveRepo() {
+	action := "move repo to link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.MoveRepoToLink(linkID, this.GetString(":repo")); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("move repo successfully")
+}
+
+// @Title RemoveRepo
+// @Description remove the repo from the link
+// @Param	:link_id	path 	string		true		"link id"
+// @Param	:repo		path 	string		true		"repo"
+// @Success 204 {string} delete success!
+// @router /:link_id/repos/:repo [delete]
+func (this *LinkController) RemoveRepo() {
+	action := "remove repo from link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.RemoveRepoFromLink(linkID, this.GetString(":repo")); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("remove repo successfully")
+}
+
+// @Title SetExcludedRepos
+// @Description set the repos which the link of org doesn't cover
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	controllers.excludedRepos	true		"body for excluded repos"
+// @Success 202 {int} map
+// @Failure 400 invalid_repo:	the repo name is invalid
+// @Failure 401 no_org_link:	the link is not the one of org
+// @router /:link_id/excluded-repos [put]
+func (this *LinkController) SetExcludedRepos() {
+	action := "set excluded repos of link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input excludedRepos
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.SetExcludedRepos(linkID, input.Repos); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("set excluded repos successfully")
+}
+
+// @Title Transfer
+// @Description move the link to another org or repo when the org is renamed or the repo is transferred
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	models.LinkTransferOption	true		"body for transfer"
+// @Success 202 {int} map
+// @Failure 400 not_yours_org:	the user is not the owner of both the current org and the target one
+// @Failure 401 no_repo:		the repo doesn't exist
+// @Failure 402 link_exists:	there is a link for the target org or repo
+// @router /:link_id/transfer [post]
+func (this *LinkController) Transfer() {
+	action := "transfer link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	link, merr := models.GetLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if link.Platform != pl.Platform {
+		this.sendFailedResponse(400, errNotYoursOrg, fmt.Errorf("not the platform of link"), action)
+		return
+	}
+	// the user must own both the current org and the target one.
+	if fr := pl.isOwnerOfOrg(link.OrgID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input models.LinkTransferOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := input.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if fr := pl.isOwnerOfOrg(input.OrgID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if input.RepoID != "" {
+		b, fr := pl.hasRepo(input.OrgID, input.RepoID)
+		if fr != nil {
+			this.sendFailedResultAsResp(fr, action)
+			return
+		}
+		if !b {
+			this.sendFailedResponse(400, errNoRepo, fmt.Errorf("no repo"), action)
+			return
+		}
+	}
+
+	unlock, fr := lockOnTransfer(&link.OrgRepo, buildOrgRepo(link.Platform, input.OrgID, input.RepoID))
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	if merr := input.Transfer(linkID, &link.OrgRepo, pl.User); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	delete(pl.Links, linkID)
+
+	// the org info is saved in the sessions and the payloads of access tokens
+	if err := models.RevokeSessionsOfLink(linkID); err != nil {
+		beego.Error(err)
+	}
+	if err := models.DeletePersonalAccessTokensOfLink(linkID); err != nil {
+		beego.Error(err)
+	}
+
+	this.sendSuccessResp("transfer link successfully")
+}
+
+// @Title Suspend
+// @Description suspend the link, the signing is closed and the checks return the result specified
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	models.LinkSuspendOption	true		"body for suspending link"
+// @Success 202 {int} map
+// @Failure 400 invalid_suspension:	the option of suspension is invalid
+// @router /:link_id/suspend [post]
+func (this *LinkController) Suspend() {
+	action := "suspend link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input models.LinkSuspendOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := input.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := input.Suspend(linkID, pl.User); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("suspend link successfully")
+}
+
+// @Title Resume
+// @Description resume the suspended link
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 202 {int} map
+// @router /:link_id/resume [post]
+func (this *LinkController) Resume() {
+	action := "resume link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.ResumeLink(linkID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("resume link successfully")
+}
+
+// @Title ReportPendingApprovals
+// @Description report the employee signings waiting for the activation by corporation
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 200 {object} models.PendingApprovalsOfCorp
+// @router /:link_id/pending-approvals [get]
+func (this *LinkController) ReportPendingApprovals() {
+	action := "report pending approvals"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	r, merr := models.ReportPendingApprovals(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(r)
+}
+
+type employeePolicy struct {
+	Policy string `json:"policy"`
+}
+
+// @Title SetEmployeePolicy
+// @Description set how the employees are covered. The employees should sign before
+// contributing if it is "sign", and the employees on the allow-list of corporation
+// are covered without signing if it is "preauthorized".
+// @Param	:link_id	path 	string				true		"link id"
+// @Param	body		body 	controllers.employeePolicy	true		"body for employee policy"
+// @Success 202 {int} map
+// @Failure 400 invalid_employee_policy:	the policy is unknown
+// @router /:link_id/employee-policy [put]
+func (this *LinkController) SetEmployeePolicy() {
+	action := "set employee policy"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input employeePolicy
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := models.SetEmployeePolicy(linkID, input.Policy); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("set employee policy successfully")
+}
+
+// @Title SetCorpSigningValidity
+// @Description set the validity period of corporation signing. The corporation administrator
+// is reminded to renew the signing before it expires, and the employees are no longer covered
+// after the grace period. The validity is removed if validity_days is 0.
+// @Param	:link_id	path 	string					true		"link id"
+// @Param	body		body 	models.CorpSigningValidityOption	true		"body for validity"
+// @Success 202 {int} map
+// @Failure 400 invalid_corp_signing_validity:	the validity is invalid
+// @router /:link_id/corp-signing-validity [put]
+func (this *LinkController) SetCorpSigningValidity() {
+	action := "set validity of corp signing"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input models.CorpSigningValidityOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := input.Validate(); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if merr := input.Set(linkID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("set validity of corp signing successfully")
+}
+
+// @Title SetAmendmentApproval
+// @Description set whether the amendments of contact information of corporation signing
+// should be approved by the community before taking effect
+// @Param	:link_id	path 	string					true		"link id"
+// @Param	body		body 	models.AmendmentApprovalOption		true		"body for approval"
+// @Success 202 {int} map
+// @Failure 400 no_link:	the link is not exists
+// @router /:link_id/amendment-approval [put]
+func (this *LinkController) SetAmendmentApproval() {
+	action := "set approval of amendment"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	var input models.AmendmentApprovalOption
+	if fr := this.fetchInputPayload(&input); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if merr := input.Set(linkID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("set approval of amendment successfully")
+}
+
+// @Title ListArchived
+// @Description list the archived links which can be restored
+// @Success 200 {object} dbmodels.LinkInfo
+// @router /archived [get]
+func (this *LinkController) ListArchived() {
+	action := "list archived links"
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if len(pl.Orgs) == 0 {
+		this.sendSuccessResp(nil)
+		return
+	}
+
+	orgs := make([]string, 0, len(pl.Orgs))
+	for k := range pl.Orgs {
+		orgs = append(orgs, k)
+	}
+	r, merr := models.ListArchivedLinks(pl.Platform, orgs)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(r)
+}
+
+// @Title Restore
+// @Description restore the archived link with all its signings
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 202 {int} map
+// @Failure 400 no_archived_link:	the link is not archived or has been purged
+// @Failure 401 link_exists:	there is another link for the org or repo
+// @router /:link_id/restore [post]
+func (this *LinkController) Restore() {
+	action := "restore link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	link, merr := models.GetArchivedLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if link.Platform != pl.Platform {
+		this.sendFailedResponse(400, errNotYoursOrg, fmt.Errorf("not the platform of link"), action)
+		return
+	}
+	if fr := pl.isOwnerOfOrg(link.OrgID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	if err := util.CreateLockedFile(genOrgFileLockPath(link.Platform, link.OrgID, link.RepoID)); err != nil {
+		this.sendFailedResponse(500, errSystemError, err, action)
+		return
+	}
+	unlock, fr := lockOnRepo(&link.OrgInfo)
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+	defer unlock()
+
+	if merr := models.RestoreLink(link); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if err := loadLink(link); err != nil {
+		beego.Error(fmt.Sprintf("failed to load the restored link:%s, err: %s", linkID, err.Error()))
+	}
+
+	this.sendSuccessResp("restore link successfully")
+}
+
+// @Title Export
+// @Description export the link and all its signings, the link can be archived
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 200 {object} dbmodels.LinkExport
+// @router /:link_id/export [get]
+func (this *LinkController) Export() {
+	action := "export link"
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	v, merr := models.ExportLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if v.Platform != pl.Platform {
+		this.sendFailedResponse(400, errNotYoursOrg, fmt.Errorf("not the platform of link"), action)
+		return
+	}
+	if fr := pl.isOwnerOfOrg(v.OrgID); fr != nil {
+		this.sendFailedResultAsResp(fr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
 // @Title ListLinks
 // @Description list all links
 // @Success 200 {object} dbmodels.LinkInfo
@@ -235,38 +879,45 @@ func LoadLinks() error {
 	}
 
 	for i := range links {
-		link := &links[i]
-
-		orgRepo := &link.OrgInfo
-		filePath := genOrgFileLockPath(orgRepo.Platform, orgRepo.OrgID, orgRepo.RepoID)
-		if err := util.CreateLockedFile(filePath); err != nil {
+		if err := loadLink(&links[i]); err != nil {
 			return err
 		}
+	}
 
-		linkID := link.LinkID
+	return nil
+}
+
+// loadLink prepares the lock file and the local files of corp clas for the link.
+func loadLink(link *models.LinkInfo) error {
+	orgRepo := &link.OrgInfo
+	filePath := genOrgFileLockPath(orgRepo.Platform, orgRepo.OrgID, orgRepo.RepoID)
+	if err := util.CreateLockedFile(filePath); err != nil {
+		return err
+	}
+
+	linkID := link.LinkID
 
-		info, err := models.GetAllCLA(linkID)
+	info, err := models.GetAllCLA(linkID)
+	if err != nil {
+		return err
+	}
+
+	for j := range info.CorpCLAs {
+		cla := &info.CorpCLAs[j]
+		text := []byte(cla.Text)
+
+		signature, err := models.DownloadCorpCLAPDF(linkID, cla.Language)
 		if err != nil {
 			return err
 		}
 
-		for j := range info.CorpCLAs {
-			cla := &info.CorpCLAs[j]
-			text := []byte(cla.Text)
-
-			signature, err := models.DownloadCorpCLAPDF(linkID, cla.Language)
-			if err != nil {
-				return err
-			}
-
-			opt := &models.CLACreateOpt{}
-			opt.Language = cla.Language
-			opt.SetCLAContent(&text)
-			opt.SetOrgSignature(&signature)
+		opt := &models.CLACreateOpt{}
+		opt.Language = cla.Language
+		opt.SetCLAContent(&text)
+		opt.SetOrgSignature(&signature)
 
-			if fr := saveCorpCLAAtLocal(opt, linkID); fr != nil {
-				return fr.reason
-			}
+		if fr := saveCorpCLAAtLocal(opt, linkID); fr != nil {
+			return fr.reason
 		}
 	}
 
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+type PersonalDataController struct {
+	baseController
+}
+
+func (this *PersonalDataController) Prepare() {
+	this.apiPrepare("")
+}
+
+// @Title SendCode
+// @Description send verification code to access or erase the personal data
+// @Param	:link_id	path 	string		true		"link id whose community sends the email"
+// @Param	:email		path 	string		true		"email of signer"
+// @Success 201 {string} "create verification code successfully"
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 no_link:                    the link doesn't exist
+// @Failure 500 system_error:               system error
+// @router /code/:link_id/:email [post]
+func (this *PersonalDataController) SendCode() {
+	action := "create verification code for personal data"
+	linkID := this.GetString(":link_id")
+	emailOfSigner := this.GetString(":email")
+
+	orgInfo, merr := models.GetOrgOfLink(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	code, merr := models.CreateVerificationCode(
+		emailOfSigner, models.PurposeOfPersonalData,
+		config.AppConfig.VerificationCodeExpiry,
+	)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp("create verification code successfully")
+
+	sendEmailToIndividual(
+		linkID, emailOfSigner,
+		fmt.Sprintf("Verification code for personal data on project of \"%s\"", orgInfo.OrgAlias),
+		email.PersonalDataCode{
+			Email:      emailOfSigner,
+			Org:        orgInfo.OrgAlias,
+			Code:       code,
+			ProjectURL: orgInfo.ProjectURL(),
+		},
+	)
+}
+
+// @Title Export
+// @Description export all the personal data about the email as json. The email is verified by
+// the verification code, or by the token of individual signer if the code is not passed.
+// @Param	body		body 	models.PersonalDataRequest	false		"body for personal data"
+// @Success 201 {object} dbmodels.PersonalData
+// @Failure 400 error_parsing_api_body:  parse payload of request failed
+// @Failure 401 wrong_verification_code: wrong verification code
+// @Failure 402 missing_token:           token is missing
+// @Failure 403 unauthorized_token:      the permission of token is unmatched
+// @Failure 429 verification_code_locked: too many failed attempts
+// @Failure 500 system_error:            system error
+// @router /export [post]
+func (this *PersonalDataController) Export() {
+	action := "export personal data"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	emailOfSigner, fr := this.verifiedEmail()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	v, merr := models.GetPersonalData(emailOfSigner)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// @Title Erase
+// @Description pseudonymise the signing records and remove the other personal data about the email.
+// The email is verified in the same way as exporting.
+// @Param	body		body 	models.PersonalDataRequest	false		"body for personal data"
+// @Success 201 {object} models.PersonalDataErasure
+// @Failure 400 error_parsing_api_body:  parse payload of request failed
+// @Failure 401 wrong_verification_code: wrong verification code
+// @Failure 402 missing_token:           token is missing
+// @Failure 403 unauthorized_token:      the permission of token is unmatched
+// @Failure 429 verification_code_locked: too many failed attempts
+// @Failure 500 system_error:            system error
+// @router /erase [post]
+func (this *PersonalDataController) Erase() {
+	action := "erase personal data"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	emailOfSigner, fr := this.verifiedEmail()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	v, merr := models.ErasePersonalData(emailOfSigner)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+
+	notifyErasingPersonalData(v)
+}
+
+// verifiedEmail returns the email which is verified by the verification code
+// or the token of individual signer.
+func (this *PersonalDataController) verifiedEmail() (string, *failedApiResult) {
+	var info models.PersonalDataRequest
+	if len(this.Ctx.Input.RequestBody) > 0 {
+		if fr := this.fetchInputPayload(&info); fr != nil {
+			return "", fr
+		}
+	}
+
+	if info.Code == "" {
+		ac := this.newAccessController(PermissionIndividualSigner)
+		if fr := this.checkApiReqToken(ac, []string{PermissionIndividualSigner}); fr != nil {
+			return "", fr
+		}
+
+		pl, ok := ac.Payload.(*acForCodePlatformPayload)
+		if !ok || pl.Email == "" {
+			return "", newFailedApiResult(400, errUnauthorizedToken, fmt.Errorf("no email in the token"))
+		}
+		return pl.Email, nil
+	}
+
+	ip, fr := this.getRemoteAddr()
+	if fr != nil {
+		return "", fr
+	}
+
+	if fr := checkAuthAttempts(models.NewAuthAttemptOfIP(ip)); fr != nil {
+		return "", fr
+	}
+
+	if merr := info.Validate(); merr != nil {
+		if merr.IsErrorOf(models.ErrWrongVerificationCode) {
+			if err := failAuthAttemptOfIP(ip); err != nil {
+				merr = err
+			}
+		}
+		return "", parseModelError(merr)
+	}
+
+	return info.Email, nil
+}
+
+func notifyErasingPersonalData(v *models.PersonalDataErasure) {
+	for _, linkID := range v.ErasedLinks {
+		orgInfo, merr := models.GetOrgOfLink(linkID)
+		if merr != nil {
+			beego.Error(merr)
+			continue
+		}
+
+		sendEmailToIndividual(
+			linkID, orgInfo.OrgEmail,
+			fmt.Sprintf("Personal data erased on project of \"%s\"", orgInfo.OrgAlias),
+			email.ErasingPersonalData{
+				Pseudonym:  v.Pseudonym,
+				Org:        orgInfo.OrgAlias,
+				ProjectURL: orgInfo.ProjectURL(),
+			},
+		)
+	}
+}
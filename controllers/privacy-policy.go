@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+type PrivacyPolicyController struct {
+	baseController
+}
+
+func (this *PrivacyPolicyController) Prepare() {
+	if strings.HasSuffix(this.routerPattern(), "/outdated/:link_id") {
+		this.apiPrepare(PermissionOwnerOfOrg)
+	} else {
+		this.apiPrepare("")
+	}
+}
+
+// @Title GetAll
+// @Description list all the versions of privacy policy, the last one is the current version
+// @Success 200 {object} models.PrivacyPolicySummary
+// @router / [get]
+func (this *PrivacyPolicyController) GetAll() {
+	this.sendSuccessResp(models.ListPrivacyPolicies())
+}
+
+// @Title Get
+// @Description get the document of privacy policy and its hash which should be passed when signing
+// @Param	:version	path 	string		true		"version of privacy policy, it can be current"
+// @Param	:lang		path 	string		true		"language of privacy policy"
+// @Success 200 {object} models.PrivacyPolicy
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 no_privacy_policy:          the privacy policy doesn't exist
+// @router /:version/:lang [get]
+func (this *PrivacyPolicyController) Get() {
+	action := "fetch privacy policy"
+
+	v, merr := models.GetPrivacyPolicy(this.GetString(":version"), this.GetString(":lang"))
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
+
+// @Title Outdated
+// @Description list the signers who didn't accept the current privacy policy
+// @Param	:link_id	path 	string		true		"link id"
+// @Success 200 {object} dbmodels.OutdatedPrivacyConsent
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 unknown_link:               unkown link id
+// @Failure 406 not_yours_org:              the link doesn't belong to your community
+// @Failure 407 no_privacy_policy:          no privacy policy is configured
+// @Failure 500 system_error:               system error
+// @router /outdated/:link_id [get]
+func (this *PrivacyPolicyController) Outdated() {
+	action := "list outdated privacy consents"
+	sendResp := this.newFuncForSendingFailedResp(action)
+	linkID := this.GetString(":link_id")
+
+	pl, fr := this.tokenPayloadBasedOnCodePlatform()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+	if fr := pl.isOwnerOfLink(linkID); fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	v, merr := models.ListOutdatedPrivacyConsents(linkID)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.sendSuccessResp(v)
+}
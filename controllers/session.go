@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+type SessionController struct {
+	baseController
+}
+
+func (this *SessionController) Prepare() {
+	// the payload is not used, so it is decoded as a map
+	this.apiPrepareWithAC(
+		&accessController{},
+		[]string{
+			PermissionOwnerOfOrg, PermissionIndividualSigner,
+			PermissionCorpAdmin, PermissionEmployeeManager,
+		},
+	)
+}
+
+// @Title GetAll
+// @Description list the active sessions of current user
+// @Success 200 {object} dbmodels.Session
+// @Failure 401 missing_token:      token is missing
+// @Failure 402 unknown_token:      token is unknown
+// @Failure 403 expired_token:      token is expired
+// @Failure 404 unauthorized_token: the permission of token is unmatched
+// @Failure 405 revoked_token:      the session of token is revoked
+// @Failure 500 system_error:       system error
+// @router / [get]
+func (this *SessionController) GetAll() {
+	action := "list sessions"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	v, merr := models.ListSessions(current.Subject)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	type sessionInfo struct {
+		models.Session
+
+		Current bool `json:"current"`
+	}
+
+	r := make([]sessionInfo, 0, len(v))
+	for i := range v {
+		r = append(r, sessionInfo{
+			Session: v[i],
+			Current: v[i].ID == current.ID,
+		})
+	}
+
+	this.sendSuccessResp(r)
+}
+
+// @Title Logout
+// @Description log out the current session
+// @Success 204 {string} "logout successfully"
+// @Failure 401 missing_token:      token is missing
+// @Failure 402 unknown_token:      token is unknown
+// @Failure 403 expired_token:      token is expired
+// @Failure 404 unauthorized_token: the permission of token is unmatched
+// @Failure 405 revoked_token:      the session of token is revoked
+// @Failure 500 system_error:       system error
+// @router / [delete]
+func (this *SessionController) Logout() {
+	action := "logout"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	ac, fr := this.getAccessController()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	if merr := models.DeleteSession(ac.TokenID); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.clearAccessToken()
+	this.sendSuccessResp(action + " successfully")
+}
+
+// @Title LogoutAll
+// @Description log out all the sessions of current user
+// @Success 204 {string} "logout all sessions successfully"
+// @Failure 401 missing_token:      token is missing
+// @Failure 402 unknown_token:      token is unknown
+// @Failure 403 expired_token:      token is expired
+// @Failure 404 unauthorized_token: the permission of token is unmatched
+// @Failure 405 revoked_token:      the session of token is revoked
+// @Failure 500 system_error:       system error
+// @router /all [delete]
+func (this *SessionController) LogoutAll() {
+	action := "logout all sessions"
+	sendResp := this.newFuncForSendingFailedResp(action)
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	if merr := models.RevokeSessions(current.Subject, ""); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	this.clearAccessToken()
+	this.sendSuccessResp(action + " successfully")
+}
+
+// @Title Revoke
+// @Description revoke a session of current user
+// @Param	:id	path 	string		true		"session id"
+// @Success 204 {string} "revoke session successfully"
+// @Failure 400 missing_url_path_parameter: missing url path parameter
+// @Failure 401 missing_token:              token is missing
+// @Failure 402 unknown_token:              token is unknown
+// @Failure 403 expired_token:              token is expired
+// @Failure 404 unauthorized_token:         the permission of token is unmatched
+// @Failure 405 revoked_token:              the session of token is revoked
+// @Failure 406 invalid_session:            the session does not exist
+// @Failure 500 system_error:               system error
+// @router /:id [delete]
+func (this *SessionController) Revoke() {
+	action := "revoke session"
+	sendResp := this.newFuncForSendingFailedResp(action)
+	id := this.GetString(":id")
+
+	current, fr := this.currentSession()
+	if fr != nil {
+		sendResp(fr)
+		return
+	}
+
+	s, merr := models.GetSession(id)
+	if merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+	if s.Subject != current.Subject {
+		this.sendFailedResponse(400, string(models.ErrInvalidSession), fmt.Errorf("not your session"), action)
+		return
+	}
+
+	if merr := models.DeleteSession(id); merr != nil {
+		this.sendModelErrorAsResp(merr, action)
+		return
+	}
+
+	if id == current.ID {
+		this.clearAccessToken()
+	}
+	this.sendSuccessResp(action + " successfully")
+}
+
+func (this *baseController) currentSession() (*models.Session, *failedApiResult) {
+	ac, fr := this.getAccessController()
+	if fr != nil {
+		return nil, fr
+	}
+
+	s, merr := models.GetSession(ac.TokenID)
+	if merr != nil {
+		return nil, parseSessionError(merr)
+	}
+	return s, nil
+}
+
+func newSession(ac *accessController) *models.Session {
+	s := &models.Session{
+		ID:         ac.TokenID,
+		Permission: ac.Permission,
+		IP:         ac.RemoteAddr,
+		Expiry:     ac.Expiry,
+	}
+
+	switch pl := ac.Payload.(type) {
+	case *acForCodePlatformPayload:
+		s.Subject = models.SessionSubjectOfCodePlatformUser(pl.Platform, pl.User)
+
+	case *acForCorpManagerPayload:
+		s.Subject = models.SessionSubjectOfCorpManager(pl.LinkID, pl.Email)
+		s.LinkID = pl.LinkID
+	}
+
+	return s
+}
+
+func checkSession(ac *accessController) *failedApiResult {
+	if ac.TokenID == "" {
+		return newFailedApiResult(401, errRevokedToken, fmt.Errorf("token without session"))
+	}
+
+	_, merr := models.GetSession(ac.TokenID)
+	return parseSessionError(merr)
+}
+
+func parseSessionError(merr models.IModelError) *failedApiResult {
+	if merr == nil {
+		return nil
+	}
+
+	if merr.IsErrorOf(models.ErrInvalidSession) {
+		return newFailedApiResult(401, errRevokedToken, merr)
+	}
+	return parseModelError(merr)
+}
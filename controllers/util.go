@@ -3,6 +3,7 @@ package controllers
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,6 +23,17 @@ const (
 	apiAccessController            = "access_controller"
 	contentTypeOfPDF               = "application/pdf"
 	fileNameOfUploadingOrgSignatue = "org_signature_file"
+	fileNameOfUploadingPDFLayout   = "layout"
+	fileNameOfUploadingPDFLogo     = "logo"
+	fieldOfRemovingPDFLogo         = "remove_logo"
+	fileNameOfSignatureImage       = "signature"
+	fileNameOfAllowList            = "allow_list"
+
+	// maxSizeOfPDFLayout is the max size of layout template of corporation signing pdf.
+	maxSizeOfPDFLayout = (64 << 10)
+
+	// maxSizeOfAllowList is the max size of csv file of employee allow-list.
+	maxSizeOfAllowList = (256 << 10)
 )
 
 func sendEmailToIndividual(linkID, to, subject string, builder email.IEmailMessageBulder) {
@@ -66,19 +78,13 @@ func notifyCorpManagerWhenAdding(linkID string, orgInfo *models.OrgInfo, info []
 	}
 }
 
-func getSingingInfo(info dbmodels.TypeSigningInfo, fields []dbmodels.Field) dbmodels.TypeSigningInfo {
-	if len(info) == 0 {
-		return info
-	}
-
-	r := dbmodels.TypeSigningInfo{}
-	for i := range fields {
-		fid := fields[i].ID
-		if v, ok := info[fid]; ok {
-			r[fid] = v
-		}
+// checkSigningInfo validates the signing info and reports the error of each field.
+func checkSigningInfo(info dbmodels.TypeSigningInfo, fields []dbmodels.Field) (dbmodels.TypeSigningInfo, *failedApiResult) {
+	v, merr := models.CheckSigningInfo(info, fields)
+	if merr != nil {
+		return nil, parseModelError(merr)
 	}
-	return r
+	return v, nil
 }
 
 func parseOrgAndRepo(s string) (string, string) {
@@ -171,6 +177,15 @@ func signHelper(linkID, claLang, applyTo string, doSign func(*models.CLAInfo) *f
 	return doSign(claInfo)
 }
 
+// getIndividualCLAText returns the text of individual cla which is printed on the receipt of signing.
+func getIndividualCLAText(linkID, claLang string) (string, *failedApiResult) {
+	v, merr := models.GetCLA(linkID, dbmodels.ApplyToIndividual, claLang)
+	if merr != nil {
+		return "", parseModelError(merr)
+	}
+	return v.Text, nil
+}
+
 func fetchInputPayloadData(input *[]byte, info interface{}) *failedApiResult {
 	if err := json.Unmarshal(*input, info); err != nil {
 		return newFailedApiResult(
@@ -196,6 +211,54 @@ func saveCorpCLAAtLocal(cla *models.CLACreateOpt, linkID string) *failedApiResul
 	return nil
 }
 
+// getOrgOfLinkForSigning returns the org of link if the link is open for signing.
+// The link of dco mode has no cla to sign.
+func getOrgOfLinkForSigning(linkID string) (*models.OrgInfo, *failedApiResult) {
+	link, merr := models.GetLink(linkID)
+	if merr != nil {
+		return nil, parseModelError(merr)
+	}
+
+	if link.IsDCO() {
+		return nil, newFailedApiResult(400, errDCOLink, fmt.Errorf("the link is in dco mode"))
+	}
+
+	if link.IsSuspended() {
+		return nil, newFailedApiResult(400, errSigningClosed, fmt.Errorf("the link is suspended"))
+	}
+	return &link.OrgInfo, nil
+}
+
+// lockOnTransfer locks on both the org or repo of link and the target one
+// in a fixed order to avoid locking each other. The lock file of target is created
+// if it doesn't exist.
+func lockOnTransfer(from, to *dbmodels.OrgRepo) (func(), *failedApiResult) {
+	paths := []string{
+		genOrgFileLockPath(from.Platform, from.OrgID, from.RepoID),
+		genOrgFileLockPath(to.Platform, to.OrgID, to.RepoID),
+	}
+	if err := util.CreateLockedFile(paths[1]); err != nil {
+		return nil, newFailedApiResult(500, errSystemError, err)
+	}
+	sort.Strings(paths)
+
+	unlock0, err := util.Lock(paths[0])
+	if err != nil {
+		return nil, newFailedApiResult(500, errSystemError, err)
+	}
+
+	unlock1, err := util.Lock(paths[1])
+	if err != nil {
+		unlock0()
+		return nil, newFailedApiResult(500, errSystemError, err)
+	}
+
+	return func() {
+		unlock1()
+		unlock0()
+	}, nil
+}
+
 func lockOnRepo(orgInfo *dbmodels.OrgInfo) (func(), *failedApiResult) {
 	unlock, err := util.Lock(genOrgFileLockPath(orgInfo.Platform, orgInfo.OrgID, orgInfo.RepoID))
 	if err != nil {
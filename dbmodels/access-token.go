@@ -0,0 +1,16 @@
+package dbmodels
+
+type PersonalAccessToken struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Owner      string   `json:"-"`
+	Permission string   `json:"permission"`
+	LinkID     string   `json:"-"`
+	Hash       string   `json:"-"`
+	Payload    []byte   `json:"-"`
+	Links      []string `json:"links"`
+	Scopes     []string `json:"scopes"`
+	Expiry     int64    `json:"expiry"`
+	CreatedAt  int64    `json:"created_at"`
+	LastUsed   int64    `json:"last_used"`
+}
@@ -0,0 +1,7 @@
+package dbmodels
+
+type AuthFailure struct {
+	Failures    int
+	LastFailure int64
+	LockedUntil int64
+}
@@ -0,0 +1,32 @@
+package dbmodels
+
+// CLATemplate is the reusable set of CLAs which is owned by a user or an org
+// on the code platform. It is owned by the submitter if Org is empty.
+type CLATemplate struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Platform  string `json:"platform"`
+	Org       string `json:"org"`
+	Submitter string `json:"submitter"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type CLATemplateCreateOption struct {
+	CLATemplate
+
+	IndividualCLAs []CLACreateOption
+	CorpCLAs       []CLACreateOption
+}
+
+type CLATemplateInfo struct {
+	CLATemplate
+
+	IndividualCLAs []CLADetail `json:"individual_clas"`
+	CorpCLAs       []CLADetail `json:"corp_clas"`
+}
+
+type CLATemplateListOption struct {
+	Platform  string
+	Submitter string
+	Orgs      []string
+}
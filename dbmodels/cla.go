@@ -5,6 +5,17 @@ const (
 	ApplyToIndividual  = "individual"
 )
 
+const (
+	FieldTypeText     = "text"
+	FieldTypeEmail    = "email"
+	FieldTypePhone    = "phone"
+	FieldTypeDate     = "date"
+	FieldTypeCountry  = "country"
+	FieldTypeURL      = "url"
+	FieldTypeEnum     = "enum"
+	FieldTypeCheckbox = "checkbox"
+)
+
 type CLA struct {
 	ID       string  `json:"id"`
 	Name     string  `json:"name"`
@@ -19,6 +30,13 @@ type Field struct {
 	Type        string `json:"type"`
 	Description string `json:"description"`
 	Required    bool   `json:"required"`
+
+	// the validation rules of the value, all of them are optional.
+	// Options is the allowed values which is required by the enum type.
+	Options   []string `json:"options,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	MinLength int      `json:"min_length,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
 }
 
 type CLAListOptions struct {
@@ -32,12 +50,18 @@ type CLAData struct {
 	URL      string  `json:"url"`
 	Language string  `json:"language"`
 	Fields   []Field `json:"fields"`
+
+	// Format is the format of cla text, such as plain, markdown and html.
+	Format string `json:"format"`
 }
 
 type CLADetail struct {
 	CLAData
 	CLAHash string `json:"cla_hash"`
 	Text    string `json:"text"`
+
+	// HTML is the safe html rendered from the text, it is not saved.
+	HTML string `json:"html,omitempty"`
 }
 
 type CLACreateOption struct {
@@ -49,6 +73,7 @@ type CLACreateOption struct {
 
 type CLAInfo struct {
 	CLALang          string
+	CLAFormat        string
 	CLAHash          string
 	OrgSignatureHash string
 	Fields           []Field
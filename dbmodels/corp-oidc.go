@@ -0,0 +1,12 @@
+package dbmodels
+
+// CorpOIDC is the configuration of identity provider which the managers
+// of corporation use to log in.
+type CorpOIDC struct {
+	LinkID       string `json:"link_id"`
+	CorpID       string `json:"corp_id"`
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"-"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
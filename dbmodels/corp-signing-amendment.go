@@ -0,0 +1,22 @@
+package dbmodels
+
+const (
+	AmendmentPending  = "pending"
+	AmendmentApplied  = "applied"
+	AmendmentRejected = "rejected"
+)
+
+// CorpSigningAmendment is the amendment of the contact information of corporation
+// signing which is submitted by the corporation administrator. Previous keeps the
+// values which are replaced when it is applied.
+type CorpSigningAmendment struct {
+	ID          string          `json:"id"`
+	Status      string          `json:"status"`
+	Changes     TypeSigningInfo `json:"changes"`
+	Previous    TypeSigningInfo `json:"previous,omitempty"`
+	SubmittedBy string          `json:"submitted_by"`
+	SubmittedAt int64           `json:"submitted_at"`
+	ReviewedBy  string          `json:"reviewed_by,omitempty"`
+	ReviewedAt  int64           `json:"reviewed_at,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+}
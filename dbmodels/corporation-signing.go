@@ -8,6 +8,47 @@ type CorporationSigningBasicInfo struct {
 	AdminName       string `json:"admin_name"`
 	CorporationName string `json:"corporation_name"`
 	Date            string `json:"date"`
+
+	// CounterSignature is set after the community counter-signs the pdf uploaded by corporation.
+	CounterSignature *CounterSignature `json:"counter_signature,omitempty"`
+
+	// ValidFrom is the time when the signing was signed or renewed last time.
+	// It is 0 for the signings before the validity was introduced.
+	ValidFrom        int64                `json:"valid_from,omitempty"`
+	Renewals         []CorpSigningRenewal `json:"renewals,omitempty"`
+	RenewalReminders int                  `json:"-"`
+
+	// ExpiresAt and ExpiryStatus are set if the link has a validity period.
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	ExpiryStatus string `json:"expiry_status,omitempty"`
+}
+
+const (
+	CorpSigningActive   = "active"
+	CorpSigningExpiring = "expiring"
+	// CorpSigningInGrace means the signing is expired, but the employees
+	// are still covered until the grace period ends.
+	CorpSigningInGrace = "in_grace"
+	CorpSigningExpired = "expired"
+)
+
+// CorpSigningRenewal records a renewal. The pdf of the previous period
+// is archived by the version of PreviousValidFrom.
+type CorpSigningRenewal struct {
+	PreviousValidFrom int64  `json:"previous_valid_from"`
+	RenewedAt         int64  `json:"renewed_at"`
+	Operator          string `json:"operator"`
+}
+
+// CounterSignature is the signature of community signatory on the corporation CLA.
+type CounterSignature struct {
+	Signatory string `json:"signatory"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Date      string `json:"date"`
+
+	// Digest is the sha256 of the pdf uploaded by corporation which was counter-signed.
+	Digest string `json:"digest"`
 }
 
 type CorporationSigningSummary struct {
@@ -20,4 +61,28 @@ type CorpSigningCreateOpt struct {
 	CorporationSigningBasicInfo
 
 	Info TypeSigningInfo `json:"info"`
+
+	PrivacyConsent
+}
+
+// PrivacyConsent is the version of privacy policy which the signer accepted.
+type PrivacyConsent struct {
+	PrivacyPolicyVersion string `json:"privacy_policy_version"`
+	PrivacyPolicyHash    string `json:"privacy_policy_hash"`
+}
+
+// OutdatedPrivacyConsent is the signer who only accepted an older version of privacy policy.
+type OutdatedPrivacyConsent struct {
+	Type  string `json:"type"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Date  string `json:"date"`
+
+	PrivacyConsent
+}
+
+// DeletedCorpSigning is the corporation signing which has been deleted.
+type DeletedCorpSigning struct {
+	LinkID     string `json:"link_id"`
+	AdminEmail string `json:"admin_email"`
 }
@@ -18,11 +18,20 @@ type IDB interface {
 type IModel interface {
 	ILink
 	ICorporationSigning
+	ICorpSigningAmendment
 	ICorporationManager
+	IEmployeeAllowList
 	IOrgEmail
 	IIndividualSigning
 	ICLA
+	ICLATemplate
 	IVerificationCode
+	IAuthFailure
+	ISession
+	IPersonalAccessToken
+	ICorpOIDC
+	IPersonalData
+	IRetention
 }
 
 type ICorporationSigning interface {
@@ -34,13 +43,46 @@ type ICorporationSigning interface {
 	ListDeletedCorpSignings(linkID string) ([]CorporationSigningBasicInfo, IDBError)
 	GetCorpSigningDetail(linkID, email string) ([]Field, *CorpSigningCreateOpt, IDBError)
 	GetCorpSigningBasicInfo(linkID, email string) (*CorporationSigningBasicInfo, IDBError)
+	ListOutdatedPrivacyConsents(linkID, version string) ([]OutdatedPrivacyConsent, IDBError)
+	CounterSignCorpCLA(linkID, email string, v *CounterSignature) IDBError
+	CancelCounterSignature(linkID, email string, v *CounterSignature) IDBError
+	RenewCorpSigning(linkID, email string, v *CorpSigningRenewal) IDBError
+	UpdateCorpSigningRenewalReminders(linkID, email string, prev, n int) (bool, IDBError)
+}
+
+// ICorpSigningAmendment records the amendments of contact information of corporation signing.
+// The info of signing is replaced if it is not nil.
+type ICorpSigningAmendment interface {
+	AddCorpSigningAmendment(linkID, email string, v *CorpSigningAmendment, info TypeSigningInfo) IDBError
+	ReviewCorpSigningAmendment(linkID, email string, v *CorpSigningAmendment, info TypeSigningInfo) IDBError
+	ListCorpSigningAmendments(linkID, email string) ([]CorpSigningAmendment, IDBError)
+}
+
+type IEmployeeAllowList interface {
+	AddEmployeesToAllowList(linkID, corpEmail string, v []AllowedEmployeeInfo) IDBError
+	RemoveEmployeeFromAllowList(linkID, corpEmail string, v *AllowedEmployee) IDBError
+	ListAllowedEmployees(linkID, corpEmail string) ([]AllowedEmployeeInfo, IDBError)
 }
 
 type IFile interface {
 	UploadCorporationSigningPDF(linkID, adminEmail string, pdf []byte) IDBError
 	DownloadCorporationSigningPDF(linkID, email, path string) IDBError
 	IsCorporationSigningPDFUploaded(linkID, email string) (bool, IDBError)
+	UploadArchivedCorporationSigningPDF(linkID, email, version string, pdf []byte) IDBError
+	DownloadArchivedCorporationSigningPDF(linkID, email, version, path string) IDBError
+	UploadCorpSigningAmendmentPDF(linkID, email, id string, pdf []byte) IDBError
+	DownloadCorpSigningAmendmentPDF(linkID, email, id, path string) IDBError
 	ListCorporationsWithPDFUploaded(linkID string) ([]string, IDBError)
+	DeleteCorpSigningPDFs(linkID, email string) IDBError
+	DeleteCorpSigningPDFsOfLink(linkID string) IDBError
+
+	UploadIndividualSigningPDF(linkID, signingID string, pdf []byte) IDBError
+	DownloadIndividualSigningPDF(linkID, signingID, path string) IDBError
+	DeleteIndividualSigningPDF(linkID, signingID string) IDBError
+	ListIndividualSigningPDFs(linkID string) ([]string, IDBError)
+
+	UploadCounterSignedPDF(linkID, adminEmail string, pdf []byte) IDBError
+	DownloadCounterSignedPDF(linkID, email, path string) IDBError
 }
 
 type ICorporationManager interface {
@@ -66,6 +108,10 @@ type IIndividualSigning interface {
 	UpdateIndividualSigning(linkID, email string, enabled bool) IDBError
 	IsIndividualSigned(linkID, email string) (bool, IDBError)
 	ListIndividualSigning(linkID, corpEmail, claLang string) ([]IndividualSigningBasicInfo, IDBError)
+	GetIndividualSigning(linkID, email string) (*IndividualSigningInfo, IDBError)
+	ListPendingEmployeeSignings(linkID string) ([]PendingEmployeeSigning, IDBError)
+	UpdateEmployeeApprovalState(linkID, email string, old, v *EmployeeApprovalState) (bool, IDBError)
+	ResolveEmployeeApproval(linkID, email, signingID string, enabled bool) IDBError
 
 	GetCLAInfoSigned(linkID, claLang, applyTo string) (*CLAInfo, IDBError)
 }
@@ -83,16 +129,110 @@ type ICLA interface {
 	GetCLAInfoToSign(linkID, claLang, applyTo string) (*CLAInfo, IDBError)
 }
 
+type ICLATemplate interface {
+	CreateCLATemplate(opt *CLATemplateCreateOption) IDBError
+	GetCLATemplate(id string) (*CLATemplateInfo, IDBError)
+	ListCLATemplates(opt *CLATemplateListOption) ([]CLATemplateInfo, IDBError)
+	DeleteCLATemplate(id string) IDBError
+	AddCLAToTemplate(id, applyTo string, cla *CLACreateOption) IDBError
+	DeleteCLAOfTemplate(id, applyTo, language string) IDBError
+	DownloadOrgSignatureOfTemplate(id, language string) ([]byte, IDBError)
+}
+
 type IVerificationCode interface {
 	CreateVerificationCode(opt VerificationCode) IDBError
 	GetVerificationCode(opt *VerificationCode) IDBError
 }
 
+type IAuthFailure interface {
+	GetAuthFailure(kind, key string) (*AuthFailure, IDBError)
+	AddAuthFailure(kind, key string, window int64) (*AuthFailure, IDBError)
+	LockAuthFailure(kind, key string, lockedUntil int64) IDBError
+	MarkAuthFailureNotified(kind, key string) (bool, IDBError)
+	DeleteAuthFailure(kind, key string) IDBError
+}
+
+type ISession interface {
+	CreateSession(opt *Session) IDBError
+	GetSession(id string) (*Session, IDBError)
+	RefreshSession(id, ip string, expiry int64) IDBError
+	ListSessions(subject string) ([]Session, IDBError)
+	DeleteSession(id string) IDBError
+	DeleteSessions(opt *SessionDeleteOption) IDBError
+}
+
+type IPersonalAccessToken interface {
+	CreatePersonalAccessToken(opt *PersonalAccessToken) IDBError
+	GetPersonalAccessToken(id string) (*PersonalAccessToken, IDBError)
+	ListPersonalAccessTokens(owner string) ([]PersonalAccessToken, IDBError)
+	UpdatePersonalAccessTokenLastUsed(id string, lastUsed int64) IDBError
+	DeletePersonalAccessToken(owner, id string) IDBError
+	DeletePersonalAccessTokensOfLink(linkID string) IDBError
+	DeletePersonalAccessTokensOfOwners(owners []string) IDBError
+}
+
+type ICorpOIDC interface {
+	SaveCorpOIDC(opt *CorpOIDC) IDBError
+	GetCorpOIDC(linkID, corpID string) (*CorpOIDC, IDBError)
+	ListCorpOIDCs(corpID string) ([]CorpOIDC, IDBError)
+	DeleteCorpOIDC(linkID, corpID string) IDBError
+}
+
+type IPersonalData interface {
+	GetPersonalData(email string) (*PersonalData, IDBError)
+	PseudonymiseIndividualSignings(email, pseudonym string) IDBError
+	DeleteEmployeeManagerOfEmail(email string) IDBError
+}
+
+// IRetention purges the data which is older than the time of retention.
+// All the purging methods return the number of purged records.
+type IRetention interface {
+	PurgeVerificationCodes(expiredBefore int64) (int64, IDBError)
+	PurgeDeletedCorpSignings(deletedBefore int64) (int64, IDBError)
+	// ListDeletedCorpSigningsToPurge lists the corporation signings deleted before the time
+	// of which the files can be purged. The files are kept if the corporation has signed
+	// again or has been deleted again recently, because they share the files.
+	ListDeletedCorpSigningsToPurge(deletedBefore int64) ([]DeletedCorpSigning, IDBError)
+	PurgeAuthRecords(before int64) (int64, IDBError)
+	ListUnlinkedLinks(unlinkedBefore int64) ([]string, IDBError)
+	PurgeDataOfLink(linkID string) IDBError
+	// ListLinkIDs lists the ids of all the links which have not been purged.
+	ListLinkIDs() ([]string, IDBError)
+
+	// AcquireLease returns true if the holder gets or renews the lease of
+	// the name, which is held by it until the expiry.
+	AcquireLease(name, holder string, expiry int64) (bool, IDBError)
+}
+
 type ILink interface {
-	GetLinkID(orgRepo *OrgRepo) (string, IDBError)
+	GetLinkOfRepo(orgRepo *OrgRepo) (*LinkInfo, IDBError)
+	ResolveLink(orgRepo *OrgRepo) (*LinkInfo, IDBError)
+	MoveRepoToLink(linkID string, orgRepo *OrgRepo, repo string) IDBError
+	RemoveRepoFromLink(linkID, repo string) IDBError
+	GetLink(linkID string) (*LinkInfo, IDBError)
+	TransferLink(linkID string, from, to *OrgRepo, record *LinkTransfer) IDBError
+	SetExcludedRepos(linkID string, repos []string) IDBError
 	CreateLink(info *LinkCreateOption) (string, IDBError)
 	Unlink(linkID string) IDBError
+	SuspendLink(linkID string, v *LinkSuspension) IDBError
+	SetEmployeePolicy(linkID, policy string) IDBError
+	SetCorpSigningValidity(linkID string, v *CorpSigningValidity) IDBError
+	SetAmendmentApproval(linkID string, required bool) IDBError
+	ListLinksWithCorpSigningValidity() ([]LinkInfo, IDBError)
+	ResumeLink(linkID string) IDBError
+	RestoreLink(linkID string) IDBError
+	GetArchivedLink(linkID string) (*LinkInfo, IDBError)
+	ListArchivedLinks(opt *LinkListOption) ([]LinkInfo, IDBError)
+	ExportLink(linkID string) (*LinkExport, IDBError)
 	GetOrgOfLink(linkID string) (*OrgInfo, IDBError)
 	ListLinks(opt *LinkListOption) ([]LinkInfo, IDBError)
 	GetAllLinks() ([]LinkInfo, IDBError)
+	ListLinksOfCLATemplate(id string) ([]LinkInfo, IDBError)
+
+	SavePDFLayout(linkID string, layout *PDFLayout) IDBError
+	GetPDFLayout(linkID string) (*PDFLayout, IDBError)
+	DeletePDFLayout(linkID string) IDBError
+
+	SaveSignatories(linkID string, v []Signatory) IDBError
+	GetSignatories(linkID string) ([]Signatory, IDBError)
 }
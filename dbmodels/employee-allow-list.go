@@ -0,0 +1,22 @@
+package dbmodels
+
+const (
+	AllowedByEmail  = "email"
+	AllowedByLogin  = "login"
+	AllowedByDomain = "domain"
+)
+
+// AllowedEmployee is an entry of the allow-list which the corporation
+// administrator uploads to pre-authorise the employees.
+type AllowedEmployee struct {
+	// Type is one of email, login and domain.
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type AllowedEmployeeInfo struct {
+	AllowedEmployee
+
+	AddedBy string `json:"added_by"`
+	AddedAt int64  `json:"added_at"`
+}
@@ -1,11 +1,35 @@
 package dbmodels
 
 type IndividualSigningBasicInfo struct {
+	// SigningID identifies the signing, it is printed on the receipt of signing.
+	SigningID string `json:"signing_id"`
+
 	ID      string `json:"id"`
 	Email   string `json:"email"`
 	Name    string `json:"name"`
 	Date    string `json:"date"`
 	Enabled bool   `json:"enabled"`
+
+	// ActivatedByAllowList means the employee signing was enabled because
+	// the employee was on the allow-list. It is cleared once the managers
+	// enable or disable the signing.
+	ActivatedByAllowList bool `json:"activated_by_allow_list,omitempty"`
+}
+
+// EmployeeApprovalState tracks the employee signing which is waiting for the
+// activation of managers. It is cleared once the signing is enabled or disabled.
+type EmployeeApprovalState struct {
+	PendingSince int64 `json:"pending_since"`
+	Reminders    int   `json:"reminders"`
+	RemindedAt   int64 `json:"reminded_at,omitempty"`
+	EscalatedAt  int64 `json:"escalated_at,omitempty"`
+}
+
+type PendingEmployeeSigning struct {
+	LinkID string `json:"link_id"`
+
+	IndividualSigningBasicInfo
+	EmployeeApprovalState
 }
 
 type IndividualSigningInfo struct {
@@ -13,4 +37,6 @@ type IndividualSigningInfo struct {
 
 	CLALanguage string          `json:"cla_language"`
 	Info        TypeSigningInfo `json:"info"`
+
+	PrivacyConsent
 }
@@ -5,15 +5,45 @@ import (
 	"strings"
 )
 
+const (
+	// LinkModeCLA requires the contributors to sign the cla.
+	LinkModeCLA = "cla"
+	// LinkModeDCO requires the commits to be signed off by their authors
+	// according to the Developer Certificate of Origin.
+	LinkModeDCO = "dco"
+)
+
+const (
+	// EmployeePolicySign requires the employees to sign, and the ones on the
+	// allow-list of corporation are enabled automatically.
+	EmployeePolicySign = "sign"
+	// EmployeePolicyPreauthorized regards the employees on the allow-list
+	// of corporation as signed without a separate signing.
+	EmployeePolicyPreauthorized = "preauthorized"
+)
+
+const (
+	LinkStatusActive = "active"
+	// LinkStatusSuspended means the signing is closed temporarily.
+	LinkStatusSuspended = "suspended"
+	// LinkStatusArchived means the link is unlinked. It can be restored
+	// before it is purged after the retention.
+	LinkStatusArchived = "archived"
+)
+
 type LinkCreateOption struct {
 	LinkID    string `json:"link_id"`
 	Submitter string `json:"submitter"`
+	Mode      string `json:"mode"`
 
 	OrgRepo
 	OrgAlias string `json:"org_alias"`
 
 	OrgEmail OrgEmailCreateInfo `json:"org_email"`
 
+	// TemplateID is the cla template which the link refers to.
+	TemplateID string `json:"template_id"`
+
 	IndividualCLAs []CLACreateOption `json:"individual_clas"`
 	CorpCLAs       []CLACreateOption `json:"corp_clas"`
 }
@@ -28,6 +58,81 @@ type LinkInfo struct {
 
 	LinkID    string `json:"link_id"`
 	Submitter string `json:"submitter"`
+	Mode      string `json:"mode"`
+	Status    string `json:"status"`
+
+	EmployeePolicy string `json:"employee_policy"`
+
+	CorpSigningValidity *CorpSigningValidity `json:"corp_signing_validity,omitempty"`
+	// AmendmentApproval means the amendments of corporation signing
+	// take effect only after the community approves them.
+	AmendmentApproval bool `json:"amendment_approval"`
+
+	ArchivedAt int64           `json:"archived_at,omitempty"`
+	Suspension *LinkSuspension `json:"suspension,omitempty"`
+
+	// Repos is the other repos of org which the link of repo covers.
+	Repos []string `json:"repos,omitempty"`
+	// ExcludedRepos is the repos which the link of org doesn't cover.
+	ExcludedRepos []string `json:"excluded_repos,omitempty"`
+
+	// Transfers is the history of moving the link to other org or repo.
+	Transfers []LinkTransfer `json:"transfers,omitempty"`
+
+	// TemplateID is the cla template which the link refers to. The clas
+	// added to the template later are added to the link too.
+	TemplateID string `json:"template_id,omitempty"`
+}
+
+// LinkTransfer records that the link is moved from an org or repo to another
+// one when it is renamed or transferred on the code platform.
+type LinkTransfer struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Operator      string `json:"operator"`
+	TransferredAt int64  `json:"transferred_at"`
+}
+
+func (this *LinkInfo) IsDCO() bool {
+	return this.Mode == LinkModeDCO
+}
+
+func (this *LinkInfo) IsSuspended() bool {
+	return this.Suspension != nil
+}
+
+func (this *LinkInfo) IsEmployeePreauthorized() bool {
+	return this.EmployeePolicy == EmployeePolicyPreauthorized
+}
+
+// CorpSigningValidity is the period in which the corporation signing is valid.
+// The corporation should renew the signing before it expires.
+type CorpSigningValidity struct {
+	ValidityDays int `json:"validity_days"`
+	// GraceDays is the days after the signing expires, in which
+	// the employees are still covered.
+	GraceDays int `json:"grace_days"`
+	// RemindBeforeDays is the days before the signing expires,
+	// when the corporation administrator is reminded to renew.
+	RemindBeforeDays int `json:"remind_before_days"`
+}
+
+// LinkSuspension is set when the owner suspends the link.
+type LinkSuspension struct {
+	Reason string `json:"reason"`
+	// CheckResult is returned as the result of checking whether
+	// the contributor has signed while the link is suspended.
+	CheckResult bool   `json:"check_result"`
+	Operator    string `json:"operator"`
+	SuspendedAt int64  `json:"suspended_at"`
+}
+
+// LinkExport is all the data of link which is exported by the owner.
+type LinkExport struct {
+	LinkInfo
+
+	IndividualSignings []IndividualSigningBasicInfo  `json:"individual_signings"`
+	CorpSignings       []CorporationSigningBasicInfo `json:"corporation_signings"`
 }
 
 type CLAOfLink struct {
@@ -81,3 +186,21 @@ type OrgInfo struct {
 	OrgAlias string `json:"org_alias"`
 	OrgEmail string `json:"org_email"`
 }
+
+// PDFLayout is the layout of corporation signing pdf which is customized by the owner of link.
+type PDFLayout struct {
+	// Layout is the text of layout template in yaml.
+	Layout string `json:"layout"`
+	// Logo is the image printed in the header of each page.
+	Logo      []byte `json:"-"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// Signatory is the representative of community who is authorised to
+// counter-sign the corporation CLAs.
+type Signatory struct {
+	// ID is the account of signatory on the code platform.
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
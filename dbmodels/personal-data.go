@@ -0,0 +1,26 @@
+package dbmodels
+
+// PersonalData is all the data about an email which is stored on all the links.
+type PersonalData struct {
+	IndividualSignings []IndividualSigningOfLink `json:"individual_signings"`
+	CorpSignings       []CorpSigningOfLink       `json:"corporation_signings"`
+	CorpManagers       []CorpManagerOfLink       `json:"corporation_managers"`
+}
+
+type IndividualSigningOfLink struct {
+	LinkID string `json:"link_id"`
+
+	IndividualSigningInfo
+}
+
+type CorpSigningOfLink struct {
+	LinkID string `json:"link_id"`
+
+	CorpSigningCreateOpt
+}
+
+type CorpManagerOfLink struct {
+	LinkID string `json:"link_id"`
+
+	CorporationManagerListResult
+}
@@ -0,0 +1,20 @@
+package dbmodels
+
+type Session struct {
+	ID         string `json:"id"`
+	Subject    string `json:"-"`
+	Permission string `json:"permission"`
+	LinkID     string `json:"link_id,omitempty"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsed   int64  `json:"last_used"`
+	Expiry     int64  `json:"expiry"`
+}
+
+type SessionDeleteOption struct {
+	LinkID   string
+	Subjects []string
+
+	// the session which will be kept
+	Except string
+}
@@ -0,0 +1,79 @@
+// dco-check checks the commit message locally before it is pushed.
+//
+// It can be installed as the commit-msg hook of git:
+//
+//	cp dco-check .git/hooks/commit-msg
+//
+// or be run with the message file and the author explicitly:
+//
+//	dco-check -name "Jane Doe" -email jane@example.com .git/COMMIT_EDITMSG
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dco"
+)
+
+var reIdent = regexp.MustCompile(`^(.*?)\s*<([^<>]*)>`)
+
+func main() {
+	name := flag.String("name", "", "name of author, it is fetched from git if empty")
+	email := flag.String("email", "", "email of author, it is fetched from git if empty")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: dco-check [-name name] [-email email] <commit message file>")
+		os.Exit(2)
+	}
+
+	msg, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "read commit message failed, err:%s\n", err.Error())
+		os.Exit(2)
+	}
+
+	if *name == "" || *email == "" {
+		n, e, err := authorOfGit()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "get author from git failed, err:%s\n", err.Error())
+			os.Exit(2)
+		}
+		if *name == "" {
+			*name = n
+		}
+		if *email == "" {
+			*email = e
+		}
+	}
+
+	c := dco.Commit{
+		Message:     string(msg),
+		AuthorName:  *name,
+		AuthorEmail: *email,
+	}
+	if r := c.Check(); !r.IsSignedOff() {
+		fmt.Fprintf(os.Stderr, "DCO check failed: %s\n", r.Detail)
+		fmt.Fprintln(os.Stderr, "use 'git commit -s' to sign off the commit")
+		os.Exit(1)
+	}
+}
+
+func authorOfGit() (string, string, error) {
+	out, err := exec.Command("git", "var", "GIT_AUTHOR_IDENT").Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	v := reIdent.FindStringSubmatch(strings.TrimSpace(string(out)))
+	if len(v) != 3 {
+		return "", "", fmt.Errorf("unknown ident: %s", out)
+	}
+	return v[1], v[2], nil
+}
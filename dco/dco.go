@@ -0,0 +1,129 @@
+// Package dco checks that the commits are signed off by their authors
+// according to the Developer Certificate of Origin.
+package dco
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	ReasonNoSignoff        = "no_signoff"
+	ReasonUnmatchedSignoff = "unmatched_signoff"
+	ReasonMissingAuthor    = "missing_author"
+)
+
+var (
+	reSignoff = regexp.MustCompile(`(?mi)^[ \t]*Signed-off-by:[ \t]*(.*?)[ \t]*<([^<>\s]+)>[ \t]*$`)
+	reTrailer = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:`)
+)
+
+type Signoff struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type Commit struct {
+	SHA         string `json:"sha"`
+	Message     string `json:"message"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email"`
+}
+
+// Result is the result of checking a commit. Reason is empty if the commit is signed off.
+type Result struct {
+	SHA    string `json:"sha"`
+	Reason string `json:"reason,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (this *Result) IsSignedOff() bool {
+	return this.Reason == ""
+}
+
+// ParseSignoffs returns all the Signed-off-by trailers in the commit message.
+// The Signed-off-by quoted in the body of message is not a trailer.
+func ParseSignoffs(msg string) []Signoff {
+	v := reSignoff.FindAllStringSubmatch(trailerBlock(msg), -1)
+	if len(v) == 0 {
+		return nil
+	}
+
+	r := make([]Signoff, 0, len(v))
+	for _, item := range v {
+		r = append(r, Signoff{Name: item[1], Email: item[2]})
+	}
+	return r
+}
+
+// trailerBlock returns the last paragraph of the commit message if all the
+// lines of it are trailers, as git does. The message of one paragraph has no
+// trailers, because the first paragraph is the subject.
+func trailerBlock(msg string) string {
+	msg = strings.TrimRight(strings.ReplaceAll(msg, "\r\n", "\n"), " \t\n")
+	lines := strings.Split(msg, "\n")
+
+	start := len(lines)
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start == 0 {
+		return ""
+	}
+
+	block := lines[start:]
+	for _, item := range block {
+		isContinuation := item[0] == ' ' || item[0] == '\t'
+		isCherryPick := strings.HasPrefix(item, "(cherry picked from commit ")
+
+		if !isContinuation && !isCherryPick && !reTrailer.MatchString(item) {
+			return ""
+		}
+	}
+
+	return strings.Join(block, "\n")
+}
+
+// Check checks whether the commit is signed off by its author. Both the name
+// and email of sign-off should be same as the ones of author, case-insensitively.
+func (this *Commit) Check() Result {
+	r := Result{SHA: this.SHA}
+
+	name := strings.TrimSpace(this.AuthorName)
+	email := strings.TrimSpace(this.AuthorEmail)
+	if name == "" || email == "" {
+		r.Reason = ReasonMissingAuthor
+		r.Detail = "the name or email of author is missing"
+		return r
+	}
+
+	signoffs := ParseSignoffs(this.Message)
+	if len(signoffs) == 0 {
+		r.Reason = ReasonNoSignoff
+		r.Detail = "there is no Signed-off-by in the commit message"
+		return r
+	}
+
+	for _, item := range signoffs {
+		if strings.EqualFold(item.Email, email) && strings.EqualFold(item.Name, name) {
+			return r
+		}
+	}
+
+	r.Reason = ReasonUnmatchedSignoff
+	r.Detail = fmt.Sprintf("expected Signed-off-by: %s <%s>", name, email)
+	return r
+}
+
+// CheckCommits checks all the commits and returns the results of the ones
+// which are not signed off.
+func CheckCommits(commits []Commit) []Result {
+	var r []Result
+	for i := range commits {
+		if v := commits[i].Check(); !v.IsSignedOff() {
+			r = append(r, v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,115 @@
+package dco
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSignoffs(t *testing.T) {
+	cases := []struct {
+		msg    string
+		expect []Signoff
+	}{
+		{
+			msg: "fix a bug\n\nSigned-off-by: Alice <alice@a.com>\n",
+			expect: []Signoff{
+				{Name: "Alice", Email: "alice@a.com"},
+			},
+		},
+		{
+			msg: "fix a bug\r\n\r\nsome details\r\n\r\n" +
+				"Reviewed-by: Bob <bob@b.com>\r\n" +
+				"signed-off-by:  Alice Li  <alice@a.com> \r\n" +
+				"Signed-off-by: Bob <bob@b.com>\r\n\r\n",
+			expect: []Signoff{
+				{Name: "Alice Li", Email: "alice@a.com"},
+				{Name: "Bob", Email: "bob@b.com"},
+			},
+		},
+		{
+			msg: "fix a bug\n\nSigned-off-by: Alice <alice@a.com>\n" +
+				"(cherry picked from commit 0123456789abcdef)\n",
+			expect: []Signoff{
+				{Name: "Alice", Email: "alice@a.com"},
+			},
+		},
+		{
+			// it is only quoted in the body
+			msg: "fix a bug\n\nthe commit should have\nSigned-off-by: Alice <alice@a.com>\n",
+		},
+		{
+			// the last paragraph is not a trailer block
+			msg: "fix a bug\n\nSigned-off-by: Alice <alice@a.com>\n\nmore details\n",
+		},
+		{
+			// the subject is not a trailer block
+			msg: "Signed-off-by: Alice <alice@a.com>\n",
+		},
+		{
+			msg: "fix a bug\n\nSigned-off-by: Alice\n",
+		},
+	}
+
+	for _, c := range cases {
+		if v := ParseSignoffs(c.msg); !reflect.DeepEqual(v, c.expect) {
+			t.Errorf("ParseSignoffs(%q) = %+v, expect %+v", c.msg, v, c.expect)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	msg := "fix a bug\n\nSigned-off-by: Alice <Alice@A.com>\n"
+
+	cases := []struct {
+		commit Commit
+		reason string
+	}{
+		{
+			commit: Commit{Message: msg, AuthorName: "alice", AuthorEmail: "alice@a.com"},
+		},
+		{
+			commit: Commit{Message: msg, AuthorName: "Bob", AuthorEmail: "alice@a.com"},
+			reason: ReasonUnmatchedSignoff,
+		},
+		{
+			commit: Commit{Message: msg, AuthorName: "Alice", AuthorEmail: "bob@b.com"},
+			reason: ReasonUnmatchedSignoff,
+		},
+		{
+			commit: Commit{Message: "fix a bug", AuthorName: "Alice", AuthorEmail: "alice@a.com"},
+			reason: ReasonNoSignoff,
+		},
+		{
+			commit: Commit{Message: msg, AuthorName: " ", AuthorEmail: "alice@a.com"},
+			reason: ReasonMissingAuthor,
+		},
+	}
+
+	for i, c := range cases {
+		if v := c.commit.Check(); v.Reason != c.reason {
+			t.Errorf("case %d: expect reason %q, got %q", i, c.reason, v.Reason)
+		}
+	}
+}
+
+func TestCheckCommits(t *testing.T) {
+	commits := []Commit{
+		{
+			SHA:         "a",
+			Message:     "fix a bug\n\nSigned-off-by: Alice <alice@a.com>",
+			AuthorName:  "Alice",
+			AuthorEmail: "alice@a.com",
+		},
+		{
+			SHA:         "b",
+			Message:     "fix a bug",
+			AuthorName:  "Alice",
+			AuthorEmail: "alice@a.com",
+		},
+	}
+
+	v := CheckCommits(commits)
+	if len(v) != 1 || v[0].SHA != "b" || v[0].Reason != ReasonNoSignoff {
+		t.Fatalf("unexpected results: %+v", v)
+	}
+}
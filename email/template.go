@@ -19,6 +19,16 @@ const (
 	TmplActivatingEmployee  = "activating employee"
 	TmplInactivaingEmployee = "inactivating employee"
 	TmplRemovingingEmployee = "removing employee"
+	TmplAuthLockout         = "auth lockout"
+	TmplPersonalDataCode    = "personal data code"
+	TmplErasingPersonalData = "erasing personal data"
+	TmplCounterSigning      = "counter signing"
+	TmplRemindingManager    = "reminding manager"
+	TmplEscalatingApproval  = "escalating approval"
+	TmplDelayingApproval    = "delaying approval"
+	TmplRenewingCorpSigning = "renewing corp signing"
+	TmplAmendingCorpSigning = "amending corp signing"
+	TmplAmendmentResult     = "amendment result"
 )
 
 var msgTmpl = map[string]*template.Template{}
@@ -36,6 +46,16 @@ func initTemplate() error {
 		TmplActivatingEmployee:  "./conf/email-template/activating-employee.tmpl",
 		TmplInactivaingEmployee: "./conf/email-template/inactivating-employee.tmpl",
 		TmplRemovingingEmployee: "./conf/email-template/removing-employee.tmpl",
+		TmplAuthLockout:         "./conf/email-template/auth-lockout.tmpl",
+		TmplPersonalDataCode:    "./conf/email-template/personal-data-code.tmpl",
+		TmplErasingPersonalData: "./conf/email-template/erasing-personal-data.tmpl",
+		TmplCounterSigning:      "./conf/email-template/counter-signing.tmpl",
+		TmplRemindingManager:    "./conf/email-template/reminding-corp-manager.tmpl",
+		TmplEscalatingApproval:  "./conf/email-template/escalating-employee-approval.tmpl",
+		TmplDelayingApproval:    "./conf/email-template/delaying-employee-approval.tmpl",
+		TmplRenewingCorpSigning: "./conf/email-template/renewing-corp-signing.tmpl",
+		TmplAmendingCorpSigning: "./conf/email-template/amending-corp-signing.tmpl",
+		TmplAmendmentResult:     "./conf/email-template/corp-signing-amendment-result.tmpl",
 	}
 
 	for name, path := range items {
@@ -88,7 +108,11 @@ func (this CorporationSigning) GenEmailMsg() (*EmailMessage, error) {
 }
 
 type IndividualSigning struct {
-	Name string
+	Name       string
+	Org        string
+	Date       string
+	SigningID  string
+	ProjectURL string
 }
 
 func (this IndividualSigning) GenEmailMsg() (*EmailMessage, error) {
@@ -137,8 +161,12 @@ func (this RemovingCorpManager) GenEmailMsg() (*EmailMessage, error) {
 type EmployeeSigning struct {
 	Name       string
 	Org        string
+	Date       string
+	SigningID  string
 	ProjectURL string
 	Managers   string
+	// Activated is true if the signing is activated by the allow-list.
+	Activated bool
 }
 
 func (this EmployeeSigning) GenEmailMsg() (*EmailMessage, error) {
@@ -150,6 +178,11 @@ type NotifyingManager struct {
 	ProjectURL       string
 	URLOfCLAPlatform string
 	Org              string
+
+	// the one-click links which are set if the employee approval is enabled
+	ApproveURL      string
+	RejectURL       string
+	LinkExpiryHours int64
 }
 
 func (this NotifyingManager) GenEmailMsg() (*EmailMessage, error) {
@@ -182,3 +215,125 @@ func (this EmployeeNotification) GenEmailMsg() (*EmailMessage, error) {
 
 	return nil, fmt.Errorf("do nothing")
 }
+
+type AuthLockout struct {
+	User       string
+	Action     string
+	Minutes    int64
+	Org        string
+	ProjectURL string
+}
+
+func (this AuthLockout) GenEmailMsg() (*EmailMessage, error) {
+	return genEmailMsg(TmplAuthLockout, this)
+}
+
+type PersonalDataCode struct {
+	Email      string
+	Org        string
+	Code       string
+	ProjectURL string
+}
+
+func (this PersonalDataCode) GenEmailMsg() (*EmailMessage, error) {
+	return genEmailMsg(TmplPersonalDataCode, this)
+}
+
+type ErasingPersonalData struct {
+	Pseudonym  string
+	Org        string
+	ProjectURL string
+}
+
+func (this ErasingPersonalData) GenEmailMsg() (*EmailMessage, error) {
+	return genEmailMsg(TmplErasingPersonalData, this)
+}
+
+type CounterSigning struct {
+	AdminName   string
+	Corporation string
+	Signatory   string
+	Date        string
+	Digest      string
+	Org         string
+	ProjectURL  string
+}
+
+func (this CounterSigning) GenEmailMsg() (*EmailMessage, error) {
+	return genEmailMsg(TmplCounterSigning, this)
+}
+
+// EmployeeApprovalReminder is sent when the employee signing has waited for the
+// activation for a long time. It reminds the employee managers by default.
+type EmployeeApprovalReminder struct {
+	// Escalation notifies the corporation administrator.
+	Escalation bool
+	// Delay notifies the employee.
+	Delay bool
+
+	Name             string
+	EmployeeEmail    string
+	PendingDays      int64
+	Managers         string
+	Org              string
+	ProjectURL       string
+	URLOfCLAPlatform string
+}
+
+func (this EmployeeApprovalReminder) GenEmailMsg() (*EmailMessage, error) {
+	if this.Escalation {
+		return genEmailMsg(TmplEscalatingApproval, this)
+	}
+
+	if this.Delay {
+		return genEmailMsg(TmplDelayingApproval, this)
+	}
+
+	return genEmailMsg(TmplRemindingManager, this)
+}
+
+// CorpSigningRenewal reminds the corporation administrator to renew the signing
+// before it expires, or after it is expired.
+type CorpSigningRenewal struct {
+	// Expired means the signing is expired and the employees are
+	// covered only until the end of grace period.
+	Expired bool
+
+	AdminName        string
+	Corporation      string
+	ExpiryDate       string
+	GraceEndDate     string
+	Org              string
+	ProjectURL       string
+	URLOfCLAPlatform string
+}
+
+func (this CorpSigningRenewal) GenEmailMsg() (*EmailMessage, error) {
+	return genEmailMsg(TmplRenewingCorpSigning, this)
+}
+
+// CorpSigningAmendment notifies the community of the amendment of contact information
+// of corporation signing, or notifies the corporation administrator of the result of it.
+type CorpSigningAmendment struct {
+	// Pending means the amendment is waiting for the approval of community.
+	Pending bool
+	// ToAdmin notifies the corporation administrator.
+	ToAdmin bool
+	// Rejected means the amendment is rejected by the community.
+	Rejected bool
+
+	AdminName        string
+	Corporation      string
+	Changes          string
+	Reason           string
+	Org              string
+	ProjectURL       string
+	URLOfCLAPlatform string
+}
+
+func (this CorpSigningAmendment) GenEmailMsg() (*EmailMessage, error) {
+	if this.ToAdmin {
+		return genEmailMsg(TmplAmendmentResult, this)
+	}
+	return genEmailMsg(TmplAmendingCorpSigning, this)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/opensourceways/app-cla-server/controllers"
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
 	"github.com/opensourceways/app-cla-server/mongodb"
 	"github.com/opensourceways/app-cla-server/obs"
 	_ "github.com/opensourceways/app-cla-server/obs/huaweicloud"
@@ -69,15 +70,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := models.InitPrivacyPolicies(&AppConfig.PrivacyPolicy); err != nil {
+		beego.Error(err)
+		os.Exit(1)
+	}
+
 	if err := platformAuth.Initialize(AppConfig.CodePlatformConfigFile); err != nil {
 		beego.Error(err)
 		os.Exit(1)
 	}
 
 	if err := pdf.InitPDFGenerator(
-		AppConfig.PythonBin,
 		AppConfig.PDFOutDir,
 		AppConfig.PDFOrgSignatureDir,
+		AppConfig.PDFLanguagesConfigFile,
 	); err != nil {
 		beego.Error(err)
 		os.Exit(1)
@@ -85,6 +91,12 @@ func main() {
 
 	worker.InitEmailWorker(pdf.GetPDFGenerator())
 
+	worker.StartJanitor(AppConfig.Retention, AppConfig.PDFOutDir, AppConfig.PDFOrgSignatureDir)
+
+	worker.StartApprovalReminder(AppConfig.ApprovalReminder, AppConfig.CLAPlatformURL)
+
+	worker.StartRenewalReminder(AppConfig.RenewalReminder, AppConfig.CLAPlatformURL)
+
 	if err := controllers.LoadLinks(); err != nil {
 		beego.Error(err)
 		os.Exit(1)
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	ScopeReadSignings = "read_signings"
+	ScopeExport       = "export"
+	ScopeCheck        = "check"
+
+	prefixOfPersonalAccessToken = "pat_"
+)
+
+type PersonalAccessToken = dbmodels.PersonalAccessToken
+
+type PersonalAccessTokenCreateOption struct {
+	Name   string   `json:"name"`
+	Links  []string `json:"links"`
+	Scopes []string `json:"scopes"`
+
+	// the seconds before the token expires. 0 means it never expires.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+func (this *PersonalAccessTokenCreateOption) Validate() IModelError {
+	if this.Name == "" {
+		return newModelError(ErrInvalidAccessToken, fmt.Errorf("missing name"))
+	}
+
+	if len(this.Links) == 0 {
+		return newModelError(ErrInvalidAccessToken, fmt.Errorf("missing links"))
+	}
+
+	if len(this.Scopes) == 0 {
+		return newModelError(ErrInvalidAccessToken, fmt.Errorf("missing scopes"))
+	}
+
+	for _, item := range this.Scopes {
+		switch item {
+		case ScopeReadSignings, ScopeExport, ScopeCheck:
+		default:
+			return newModelError(ErrInvalidAccessToken, fmt.Errorf("unknown scope: %s", item))
+		}
+	}
+
+	if this.ExpiresIn < 0 {
+		return newModelError(ErrInvalidAccessToken, fmt.Errorf("invalid expires_in"))
+	}
+
+	return nil
+}
+
+// Create saves the token and returns the plain token which can't be fetched again.
+func (this *PersonalAccessTokenCreateOption) Create(owner, permission, linkID string, payload []byte) (string, string, IModelError) {
+	id := util.RandStr(16, "alphanum")
+	secret := util.RandStr(32, "alphanum")
+
+	opt := dbmodels.PersonalAccessToken{
+		ID:         id,
+		Name:       this.Name,
+		Owner:      owner,
+		Permission: permission,
+		LinkID:     linkID,
+		Hash:       hashOfAccessTokenSecret(secret),
+		Payload:    payload,
+		Links:      this.Links,
+		Scopes:     this.Scopes,
+		CreatedAt:  util.Now(),
+	}
+	if this.ExpiresIn > 0 {
+		opt.Expiry = util.Expiry(this.ExpiresIn)
+	}
+
+	if err := dbmodels.GetDB().CreatePersonalAccessToken(&opt); err != nil {
+		return "", "", parseDBError(err)
+	}
+
+	return id, fmt.Sprintf("%s%s.%s", prefixOfPersonalAccessToken, id, secret), nil
+}
+
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, prefixOfPersonalAccessToken)
+}
+
+// CheckPersonalAccessToken verifies the token and records the time it was used.
+func CheckPersonalAccessToken(token string) (*PersonalAccessToken, IModelError) {
+	v := strings.SplitN(strings.TrimPrefix(token, prefixOfPersonalAccessToken), ".", 2)
+	if len(v) != 2 || v[0] == "" || v[1] == "" {
+		return nil, newModelError(ErrInvalidAccessToken, fmt.Errorf("invalid format"))
+	}
+
+	r, err := dbmodels.GetDB().GetPersonalAccessToken(v[0])
+	if err != nil {
+		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return nil, newModelError(ErrInvalidAccessToken, fmt.Errorf("token is revoked"))
+		}
+		return nil, parseDBError(err)
+	}
+
+	h := hashOfAccessTokenSecret(v[1])
+	if subtle.ConstantTimeCompare([]byte(h), []byte(r.Hash)) != 1 {
+		return nil, newModelError(ErrInvalidAccessToken, fmt.Errorf("invalid token"))
+	}
+
+	now := util.Now()
+	if r.Expiry > 0 && r.Expiry < now {
+		return nil, newModelError(ErrInvalidAccessToken, fmt.Errorf("token is expired"))
+	}
+
+	if err := dbmodels.GetDB().UpdatePersonalAccessTokenLastUsed(r.ID, now); err != nil {
+		return nil, parseDBError(err)
+	}
+
+	return r, nil
+}
+
+func ListPersonalAccessTokens(owner string) ([]PersonalAccessToken, IModelError) {
+	v, err := dbmodels.GetDB().ListPersonalAccessTokens(owner)
+	return v, parseDBError(err)
+}
+
+func DeletePersonalAccessToken(owner, id string) IModelError {
+	err := dbmodels.GetDB().DeletePersonalAccessToken(owner, id)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrInvalidAccessToken, fmt.Errorf("no such token"))
+	}
+	return parseDBError(err)
+}
+
+// RevokePersonalAccessTokensOfCorpManagers deletes the tokens created by the
+// corporation managers, such as when they are removed or reset the password.
+func RevokePersonalAccessTokensOfCorpManagers(linkID string, emails []string) IModelError {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	owners := make([]string, 0, len(emails))
+	for _, item := range emails {
+		owners = append(owners, SessionSubjectOfCorpManager(linkID, item))
+	}
+
+	err := dbmodels.GetDB().DeletePersonalAccessTokensOfOwners(owners)
+	return parseDBError(err)
+}
+
+// DeletePersonalAccessTokensOfLink removes the link from the scope of tokens,
+// and deletes the tokens which are left without any link.
+func DeletePersonalAccessTokensOfLink(linkID string) IModelError {
+	err := dbmodels.GetDB().DeletePersonalAccessTokensOfLink(linkID)
+	return parseDBError(err)
+}
+
+func hashOfAccessTokenSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
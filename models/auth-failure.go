@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	authFailureOfAccount          = "account"
+	authFailureOfVerificationCode = "verification_code"
+	authFailureOfIP               = "ip"
+)
+
+// AuthAttempt counts the failed authentications of an account,
+// a verification code or a client ip.
+type AuthAttempt struct {
+	kind string
+	key  string
+}
+
+func NewAuthAttemptOfAccount(user string) AuthAttempt {
+	return AuthAttempt{kind: authFailureOfAccount, key: strings.ToLower(user)}
+}
+
+func NewAuthAttemptOfVerificationCode(email, purpose string) AuthAttempt {
+	return AuthAttempt{
+		kind: authFailureOfVerificationCode,
+		key:  fmt.Sprintf("%s/%s", strings.ToLower(email), purpose),
+	}
+}
+
+func NewAuthAttemptOfIP(ip string) AuthAttempt {
+	return AuthAttempt{kind: authFailureOfIP, key: ip}
+}
+
+func (this AuthAttempt) maxFailures() int {
+	cfg := &config.AppConfig.AuthFailure
+
+	switch this.kind {
+	case authFailureOfAccount:
+		return cfg.MaxFailuresOfAccount
+	case authFailureOfVerificationCode:
+		return cfg.MaxFailuresOfVerificationCode
+	default:
+		return cfg.MaxFailuresOfIP
+	}
+}
+
+func (this AuthAttempt) lockedErrCode() ModelErrCode {
+	switch this.kind {
+	case authFailureOfAccount:
+		return ErrAccountLocked
+	case authFailureOfVerificationCode:
+		return ErrVerificationCodeLocked
+	default:
+		return ErrClientIPLocked
+	}
+}
+
+func (this AuthAttempt) delay(failures int) int64 {
+	cfg := &config.AppConfig.AuthFailure
+
+	d := cfg.DelayStep * int64(failures)
+	if d > cfg.MaxDelay {
+		return cfg.MaxDelay
+	}
+	return d
+}
+
+// Check returns error if the attempt is locked or too close to the last failure.
+func (this AuthAttempt) Check() IModelError {
+	v, err := dbmodels.GetDB().GetAuthFailure(this.kind, this.key)
+	if err != nil {
+		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return nil
+		}
+		return parseDBError(err)
+	}
+
+	now := util.Now()
+	if v.LockedUntil > now {
+		return newModelError(
+			this.lockedErrCode(),
+			fmt.Errorf("too many failed attempts, try again after %d seconds", v.LockedUntil-now),
+		)
+	}
+
+	if t := v.LastFailure + this.delay(v.Failures); t > now {
+		return newModelError(
+			ErrFrequentAuthAttempts,
+			fmt.Errorf("failed attempt just now, try again after %d seconds", t-now),
+		)
+	}
+
+	return nil
+}
+
+// Fail records a failed attempt. It returns the error of lockout
+// when the failures reach the limit.
+func (this AuthAttempt) Fail() IModelError {
+	cfg := &config.AppConfig.AuthFailure
+
+	v, err := dbmodels.GetDB().AddAuthFailure(this.kind, this.key, cfg.FailureWindow)
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	if v.Failures < this.maxFailures() {
+		return nil
+	}
+
+	err = dbmodels.GetDB().LockAuthFailure(this.kind, this.key, util.Now()+cfg.LockoutDuration)
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	return newModelError(
+		this.lockedErrCode(),
+		fmt.Errorf("too many failed attempts, try again after %d seconds", cfg.LockoutDuration),
+	)
+}
+
+func (this AuthAttempt) Succeed() IModelError {
+	err := dbmodels.GetDB().DeleteAuthFailure(this.kind, this.key)
+	return parseDBError(err)
+}
+
+// MarkNotified returns true if it is the first time to notify the lockout.
+func (this AuthAttempt) MarkNotified() (bool, IModelError) {
+	v, err := dbmodels.GetDB().MarkAuthFailureNotified(this.kind, this.key)
+	return v, parseDBError(err)
+}
+
+func IsAuthLockedError(err IModelError) bool {
+	return err.IsErrorOf(ErrAccountLocked) ||
+		err.IsErrorOf(ErrVerificationCodeLocked) ||
+		err.IsErrorOf(ErrClientIPLocked)
+}
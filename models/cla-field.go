@@ -0,0 +1,265 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+const (
+	// maxLengthOfFieldValue is the length limit of value whose field doesn't set max length.
+	maxLengthOfFieldValue = 512
+
+	fieldValueChecked   = "true"
+	fieldValueUnchecked = "false"
+
+	dateLayoutOfField = "2006-01-02"
+)
+
+var (
+	validFieldTypes = map[string]bool{
+		dbmodels.FieldTypeText:     true,
+		dbmodels.FieldTypeEmail:    true,
+		dbmodels.FieldTypePhone:    true,
+		dbmodels.FieldTypeDate:     true,
+		dbmodels.FieldTypeCountry:  true,
+		dbmodels.FieldTypeURL:      true,
+		dbmodels.FieldTypeEnum:     true,
+		dbmodels.FieldTypeCheckbox: true,
+	}
+
+	phoneRegexp = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{4,24}[0-9]$`)
+
+	// countryCodes is the officially assigned codes of ISO 3166-1 alpha-2.
+	countryCodes = func() map[string]bool {
+		codes := "AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ " +
+			"BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+			"CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ " +
+			"DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+			"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY " +
+			"HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+			"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY " +
+			"MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ " +
+			"NA NC NE NF NG NI NL NO NP NR NU NZ OM " +
+			"PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+			"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ " +
+			"TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ " +
+			"UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+		m := map[string]bool{}
+		for _, item := range strings.Fields(codes) {
+			m[item] = true
+		}
+		return m
+	}()
+)
+
+// FieldError is the reason why the value of a field is invalid.
+type FieldError struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// FieldErrors is returned when some values of signing info are invalid.
+// It reports the error of each field.
+type FieldErrors []FieldError
+
+func (this FieldErrors) Error() string {
+	v := make([]string, 0, len(this))
+	for _, item := range this {
+		v = append(v, fmt.Sprintf("%s: %s", item.Title, item.Reason))
+	}
+	return "invalid signing info, " + strings.Join(v, "; ")
+}
+
+func (this FieldErrors) IsErrorOf(code ModelErrCode) bool {
+	return code == ErrInvalidSigningInfo
+}
+
+func (this FieldErrors) ErrCode() ModelErrCode {
+	return ErrInvalidSigningInfo
+}
+
+func validateCLAFields(fields []CLAField) IModelError {
+	for i := range fields {
+		if err := validateCLAField(&fields[i]); err != nil {
+			return newModelError(
+				ErrInvalidCLAField,
+				fmt.Errorf("invalid field(%s): %s", fields[i].ID, err.Error()),
+			)
+		}
+	}
+	return nil
+}
+
+func validateCLAField(f *CLAField) error {
+	// the fields of the clas created before the types were introduced
+	// have free-form types, which are taken as text as before.
+	f.Type = strings.ToLower(strings.TrimSpace(f.Type))
+	if !validFieldTypes[f.Type] {
+		f.Type = dbmodels.FieldTypeText
+	}
+
+	if f.MinLength < 0 || f.MaxLength < 0 {
+		return fmt.Errorf("negative length")
+	}
+
+	if f.MaxLength > 0 && f.MinLength > f.MaxLength {
+		return fmt.Errorf("min length exceeds max length")
+	}
+
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %s", err.Error())
+		}
+	}
+
+	if f.Type == dbmodels.FieldTypeEnum && len(f.Options) == 0 {
+		return fmt.Errorf("enum type needs options")
+	}
+
+	if f.Type == dbmodels.FieldTypeCountry {
+		for i, item := range f.Options {
+			item = strings.ToUpper(item)
+			if !countryCodes[item] {
+				return fmt.Errorf("invalid country code:%s", item)
+			}
+			f.Options[i] = item
+		}
+	}
+
+	return nil
+}
+
+// CheckSigningInfo validates the values of signing info against the fields of cla.
+// It returns the normalized values and drops the ones not belonging to any field.
+func CheckSigningInfo(info dbmodels.TypeSigningInfo, fields []CLAField) (dbmodels.TypeSigningInfo, IModelError) {
+	r := dbmodels.TypeSigningInfo{}
+	var errs FieldErrors
+
+	for i := range fields {
+		f := &fields[i]
+
+		v, err := checkFieldValue(f, info[f.ID])
+		if err != nil {
+			errs = append(errs, FieldError{
+				ID:     f.ID,
+				Title:  f.Title,
+				Reason: err.Error(),
+			})
+			continue
+		}
+
+		if v != "" {
+			r[f.ID] = v
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return r, nil
+}
+
+func checkFieldValue(f *CLAField, value string) (string, error) {
+	v := strings.TrimSpace(value)
+
+	if v == "" || (f.Type == dbmodels.FieldTypeCheckbox && v == fieldValueUnchecked) {
+		if f.Required {
+			return "", fmt.Errorf("it is required")
+		}
+		return v, nil
+	}
+
+	for _, c := range v {
+		if unicode.IsControl(c) {
+			return "", fmt.Errorf("it contains control character")
+		}
+	}
+
+	n := utf8.RuneCountInString(v)
+	if f.MinLength > 0 && n < f.MinLength {
+		return "", fmt.Errorf("it is shorter than %d", f.MinLength)
+	}
+
+	if max := f.MaxLength; (max > 0 && n > max) || n > maxLengthOfFieldValue {
+		if max <= 0 || max > maxLengthOfFieldValue {
+			max = maxLengthOfFieldValue
+		}
+		return "", fmt.Errorf("it is longer than %d", max)
+	}
+
+	v, err := normalizeFieldValue(f, v)
+	if err != nil {
+		return "", err
+	}
+
+	if f.Pattern != "" {
+		if b, err := regexp.MatchString(f.Pattern, v); err != nil || !b {
+			return "", fmt.Errorf("it doesn't match the pattern")
+		}
+	}
+
+	if len(f.Options) > 0 && f.Type != dbmodels.FieldTypeCheckbox {
+		for _, item := range f.Options {
+			if item == v {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("it is not one of the allowed values")
+	}
+
+	return v, nil
+}
+
+func normalizeFieldValue(f *CLAField, v string) (string, error) {
+	switch f.Type {
+	case dbmodels.FieldTypeEmail:
+		if checkEmailFormat(v) != nil {
+			return "", fmt.Errorf("it is not an email")
+		}
+
+	case dbmodels.FieldTypePhone:
+		if !phoneRegexp.MatchString(v) {
+			return "", fmt.Errorf("it is not a phone number")
+		}
+
+	case dbmodels.FieldTypeDate:
+		t, err := time.Parse(dateLayoutOfField, v)
+		if err != nil {
+			return "", fmt.Errorf("it is not a date of format YYYY-MM-DD")
+		}
+		return t.Format(dateLayoutOfField), nil
+
+	case dbmodels.FieldTypeCountry:
+		v = strings.ToUpper(v)
+		if !countryCodes[v] {
+			return "", fmt.Errorf("it is not a country code of ISO 3166-1 alpha-2")
+		}
+
+	case dbmodels.FieldTypeURL:
+		u, err := url.ParseRequestURI(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("it is not a http(s) url")
+		}
+
+	case dbmodels.FieldTypeCheckbox:
+		if v != fieldValueChecked {
+			return "", fmt.Errorf("it should be %s or %s", fieldValueChecked, fieldValueUnchecked)
+		}
+	}
+
+	return v, nil
+}
+
+// IsFieldChecked returns true if the value of checkbox field is checked.
+func IsFieldChecked(v string) bool {
+	return v == fieldValueChecked
+}
@@ -0,0 +1,265 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	lengthOfCLATemplateID      = 16
+	maxLengthOfCLATemplateName = 100
+)
+
+type CLATemplate = dbmodels.CLATemplateInfo
+
+type CLATemplateCreateOption struct {
+	Name string `json:"name"`
+
+	// Org is the org on code platform which owns the template.
+	// The template is owned by the submitter if it is empty.
+	Org string `json:"org"`
+
+	IndividualCLA *CLACreateOpt `json:"individual_cla"`
+	CorpCLA       *CLACreateOpt `json:"corp_cla"`
+}
+
+func (this *CLATemplateCreateOption) Validate(langs map[string]bool) IModelError {
+	if err := validateCLATemplateName(this.Name); err != nil {
+		return err
+	}
+
+	if this.IndividualCLA == nil && this.CorpCLA == nil {
+		return newModelError(
+			ErrMissgingCLA,
+			fmt.Errorf("must specify one of individual and corp clas"),
+		)
+	}
+
+	if this.IndividualCLA != nil {
+		if err := this.IndividualCLA.Validate("", langs); err != nil {
+			return err
+		}
+	}
+
+	if this.CorpCLA != nil {
+		if err := this.CorpCLA.Validate(dbmodels.ApplyToCorporation, langs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (this *CLATemplateCreateOption) Create(platform, submitter string) (string, IModelError) {
+	set := CLASet{}
+	if this.IndividualCLA != nil {
+		set.IndividualCLAs = []CLACreateOpt{*this.IndividualCLA}
+	}
+	if this.CorpCLA != nil {
+		set.CorpCLAs = []CLACreateOpt{*this.CorpCLA}
+	}
+
+	return set.CreateCLATemplate(this.Name, platform, this.Org, submitter)
+}
+
+func validateCLATemplateName(name string) IModelError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return newModelError(ErrInvalidCLATemplate, fmt.Errorf("missing name"))
+	}
+
+	if len([]rune(name)) > maxLengthOfCLATemplateName {
+		return newModelError(
+			ErrInvalidCLATemplate,
+			fmt.Errorf("the name is longer than %d", maxLengthOfCLATemplateName),
+		)
+	}
+	return nil
+}
+
+// CLASet is the clas of all the languages which is copied from a link or a cla template.
+type CLASet struct {
+	IndividualCLAs []CLACreateOpt
+	CorpCLAs       []CLACreateOpt
+}
+
+func (this *CLASet) IsEmpty() bool {
+	return len(this.IndividualCLAs) == 0 && len(this.CorpCLAs) == 0
+}
+
+// CreateCLATemplate saves the cla set as a template.
+func (this *CLASet) CreateCLATemplate(name, platform, org, submitter string) (string, IModelError) {
+	if err := validateCLATemplateName(name); err != nil {
+		return "", err
+	}
+
+	if this.IsEmpty() {
+		return "", newModelError(ErrMissgingCLA, fmt.Errorf("no cla"))
+	}
+
+	opt := dbmodels.CLATemplateCreateOption{
+		CLATemplate: dbmodels.CLATemplate{
+			ID:        util.RandStr(lengthOfCLATemplateID, "alphanum"),
+			Name:      strings.TrimSpace(name),
+			Platform:  platform,
+			Org:       org,
+			Submitter: submitter,
+			CreatedAt: util.Now(),
+		},
+		IndividualCLAs: toCLACreateOptions(this.IndividualCLAs),
+		CorpCLAs:       toCLACreateOptions(this.CorpCLAs),
+	}
+
+	if err := dbmodels.GetDB().CreateCLATemplate(&opt); err != nil {
+		return "", parseDBError(err)
+	}
+	return opt.ID, nil
+}
+
+func toCLACreateOptions(v []CLACreateOpt) []dbmodels.CLACreateOption {
+	if len(v) == 0 {
+		return nil
+	}
+
+	r := make([]dbmodels.CLACreateOption, 0, len(v))
+	for i := range v {
+		r = append(r, *v[i].toCLACreateOption())
+	}
+	return r
+}
+
+func newCLASet(
+	individualCLAs, corpCLAs []dbmodels.CLADetail,
+	getSignature func(lang string) ([]byte, dbmodels.IDBError),
+) (*CLASet, IModelError) {
+	set := &CLASet{}
+
+	for i := range individualCLAs {
+		set.IndividualCLAs = append(set.IndividualCLAs, *newCLACreateOpt(&individualCLAs[i], nil))
+	}
+
+	for i := range corpCLAs {
+		item := &corpCLAs[i]
+
+		signature, err := getSignature(item.Language)
+		if err != nil {
+			return nil, parseDBError(err)
+		}
+		if len(signature) == 0 {
+			return nil, newModelError(
+				ErrNoOrgSignature, fmt.Errorf("no signature of %s", item.Language),
+			)
+		}
+
+		set.CorpCLAs = append(set.CorpCLAs, *newCLACreateOpt(item, &signature))
+	}
+
+	return set, nil
+}
+
+func newCLACreateOpt(cla *dbmodels.CLADetail, signature *[]byte) *CLACreateOpt {
+	text := []byte(cla.Text)
+
+	opt := &CLACreateOpt{CLAData: cla.CLAData}
+	opt.SetCLAContent(&text)
+	opt.SetOrgSignature(signature)
+
+	return opt
+}
+
+// GetCLASetOfLink returns the clas of link including the org signatures.
+func GetCLASetOfLink(linkID string) (*CLASet, IModelError) {
+	v, merr := GetAllCLA(linkID)
+	if merr != nil {
+		return nil, merr
+	}
+
+	return newCLASet(
+		v.IndividualCLAs, v.CorpCLAs,
+		func(lang string) ([]byte, dbmodels.IDBError) {
+			return dbmodels.GetDB().DownloadCorpCLAPDF(linkID, lang)
+		},
+	)
+}
+
+// GetCLASetOfTemplate returns the clas of template including the org signatures.
+func GetCLASetOfTemplate(id string) (*CLASet, IModelError) {
+	v, merr := GetCLATemplate(id)
+	if merr != nil {
+		return nil, merr
+	}
+
+	return newCLASet(
+		v.IndividualCLAs, v.CorpCLAs,
+		func(lang string) ([]byte, dbmodels.IDBError) {
+			return dbmodels.GetDB().DownloadOrgSignatureOfTemplate(id, lang)
+		},
+	)
+}
+
+func GetCLATemplate(id string) (*CLATemplate, IModelError) {
+	v, err := dbmodels.GetDB().GetCLATemplate(id)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoCLATemplate, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// ListCLATemplates lists the templates owned by the user or the orgs.
+func ListCLATemplates(platform, user string, orgs []string) ([]CLATemplate, IModelError) {
+	v, err := dbmodels.GetDB().ListCLATemplates(&dbmodels.CLATemplateListOption{
+		Platform:  platform,
+		Submitter: user,
+		Orgs:      orgs,
+	})
+	return v, parseDBError(err)
+}
+
+// ListLinksOfCLATemplate lists the links which refer to the cla template.
+func ListLinksOfCLATemplate(id string) ([]LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().ListLinksOfCLATemplate(id)
+	return v, parseDBError(err)
+}
+
+func DeleteCLATemplate(id string) IModelError {
+	err := dbmodels.GetDB().DeleteCLATemplate(id)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoCLATemplate, err)
+	}
+	return parseDBError(err)
+}
+
+func (this *CLACreateOpt) AddToCLATemplate(id, applyTo string) IModelError {
+	err := dbmodels.GetDB().AddCLAToTemplate(id, applyTo, this.toCLACreateOption())
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoCLATemplateOrCLAExists, err)
+	}
+	return parseDBError(err)
+}
+
+func DeleteCLAOfTemplate(id, applyTo, language string) IModelError {
+	err := dbmodels.GetDB().DeleteCLAOfTemplate(id, applyTo, language)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoCLATemplate, err)
+	}
+	return parseDBError(err)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/opensourceways/app-cla-server/clatext"
 	"github.com/opensourceways/app-cla-server/config"
 	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/util"
@@ -93,13 +94,61 @@ func (this *CLACreateOpt) GenCLAInfo() *CLAInfo {
 		OrgSignatureHash: util.Md5sumOfBytes(this.orgSignature),
 		CLAHash:          util.Md5sumOfBytes(this.content),
 		CLALang:          this.Language,
+		CLAFormat:        this.Format,
 		Fields:           this.Fields,
 	}
 }
 
 func (this *CLACreateOpt) Validate(applyTo string, langs map[string]bool) IModelError {
+	if err := this.validate(applyTo, langs); err != nil {
+		return err
+	}
+
+	text, err := downloadCLA(this.URL, this.Format)
+	if err != nil {
+		return newModelError(ErrSystemError, err)
+	}
+
+	// the hash of cla is computed over the canonical source
+	content := clatext.Canonicalize(this.Format, *text)
+	this.content = &content
+
+	if applyTo == dbmodels.ApplyToCorporation && this.orgSignature == nil {
+		return newModelError(ErrNoOrgSignature, fmt.Errorf("no signatrue"))
+	}
+
+	return nil
+}
+
+// validateCopied validates the cla which is copied from a link or a cla template.
+// Its content has been downloaded already, but the languages supported or the
+// limits of fields may be changed since then.
+func (this *CLACreateOpt) validateCopied(applyTo string, langs map[string]bool) IModelError {
+	if err := this.validate(applyTo, langs); err != nil {
+		return err
+	}
+
+	if this.content == nil {
+		return newModelError(ErrMissgingCLA, fmt.Errorf("no content of cla"))
+	}
+
+	if applyTo == dbmodels.ApplyToCorporation && this.orgSignature == nil {
+		return newModelError(ErrNoOrgSignature, fmt.Errorf("no signatrue"))
+	}
+
+	return nil
+}
+
+func (this *CLACreateOpt) validate(applyTo string, langs map[string]bool) IModelError {
 	this.Language = strings.ToLower(this.Language)
 
+	if this.Format = strings.ToLower(this.Format); this.Format == "" {
+		this.Format = clatext.FormatPlain
+	}
+	if !clatext.IsValidFormat(this.Format) {
+		return newModelError(ErrUnsupportedCLAFormat, fmt.Errorf("unsupported cla format"))
+	}
+
 	if applyTo == dbmodels.ApplyToCorporation && !langs[this.Language] {
 		return newModelError(ErrUnsupportedCLALang, fmt.Errorf("unsupported_cla_lang"))
 	}
@@ -118,20 +167,10 @@ func (this *CLACreateOpt) Validate(applyTo string, langs map[string]bool) IModel
 		}
 	}
 
-	text, err := downloadCLA(this.URL)
-	if err != nil {
-		return newModelError(ErrSystemError, err)
-	}
-	this.content = text
-
-	if applyTo == dbmodels.ApplyToCorporation && this.orgSignature == nil {
-		return newModelError(ErrNoOrgSignature, fmt.Errorf("no signatrue"))
-	}
-
-	return nil
+	return validateCLAFields(this.Fields)
 }
 
-func downloadCLA(url string) (*[]byte, error) {
+func downloadCLA(url, format string) (*[]byte, error) {
 	var resp *http.Response
 
 	for i := 0; i < 3; i++ {
@@ -153,7 +192,9 @@ func downloadCLA(url string) (*[]byte, error) {
 		return nil, err
 	}
 
-	if strings.HasPrefix(http.DetectContentType(data), "text/plain") {
+	ct := http.DetectContentType(data)
+	if strings.HasPrefix(ct, "text/plain") ||
+		(format == clatext.FormatHTML && strings.HasPrefix(ct, "text/html")) {
 		return &data, nil
 	}
 
@@ -163,6 +204,9 @@ func downloadCLA(url string) (*[]byte, error) {
 func GetCLAByType(orgRepo *dbmodels.OrgRepo, applyTo string) (string, []dbmodels.CLADetail, IModelError) {
 	linkID, v, err := dbmodels.GetDB().GetCLAByType(orgRepo, applyTo)
 	if err == nil {
+		for i := range v {
+			v[i].HTML = clatext.Parse(v[i].Format, v[i].Text).HTML()
+		}
 		return linkID, v, nil
 	}
 
@@ -184,6 +228,49 @@ func GetAllCLA(linkID string) (*dbmodels.CLAOfLink, IModelError) {
 	return v, parseDBError(err)
 }
 
+// CLAPreview is the cla text rendered for the signing page and the email.
+type CLAPreview struct {
+	Format string `json:"format"`
+	HTML   string `json:"html"`
+	Email  string `json:"email"`
+}
+
+func PreviewCLA(linkID, applyTo, language string) (*CLAPreview, IModelError) {
+	item, merr := GetCLA(linkID, applyTo, language)
+	if merr != nil {
+		return nil, merr
+	}
+
+	doc := clatext.Parse(item.Format, item.Text)
+
+	return &CLAPreview{
+		Format: item.Format,
+		HTML:   doc.HTML(),
+		Email:  doc.PlainText(),
+	}, nil
+}
+
+// GetCLA returns the cla of the language including its text.
+func GetCLA(linkID, applyTo, language string) (*dbmodels.CLADetail, IModelError) {
+	v, merr := GetAllCLA(linkID)
+	if merr != nil {
+		return nil, merr
+	}
+
+	clas := v.IndividualCLAs
+	if applyTo == dbmodels.ApplyToCorporation {
+		clas = v.CorpCLAs
+	}
+
+	for i := range clas {
+		if item := &clas[i]; item.Language == language {
+			return item, nil
+		}
+	}
+
+	return nil, newModelError(ErrMissgingCLA, fmt.Errorf("no cla of language:%s", language))
+}
+
 func HasCLA(linkID, applyTo, language string) (bool, IModelError) {
 	v, err := dbmodels.GetDB().HasCLA(linkID, applyTo, language)
 	if err == nil {
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+type CorpOIDC = dbmodels.CorpOIDC
+
+type CorpOIDCSettingOption struct {
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+func (this *CorpOIDCSettingOption) Validate() IModelError {
+	this.Issuer = strings.TrimSpace(this.Issuer)
+
+	u, err := url.Parse(this.Issuer)
+	if err != nil || u.Host == "" || u.Scheme != "https" {
+		return newModelError(ErrInvalidCorpOIDC, fmt.Errorf("the issuer should be an https url"))
+	}
+
+	if this.ClientID == "" {
+		return newModelError(ErrInvalidCorpOIDC, fmt.Errorf("missing client id"))
+	}
+
+	if this.ClientSecret == "" {
+		return newModelError(ErrInvalidCorpOIDC, fmt.Errorf("missing client secret"))
+	}
+
+	return nil
+}
+
+func (this *CorpOIDCSettingOption) Save(linkID, corpEmail string) IModelError {
+	err := dbmodels.GetDB().SaveCorpOIDC(&dbmodels.CorpOIDC{
+		LinkID:       linkID,
+		CorpID:       util.EmailSuffix(corpEmail),
+		Issuer:       this.Issuer,
+		ClientID:     this.ClientID,
+		ClientSecret: this.ClientSecret,
+		UpdatedAt:    util.Now(),
+	})
+	return parseDBError(err)
+}
+
+func GetCorpOIDC(linkID, corpEmail string) (*CorpOIDC, IModelError) {
+	v, err := dbmodels.GetDB().GetCorpOIDC(linkID, util.EmailSuffix(corpEmail))
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoCorpOIDC, fmt.Errorf("oidc is not configured"))
+	}
+	return nil, parseDBError(err)
+}
+
+// ListCorpOIDCs returns the configurations of all the links which the corporation signed.
+func ListCorpOIDCs(corpEmail string) ([]CorpOIDC, IModelError) {
+	v, err := dbmodels.GetDB().ListCorpOIDCs(util.EmailSuffix(corpEmail))
+	if err != nil {
+		return nil, parseDBError(err)
+	}
+
+	if len(v) == 0 {
+		return nil, newModelError(ErrNoCorpOIDC, fmt.Errorf("oidc is not configured"))
+	}
+	return v, nil
+}
+
+func DeleteCorpOIDC(linkID, corpEmail string) IModelError {
+	err := dbmodels.GetDB().DeleteCorpOIDC(linkID, util.EmailSuffix(corpEmail))
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoCorpOIDC, fmt.Errorf("oidc is not configured"))
+	}
+	return parseDBError(err)
+}
+
+// AuthenticateByOIDC returns the corporation managers of the email on the links
+// which are configured with the identity provider.
+func AuthenticateByOIDC(email, issuer, clientID string) (map[string]dbmodels.CorporationManagerCheckResult, IModelError) {
+	v, merr := CorporationManagerAuthentication{User: email}.FindAccounts()
+	if merr != nil || len(v) == 0 {
+		return v, merr
+	}
+
+	cfgs, merr := ListCorpOIDCs(email)
+	if merr != nil {
+		if merr.IsErrorOf(ErrNoCorpOIDC) {
+			return nil, nil
+		}
+		return nil, merr
+	}
+
+	allowed := map[string]bool{}
+	for i := range cfgs {
+		if cfgs[i].Issuer == issuer && cfgs[i].ClientID == clientID {
+			allowed[cfgs[i].LinkID] = true
+		}
+	}
+
+	for k := range v {
+		if !allowed[k] {
+			delete(v, k)
+		}
+	}
+	return v, nil
+}
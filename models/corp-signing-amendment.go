@@ -0,0 +1,291 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	lengthOfAmendmentID = 16
+
+	maxLengthOfAmendmentReason = 512
+)
+
+type CorpSigningAmendment = dbmodels.CorpSigningAmendment
+
+// AmendedCorpSigning is the amendment with the corporation signing and
+// the fields of cla, which are used to generate the amendment page.
+type AmendedCorpSigning struct {
+	Amendment CorpSigningAmendment
+	Signing   CorporationSigningBasicInfo
+	Fields    []CLAField
+}
+
+// IsApplied checks whether the amendment has taken effect.
+func (this *AmendedCorpSigning) IsApplied() bool {
+	return this.Amendment.Status == dbmodels.AmendmentApplied
+}
+
+// CorpSigningAmendmentOption is the new values of the contact fields of corporation
+// signing. A field which is not required can be cleared by an empty value.
+type CorpSigningAmendmentOption struct {
+	Changes dbmodels.TypeSigningInfo `json:"changes"`
+}
+
+// Submit records the amendment. It is applied at once unless the approval of community is required.
+func (this *CorpSigningAmendmentOption) Submit(linkID, corpEmail, operator string, approvalRequired bool) (*AmendedCorpSigning, IModelError) {
+	if len(this.Changes) == 0 {
+		return nil, newModelError(ErrInvalidAmendment, fmt.Errorf("no changes"))
+	}
+
+	fields, signing, merr := getCorpSigningForAmendment(linkID, corpEmail)
+	if merr != nil {
+		return nil, merr
+	}
+
+	info, changes, previous, merr := amendSigningInfo(signing.Info, this.Changes, fields)
+	if merr != nil {
+		return nil, merr
+	}
+
+	r := &AmendedCorpSigning{
+		Amendment: CorpSigningAmendment{
+			ID:          util.RandStr(lengthOfAmendmentID, "alphanum"),
+			Status:      dbmodels.AmendmentPending,
+			Changes:     changes,
+			SubmittedBy: operator,
+			SubmittedAt: util.Now(),
+		},
+		Signing: signing.CorporationSigningBasicInfo,
+		Fields:  fields,
+	}
+
+	if approvalRequired {
+		info = nil
+	} else {
+		r.Amendment.Status = dbmodels.AmendmentApplied
+		r.Amendment.Previous = previous
+	}
+
+	err := dbmodels.GetDB().AddCorpSigningAmendment(linkID, corpEmail, &r.Amendment, info)
+	if err == nil {
+		return r, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrAmendmentPending, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// CorpSigningAmendmentReview is the decision of community on the pending amendment.
+type CorpSigningAmendmentReview struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+func (this *CorpSigningAmendmentReview) Validate() IModelError {
+	if len([]rune(this.Reason)) > maxLengthOfAmendmentReason {
+		return newModelError(
+			ErrInvalidAmendment,
+			fmt.Errorf("the reason should not be longer than %d", maxLengthOfAmendmentReason),
+		)
+	}
+	return nil
+}
+
+// Review approves or rejects the pending amendment. The current values of signing
+// are kept as the previous values when it is approved.
+func (this *CorpSigningAmendmentReview) Review(linkID, corpEmail, id, operator string) (*AmendedCorpSigning, IModelError) {
+	amendments, merr := ListCorpSigningAmendments(linkID, corpEmail)
+	if merr != nil {
+		return nil, merr
+	}
+
+	var amendment *CorpSigningAmendment
+	for i := range amendments {
+		if item := &amendments[i]; item.ID == id && item.Status == dbmodels.AmendmentPending {
+			amendment = item
+			break
+		}
+	}
+	if amendment == nil {
+		return nil, newModelError(ErrNoPendingAmendment, fmt.Errorf("no pending amendment"))
+	}
+
+	fields, signing, merr := getCorpSigningForAmendment(linkID, corpEmail)
+	if merr != nil {
+		return nil, merr
+	}
+
+	var info dbmodels.TypeSigningInfo
+	if this.Approved {
+		v, changes, previous, merr := amendSigningInfo(signing.Info, amendment.Changes, fields)
+		if merr != nil {
+			return nil, merr
+		}
+
+		info = v
+		amendment.Changes = changes
+		amendment.Previous = previous
+		amendment.Status = dbmodels.AmendmentApplied
+	} else {
+		amendment.Status = dbmodels.AmendmentRejected
+		amendment.Reason = this.Reason
+	}
+
+	amendment.ReviewedBy = operator
+	amendment.ReviewedAt = util.Now()
+
+	err := dbmodels.GetDB().ReviewCorpSigningAmendment(linkID, corpEmail, amendment, info)
+	if err != nil {
+		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return nil, newModelError(ErrNoPendingAmendment, err)
+		}
+		return nil, parseDBError(err)
+	}
+
+	return &AmendedCorpSigning{
+		Amendment: *amendment,
+		Signing:   signing.CorporationSigningBasicInfo,
+		Fields:    fields,
+	}, nil
+}
+
+func ListCorpSigningAmendments(linkID, corpEmail string) ([]CorpSigningAmendment, IModelError) {
+	v, err := dbmodels.GetDB().ListCorpSigningAmendments(linkID, corpEmail)
+	if err == nil {
+		if v == nil {
+			return nil, newModelError(ErrUnsigned, fmt.Errorf("unsigned"))
+		}
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// AmendmentApprovalOption sets whether the amendments of corporation signing
+// of link should be approved by the community before taking effect.
+type AmendmentApprovalOption struct {
+	Required bool `json:"required"`
+}
+
+func (this *AmendmentApprovalOption) Set(linkID string) IModelError {
+	err := dbmodels.GetDB().SetAmendmentApproval(linkID, this.Required)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func UploadCorpSigningAmendmentPDF(linkID, corpEmail, id string, pdf []byte) IModelError {
+	err := dbmodels.GetDB().UploadCorpSigningAmendmentPDF(linkID, corpEmail, id, pdf)
+	return parseDBError(err)
+}
+
+func DownloadCorpSigningAmendmentPDF(linkID, corpEmail, id, path string) IModelError {
+	err := dbmodels.GetDB().DownloadCorpSigningAmendmentPDF(linkID, corpEmail, id, path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLinkOrUnuploaed, err)
+	}
+	return parseDBError(err)
+}
+
+// GetAppliedCorpSigningAmendment returns the amendment which has taken effect
+// with the current corporation signing, which is used to generate its page again.
+func GetAppliedCorpSigningAmendment(linkID, corpEmail, id string) (*AmendedCorpSigning, IModelError) {
+	amendments, merr := ListCorpSigningAmendments(linkID, corpEmail)
+	if merr != nil {
+		return nil, merr
+	}
+
+	var amendment *CorpSigningAmendment
+	for i := range amendments {
+		if item := &amendments[i]; item.ID == id && item.Status == dbmodels.AmendmentApplied {
+			amendment = item
+			break
+		}
+	}
+	if amendment == nil {
+		return nil, newModelError(ErrNoLinkOrUnuploaed, fmt.Errorf("the amendment has not taken effect"))
+	}
+
+	fields, signing, merr := getCorpSigningForAmendment(linkID, corpEmail)
+	if merr != nil {
+		return nil, merr
+	}
+
+	return &AmendedCorpSigning{
+		Amendment: *amendment,
+		Signing:   signing.CorporationSigningBasicInfo,
+		Fields:    fields,
+	}, nil
+}
+
+func getCorpSigningForAmendment(linkID, corpEmail string) ([]CLAField, *CorporationSigning, IModelError) {
+	fields, signing, merr := GetCorpSigningDetail(linkID, corpEmail)
+	if merr != nil {
+		return nil, nil, merr
+	}
+	if signing == nil {
+		return nil, nil, newModelError(ErrUnsigned, fmt.Errorf("unsigned"))
+	}
+
+	return fields, signing, nil
+}
+
+// amendSigningInfo applies the changes to the signing info and validates the result against
+// the fields of cla. It returns the new info, the values which are really changed and
+// the values which are replaced.
+func amendSigningInfo(current, changes dbmodels.TypeSigningInfo, fields []CLAField) (
+	dbmodels.TypeSigningInfo, dbmodels.TypeSigningInfo, dbmodels.TypeSigningInfo, IModelError,
+) {
+	ids := make(map[string]bool, len(fields))
+	for i := range fields {
+		ids[fields[i].ID] = true
+	}
+
+	merged := dbmodels.TypeSigningInfo{}
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		if !ids[k] {
+			return nil, nil, nil, newModelError(ErrInvalidAmendment, fmt.Errorf("unknown field: %s", k))
+		}
+		merged[k] = v
+	}
+
+	info, merr := CheckSigningInfo(merged, fields)
+	if merr != nil {
+		return nil, nil, nil, merr
+	}
+
+	changed := dbmodels.TypeSigningInfo{}
+	previous := dbmodels.TypeSigningInfo{}
+	for k := range changes {
+		if info[k] != current[k] {
+			changed[k] = info[k]
+			previous[k] = current[k]
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil, nil, nil, newModelError(ErrInvalidAmendment, fmt.Errorf("nothing is changed"))
+	}
+
+	return info, changed, previous, nil
+}
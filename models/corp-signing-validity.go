@@ -0,0 +1,200 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const (
+	maxValidityDays = 3650
+	maxGraceDays    = 365
+
+	secondsOfDay = 86400
+)
+
+type CorpSigningValidity = dbmodels.CorpSigningValidity
+type CorpSigningRenewal = dbmodels.CorpSigningRenewal
+
+// CorpSigningValidityOption sets the validity period of corporation signing of link.
+// The validity is removed if ValidityDays is 0.
+type CorpSigningValidityOption struct {
+	CorpSigningValidity
+}
+
+func (this *CorpSigningValidityOption) Validate() IModelError {
+	v := &this.CorpSigningValidity
+
+	if v.ValidityDays == 0 {
+		if v.GraceDays != 0 || v.RemindBeforeDays != 0 {
+			return newModelError(ErrInvalidValidity, fmt.Errorf("missing validity days"))
+		}
+		return nil
+	}
+
+	if v.ValidityDays < 0 || v.ValidityDays > maxValidityDays {
+		return newModelError(
+			ErrInvalidValidity,
+			fmt.Errorf("validity days should be between 1 and %d", maxValidityDays),
+		)
+	}
+
+	if v.GraceDays < 0 || v.GraceDays > maxGraceDays {
+		return newModelError(
+			ErrInvalidValidity,
+			fmt.Errorf("grace days should be between 0 and %d", maxGraceDays),
+		)
+	}
+
+	if v.RemindBeforeDays < 0 || v.RemindBeforeDays >= v.ValidityDays {
+		return newModelError(
+			ErrInvalidValidity,
+			fmt.Errorf("remind before days should be between 0 and validity days"),
+		)
+	}
+
+	return nil
+}
+
+func (this *CorpSigningValidityOption) Set(linkID string) IModelError {
+	var v *CorpSigningValidity
+	if this.ValidityDays > 0 {
+		v = &this.CorpSigningValidity
+	}
+
+	err := dbmodels.GetDB().SetCorpSigningValidity(linkID, v)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func ListLinksWithCorpSigningValidity() ([]LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().ListLinksWithCorpSigningValidity()
+	return v, parseDBError(err)
+}
+
+// ValidFromOfCorpSigning returns the beginning of current validity period. The date
+// of signing is used for the signings which were signed before the validity is introduced.
+func ValidFromOfCorpSigning(s *CorporationSigningBasicInfo) int64 {
+	if s.ValidFrom > 0 {
+		return s.ValidFrom
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s.Date, time.Local)
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+// CorpSigningExpiry returns the time when the signing expires and its status at the moment.
+func CorpSigningExpiry(v *CorpSigningValidity, s *CorporationSigningBasicInfo, now int64) (int64, string) {
+	validFrom := ValidFromOfCorpSigning(s)
+	if v == nil || validFrom == 0 {
+		return 0, ""
+	}
+
+	expiresAt := validFrom + int64(v.ValidityDays)*secondsOfDay
+
+	switch {
+	case now < expiresAt-int64(v.RemindBeforeDays)*secondsOfDay:
+		return expiresAt, dbmodels.CorpSigningActive
+	case now < expiresAt:
+		return expiresAt, dbmodels.CorpSigningExpiring
+	case now < expiresAt+int64(v.GraceDays)*secondsOfDay:
+		return expiresAt, dbmodels.CorpSigningInGrace
+	default:
+		return expiresAt, dbmodels.CorpSigningExpired
+	}
+}
+
+func setCorpSigningExpiry(v *CorpSigningValidity, s *CorporationSigningBasicInfo, now int64) {
+	s.ExpiresAt, s.ExpiryStatus = CorpSigningExpiry(v, s, now)
+}
+
+// CorpSigningStatusOfEmployee returns the expiry status of the corporation signing which
+// covers the employee. It is empty if the link has no validity or the corporation has not signed.
+func CorpSigningStatusOfEmployee(link *LinkInfo, email string) (string, IModelError) {
+	if link.CorpSigningValidity == nil {
+		return "", nil
+	}
+
+	s, merr := GetCorpSigningBasicInfo(link.LinkID, email)
+	if merr != nil {
+		if merr.IsErrorOf(ErrUnsigned) {
+			return "", nil
+		}
+		return "", merr
+	}
+
+	_, status := CorpSigningExpiry(link.CorpSigningValidity, s, util.Now())
+	return status, nil
+}
+
+// IsCorpSigningExpired checks whether the employees of corporation are no longer covered.
+func IsCorpSigningExpired(linkID, email string) (bool, IModelError) {
+	link, merr := GetLink(linkID)
+	if merr != nil {
+		return false, merr
+	}
+
+	status, merr := CorpSigningStatusOfEmployee(link, email)
+	return status == dbmodels.CorpSigningExpired, merr
+}
+
+// ArchiveVersionOfCorpSigning returns the version by which the pdf
+// of current validity period is archived when the signing is renewed.
+func ArchiveVersionOfCorpSigning(s *CorporationSigningBasicInfo) string {
+	return strconv.FormatInt(ValidFromOfCorpSigning(s), 10)
+}
+
+// RenewCorpSigning starts a new validity period of the signing.
+func RenewCorpSigning(linkID string, s *CorporationSigningBasicInfo, operator string) IModelError {
+	v := CorpSigningRenewal{
+		PreviousValidFrom: ValidFromOfCorpSigning(s),
+		RenewedAt:         util.Now(),
+		Operator:          operator,
+	}
+
+	err := dbmodels.GetDB().RenewCorpSigning(linkID, s.AdminEmail, &v)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLinkOrUnsigned, err)
+	}
+	return parseDBError(err)
+}
+
+// UpdateCorpSigningRenewalReminders changes the number of renewal reminders from
+// prev to n. It returns false if the number is not prev any more.
+func UpdateCorpSigningRenewalReminders(linkID, email string, prev, n int) (bool, IModelError) {
+	b, err := dbmodels.GetDB().UpdateCorpSigningRenewalReminders(linkID, email, prev, n)
+	return b, parseDBError(err)
+}
+
+func UploadArchivedCorporationSigningPDF(linkID, email, version string, pdf []byte) IModelError {
+	err := dbmodels.GetDB().UploadArchivedCorporationSigningPDF(linkID, email, version, pdf)
+	return parseDBError(err)
+}
+
+func DownloadArchivedCorporationSigningPDF(linkID, email, version, path string) IModelError {
+	err := dbmodels.GetDB().DownloadArchivedCorporationSigningPDF(linkID, email, version, path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLinkOrUnuploaed, err)
+	}
+	return parseDBError(err)
+}
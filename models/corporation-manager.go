@@ -15,6 +15,21 @@ type CorporationManagerAuthentication struct {
 }
 
 func (this CorporationManagerAuthentication) Authenticate() (map[string]dbmodels.CorporationManagerCheckResult, IModelError) {
+	v, merr := this.FindAccounts()
+	if merr != nil {
+		return nil, merr
+	}
+
+	for k := range v {
+		if !isSamePasswords(v[k].Password, this.Password) {
+			delete(v, k)
+		}
+	}
+	return v, nil
+}
+
+// FindAccounts returns all the corporation managers matched with the user without checking the password.
+func (this CorporationManagerAuthentication) FindAccounts() (map[string]dbmodels.CorporationManagerCheckResult, IModelError) {
 	info := dbmodels.CorporationManagerCheckInfo{}
 	if merr := checkEmailFormat(this.User); merr == nil {
 		info.Email = this.User
@@ -30,11 +45,6 @@ func (this CorporationManagerAuthentication) Authenticate() (map[string]dbmodels
 
 	v, err := dbmodels.GetDB().CheckCorporationManagerExist(info)
 	if err == nil {
-		for k := range v {
-			if !isSamePasswords(v[k].Password, this.Password) {
-				delete(v, k)
-			}
-		}
 		return v, nil
 	}
 
@@ -14,6 +14,8 @@ func InitializeCorpSigning(linkID string, info *OrgInfo, cla *CLAInfo) IModelErr
 
 type CorporationSigning = dbmodels.CorpSigningCreateOpt
 
+type CorporationSigningBasicInfo = dbmodels.CorporationSigningBasicInfo
+
 type CorporationSigningCreateOption struct {
 	CorporationSigning
 
@@ -25,11 +27,16 @@ func (this *CorporationSigningCreateOption) Validate(orgCLAID string) IModelErro
 	if err != nil {
 		return err
 	}
+
+	if err := checkPrivacyConsent(&this.PrivacyConsent); err != nil {
+		return err
+	}
 	return checkEmailFormat(this.AdminEmail)
 }
 
 func (this *CorporationSigningCreateOption) Create(orgCLAID string) IModelError {
 	this.Date = util.Date()
+	this.ValidFrom = util.Now()
 
 	err := dbmodels.GetDB().SignCorpCLA(orgCLAID, &this.CorporationSigning)
 	if err == nil {
@@ -69,12 +76,29 @@ func ListCorpsWithPDFUploaded(linkID string) ([]string, IModelError) {
 	return v, parseDBError(err)
 }
 
+// ListCorpSignings lists the corporation signings with their expiry status
+// if the link has a validity period.
 func ListCorpSignings(linkID, language string) ([]dbmodels.CorporationSigningSummary, IModelError) {
 	v, err := dbmodels.GetDB().ListCorpSignings(linkID, language)
 	if err == nil {
 		if v == nil {
 			v = []dbmodels.CorporationSigningSummary{}
 		}
+
+		if len(v) > 0 {
+			link, merr := GetLink(linkID)
+			if merr != nil {
+				return nil, merr
+			}
+
+			if validity := link.CorpSigningValidity; validity != nil {
+				now := util.Now()
+				for i := range v {
+					setCorpSigningExpiry(validity, &v[i].CorporationSigningBasicInfo, now)
+				}
+			}
+		}
+
 		return v, nil
 	}
 
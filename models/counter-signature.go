@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+const (
+	maxNumOfSignatories  = 20
+	maxLengthOfSignatory = 100
+)
+
+type Signatory = dbmodels.Signatory
+
+type CounterSignature = dbmodels.CounterSignature
+
+// Signatories is the representatives of community who are authorised to counter-sign.
+type Signatories []Signatory
+
+func (this Signatories) Validate() IModelError {
+	if len(this) > maxNumOfSignatories {
+		return newModelError(
+			ErrInvalidSignatory,
+			fmt.Errorf("the number of signatories should not be more than %d", maxNumOfSignatories),
+		)
+	}
+
+	ids := map[string]bool{}
+	for i := range this {
+		item := &this[i]
+		item.ID = strings.TrimSpace(item.ID)
+		item.Name = strings.TrimSpace(item.Name)
+		item.Title = strings.TrimSpace(item.Title)
+
+		if item.ID == "" || item.Name == "" {
+			return newModelError(ErrInvalidSignatory, fmt.Errorf("missing id or name of signatory"))
+		}
+
+		for _, v := range []string{item.ID, item.Name, item.Title} {
+			if len([]rune(v)) > maxLengthOfSignatory {
+				return newModelError(
+					ErrInvalidSignatory,
+					fmt.Errorf("the field of signatory is longer than %d", maxLengthOfSignatory),
+				)
+			}
+		}
+
+		if ids[item.ID] {
+			return newModelError(ErrInvalidSignatory, fmt.Errorf("duplicate signatory: %s", item.ID))
+		}
+		ids[item.ID] = true
+	}
+
+	return nil
+}
+
+func (this Signatories) Save(linkID string) IModelError {
+	err := dbmodels.GetDB().SaveSignatories(linkID, this)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func GetSignatories(linkID string) (Signatories, IModelError) {
+	v, err := dbmodels.GetDB().GetSignatories(linkID)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// GetSignatory returns the signatory of the account on code platform.
+func GetSignatory(linkID, user string) (*Signatory, IModelError) {
+	v, merr := GetSignatories(linkID)
+	if merr != nil {
+		return nil, merr
+	}
+
+	for i := range v {
+		if v[i].ID == user {
+			return &v[i], nil
+		}
+	}
+
+	return nil, newModelError(
+		ErrNotSignatory, fmt.Errorf("%s is not the signatory of community", user),
+	)
+}
+
+func CounterSignCorpCLA(linkID, email string, v *CounterSignature) IModelError {
+	err := dbmodels.GetDB().CounterSignCorpCLA(linkID, email, v)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLinkOrCounterSigned, err)
+	}
+	return parseDBError(err)
+}
+
+// CancelCounterSignature reverts the counter-signature whose pdf failed to be saved.
+func CancelCounterSignature(linkID, email string, v *CounterSignature) IModelError {
+	return parseDBError(dbmodels.GetDB().CancelCounterSignature(linkID, email, v))
+}
+
+// UploadCounterSignedPDF saves the fully executed pdf of corporation signing.
+func UploadCounterSignedPDF(linkID, email string, pdf []byte) IModelError {
+	err := dbmodels.GetDB().UploadCounterSignedPDF(linkID, email, pdf)
+	return parseDBError(err)
+}
+
+func DownloadCounterSignedPDF(linkID, email, path string) IModelError {
+	err := dbmodels.GetDB().DownloadCounterSignedPDF(linkID, email, path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNotCounterSigned, err)
+	}
+	return parseDBError(err)
+}
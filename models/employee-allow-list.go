@@ -0,0 +1,285 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const maxNumOfAllowedEmployees = 5000
+
+type AllowedEmployee = dbmodels.AllowedEmployee
+type AllowedEmployeeInfo = dbmodels.AllowedEmployeeInfo
+
+type AllowListOption struct {
+	Entries []AllowedEmployee `json:"entries"`
+}
+
+// ParseAllowListCSV parses the allow-list in csv. Each line is either "type,value"
+// or only the value whose type is detected: an email if it includes '@', a domain
+// if it starts with '@', otherwise a login of code platform.
+func ParseAllowListCSV(data []byte) (AllowListOption, IModelError) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	opt := AllowListOption{}
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return opt, newModelError(ErrInvalidAllowList, err)
+		}
+
+		switch len(record) {
+		case 1:
+			opt.Entries = append(opt.Entries, detectAllowedEmployee(record[0]))
+
+		case 2:
+			if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "type") {
+				// header
+				continue
+			}
+			opt.Entries = append(opt.Entries, AllowedEmployee{
+				Type:  strings.ToLower(strings.TrimSpace(record[0])),
+				Value: record[1],
+			})
+
+		default:
+			return opt, newModelError(
+				ErrInvalidAllowList, fmt.Errorf("invalid line %d", line),
+			)
+		}
+	}
+
+	return opt, nil
+}
+
+func detectAllowedEmployee(s string) AllowedEmployee {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "@") {
+		return AllowedEmployee{Type: dbmodels.AllowedByDomain, Value: s[1:]}
+	}
+	if strings.Contains(s, "@") {
+		return AllowedEmployee{Type: dbmodels.AllowedByEmail, Value: s}
+	}
+	return AllowedEmployee{Type: dbmodels.AllowedByLogin, Value: s}
+}
+
+// Validate normalizes the entries and checks whether the emails and domains
+// belong to the corporation.
+func (this *AllowListOption) Validate(corpEmail string) IModelError {
+	n := len(this.Entries)
+	if n == 0 || n > maxNumOfAllowedEmployees {
+		return newModelError(
+			ErrInvalidAllowList,
+			fmt.Errorf("the number of entries should be between 1 and %d", maxNumOfAllowedEmployees),
+		)
+	}
+
+	corpID := util.EmailSuffix(corpEmail)
+
+	v := make([]AllowedEmployee, 0, n)
+	m := map[AllowedEmployee]bool{}
+	for i := range this.Entries {
+		item := this.Entries[i]
+		item.Value = strings.TrimSpace(item.Value)
+
+		switch item.Type {
+		case dbmodels.AllowedByEmail:
+			item.Value = strings.ToLower(item.Value)
+			if checkEmailFormat(item.Value) != nil || util.EmailSuffix(item.Value) != corpID {
+				return newModelError(
+					ErrInvalidAllowList,
+					fmt.Errorf("%s is not an email of corporation", item.Value),
+				)
+			}
+
+		case dbmodels.AllowedByDomain:
+			item.Value = strings.ToLower(item.Value)
+			if item.Value != corpID {
+				return newModelError(
+					ErrInvalidAllowList,
+					fmt.Errorf("%s is not the domain of corporation", item.Value),
+				)
+			}
+
+		case dbmodels.AllowedByLogin:
+			if item.Value == "" || strings.ContainsAny(item.Value, " @/") {
+				return newModelError(
+					ErrInvalidAllowList, fmt.Errorf("invalid login: %s", item.Value),
+				)
+			}
+
+		default:
+			return newModelError(
+				ErrInvalidAllowList, fmt.Errorf("unknown type: %s", item.Type),
+			)
+		}
+
+		if !m[item] {
+			m[item] = true
+			v = append(v, item)
+		}
+	}
+
+	this.Entries = v
+	return nil
+}
+
+// Add adds the entries which are not on the allow-list.
+func (this *AllowListOption) Add(linkID, corpEmail, operator string) IModelError {
+	existing, merr := ListAllowedEmployees(linkID, corpEmail)
+	if merr != nil {
+		return merr
+	}
+
+	m := map[AllowedEmployee]bool{}
+	for i := range existing {
+		m[existing[i].AllowedEmployee] = true
+	}
+
+	now := util.Now()
+	v := make([]AllowedEmployeeInfo, 0, len(this.Entries))
+	for _, item := range this.Entries {
+		if !m[item] {
+			v = append(v, AllowedEmployeeInfo{
+				AllowedEmployee: item,
+				AddedBy:         operator,
+				AddedAt:         now,
+			})
+		}
+	}
+
+	if len(v) == 0 {
+		return nil
+	}
+
+	if len(existing)+len(v) > maxNumOfAllowedEmployees {
+		return newModelError(
+			ErrInvalidAllowList,
+			fmt.Errorf("the allow-list can't have more than %d entries", maxNumOfAllowedEmployees),
+		)
+	}
+
+	err := dbmodels.GetDB().AddEmployeesToAllowList(linkID, corpEmail, v)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func (this *AllowListOption) Remove(linkID, corpEmail string) IModelError {
+	for i := range this.Entries {
+		err := dbmodels.GetDB().RemoveEmployeeFromAllowList(linkID, corpEmail, &this.Entries[i])
+		if err == nil {
+			continue
+		}
+
+		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return newModelError(ErrNoLink, err)
+		}
+		return parseDBError(err)
+	}
+
+	return nil
+}
+
+// IsAllowed checks whether the employee matches one of the entries.
+func (this *AllowListOption) IsAllowed(email, login string) bool {
+	for _, item := range this.Entries {
+		switch item.Type {
+		case dbmodels.AllowedByEmail:
+			if strings.EqualFold(item.Value, email) {
+				return true
+			}
+
+		case dbmodels.AllowedByDomain:
+			if strings.EqualFold(item.Value, util.EmailSuffix(email)) {
+				return true
+			}
+
+		case dbmodels.AllowedByLogin:
+			if login != "" && strings.EqualFold(item.Value, login) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ListAllowedEmployees(linkID, corpEmail string) ([]AllowedEmployeeInfo, IModelError) {
+	v, err := dbmodels.GetDB().ListAllowedEmployees(linkID, corpEmail)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// GetAllowList returns the allow-list of the corporation which the email belongs to.
+func GetAllowList(linkID, email string) (AllowListOption, IModelError) {
+	v, merr := ListAllowedEmployees(linkID, email)
+	if merr != nil {
+		return AllowListOption{}, merr
+	}
+
+	r := AllowListOption{Entries: make([]AllowedEmployee, 0, len(v))}
+	for i := range v {
+		r.Entries = append(r.Entries, v[i].AllowedEmployee)
+	}
+	return r, nil
+}
+
+// IsEmployeeAllowed checks whether the employee is on the allow-list of its corporation.
+func IsEmployeeAllowed(linkID, email, login string) (bool, IModelError) {
+	v, merr := GetAllowList(linkID, email)
+	if merr != nil {
+		return false, merr
+	}
+	return v.IsAllowed(email, login), nil
+}
+
+// IsEmployeePreauthorized checks whether the employee is regarded as signed
+// when the employee policy of link is preauthorized.
+func IsEmployeePreauthorized(linkID, email, login string) (bool, IModelError) {
+	b, merr := IsCorpSigned(linkID, email)
+	if merr != nil || !b {
+		return false, merr
+	}
+
+	return IsEmployeeAllowed(linkID, email, login)
+}
+
+func SetEmployeePolicy(linkID, policy string) IModelError {
+	if policy != dbmodels.EmployeePolicySign && policy != dbmodels.EmployeePolicyPreauthorized {
+		return newModelError(
+			ErrInvalidEmployeePolicy, fmt.Errorf("unknown employee policy: %s", policy),
+		)
+	}
+
+	err := dbmodels.GetDB().SetEmployeePolicy(linkID, policy)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
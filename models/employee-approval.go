@@ -0,0 +1,110 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+type PendingEmployeeSigning = dbmodels.PendingEmployeeSigning
+type EmployeeApprovalState = dbmodels.EmployeeApprovalState
+
+// ListPendingEmployeeSignings lists the employee signings waiting for the activation
+// of the link, or of all the links if the link id is empty.
+func ListPendingEmployeeSignings(linkID string) ([]PendingEmployeeSigning, IModelError) {
+	v, err := dbmodels.GetDB().ListPendingEmployeeSignings(linkID)
+	return v, parseDBError(err)
+}
+
+// UpdateEmployeeApprovalState changes the approval state of the pending employee
+// signing from old to v. It returns false if the state has been changed by others.
+func UpdateEmployeeApprovalState(linkID, email string, old, v *EmployeeApprovalState) (bool, IModelError) {
+	b, err := dbmodels.GetDB().UpdateEmployeeApprovalState(linkID, email, old, v)
+	return b, parseDBError(err)
+}
+
+// ResolveEmployeeApproval enables or disables the employee signing which is
+// still waiting for the activation.
+func ResolveEmployeeApproval(linkID, email, signingID string, enabled bool) IModelError {
+	err := dbmodels.GetDB().ResolveEmployeeApproval(linkID, email, signingID, enabled)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLinkOrUnsigned, err)
+	}
+	return parseDBError(err)
+}
+
+type PendingEmployeeApproval struct {
+	PendingEmployeeSigning
+
+	AgeDays int64 `json:"age_days"`
+}
+
+// PendingApprovalsOfCorp is the report of the pending employee signings of a corporation.
+type PendingApprovalsOfCorp struct {
+	CorpID             string                    `json:"corp_id"`
+	Count              int                       `json:"count"`
+	OldestPendingSince int64                     `json:"oldest_pending_since"`
+	MaxAgeDays         int64                     `json:"max_age_days"`
+	Employees          []PendingEmployeeApproval `json:"employees"`
+}
+
+// ReportPendingApprovals groups the pending employee signings of the link by corporation.
+// The corporation which has waited longest is the first.
+func ReportPendingApprovals(linkID string) ([]PendingApprovalsOfCorp, IModelError) {
+	v, merr := ListPendingEmployeeSignings(linkID)
+	if merr != nil {
+		return nil, merr
+	}
+
+	now := util.Now()
+	m := map[string]*PendingApprovalsOfCorp{}
+	for i := range v {
+		item := &v[i]
+
+		corpID := util.EmailSuffix(item.Email)
+		corp, ok := m[corpID]
+		if !ok {
+			corp = &PendingApprovalsOfCorp{CorpID: corpID}
+			m[corpID] = corp
+		}
+
+		age := PendingDays(item.PendingSince, now)
+		corp.Employees = append(corp.Employees, PendingEmployeeApproval{
+			PendingEmployeeSigning: *item,
+			AgeDays:                age,
+		})
+		corp.Count++
+
+		if corp.OldestPendingSince == 0 || item.PendingSince < corp.OldestPendingSince {
+			corp.OldestPendingSince = item.PendingSince
+			corp.MaxAgeDays = age
+		}
+	}
+
+	r := make([]PendingApprovalsOfCorp, 0, len(m))
+	for _, item := range m {
+		sort.Slice(item.Employees, func(i, j int) bool {
+			return item.Employees[i].PendingSince < item.Employees[j].PendingSince
+		})
+		r = append(r, *item)
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].OldestPendingSince < r[j].OldestPendingSince
+	})
+
+	return r, nil
+}
+
+// PendingDays returns the whole days since the time.
+func PendingDays(since, now int64) int64 {
+	if now <= since {
+		return 0
+	}
+	return (now - since) / 86400
+}
@@ -25,6 +25,23 @@ func ListIndividualSigning(linkID, corpEmail, claLang string) ([]dbmodels.Indivi
 	return nil, parseDBError(err)
 }
 
+// ListPendingEmployeeSigning returns the employee signings which are waiting for
+// the activation of employee managers.
+func ListPendingEmployeeSigning(linkID, corpEmail string) ([]dbmodels.IndividualSigningBasicInfo, IModelError) {
+	v, merr := ListIndividualSigning(linkID, corpEmail, "")
+	if merr != nil {
+		return nil, merr
+	}
+
+	r := make([]dbmodels.IndividualSigningBasicInfo, 0, len(v))
+	for i := range v {
+		if !v[i].Enabled {
+			r = append(r, v[i])
+		}
+	}
+	return r, nil
+}
+
 type EmployeeSigningUdateInfo struct {
 	Enabled bool `json:"enabled"`
 }
@@ -9,41 +9,76 @@ import (
 type ModelErrCode string
 
 const (
-	ErrSystemError             ModelErrCode = "system_error"
-	ErrUnknownDBError          ModelErrCode = "unknown_db_error"
-	ErrWrongVerificationCode   ModelErrCode = "wrong_verification_code"
-	ErrVerificationCodeExpired ModelErrCode = "expired_verification_code"
-	ErrUnmatchedUserID         ModelErrCode = "unmatched_user_id"
-	ErrUnmatchedEmail          ModelErrCode = "unmatched_email"
-	ErrNotAnEmail              ModelErrCode = "not_an_email"
-	ErrNoLink                  ModelErrCode = "no_link"
-	ErrNoLinkOrResigned        ModelErrCode = "no_link_or_resigned"
-	ErrNoLinkOrUnsigned        ModelErrCode = "no_link_or_unsigned"
-	ErrUnsigned                ModelErrCode = "unsigned"
-	ErrSamePassword            ModelErrCode = "same_password"
-	ErrWrongOldPassword        ModelErrCode = "wrong_old_password"
-	ErrTooShortOrLongPassword  ModelErrCode = "too_short_or_long_password"
-	ErrInvalidPassword         ModelErrCode = "invalid_password"
-	ErrNoLinkOrNoManagerOrFO   ModelErrCode = "no_link_or_no_manager_or_frequent_operation"
-	ErrNoLinkOrManagerExists   ModelErrCode = "no_link_or_manager_exists"
-	ErrCorpManagerExists       ModelErrCode = "corp_manager_exists"
-	ErrCorpManagerDoesNotExist ModelErrCode = "corp_manager_does_not_exist"
-	ErrInvalidManagerID        ModelErrCode = "invalid_manager_id"
-	ErrDuplicateManagerID      ModelErrCode = "duplicate_manager_id"
-	ErrEmptyPayload            ModelErrCode = "empty_payload"
-	ErrAdminAsManager          ModelErrCode = "admin_as_manager"
-	ErrNotSameCorp             ModelErrCode = "not_same_corp"
-	ErrManyEmployeeManagers    ModelErrCode = "many_employee_managers"
-	ErrOrgEmailNotExists       ModelErrCode = "org_email_not_exists"
-	ErrLinkExists              ModelErrCode = "link_exists"
-	ErrUnsupportedCLALang      ModelErrCode = "unsupported_cla_lang"
-	ErrNoCLAField              ModelErrCode = "no_cla_field"
-	ErrManyCLAField            ModelErrCode = "many_cla_field"
-	ErrCLAFieldID              ModelErrCode = "invalid_cla_field_id"
-	ErrNoOrgSignature          ModelErrCode = "missing_org_signature"
-	ErrMissgingCLA             ModelErrCode = "missing_cla"
-	ErrNoLinkOrCLAExists       ModelErrCode = "no_link_or_cla_exists"
-	ErrNoLinkOrUnuploaed       ModelErrCode = "no_link_or_unuploaded"
+	ErrSystemError              ModelErrCode = "system_error"
+	ErrUnknownDBError           ModelErrCode = "unknown_db_error"
+	ErrWrongVerificationCode    ModelErrCode = "wrong_verification_code"
+	ErrVerificationCodeExpired  ModelErrCode = "expired_verification_code"
+	ErrUnmatchedUserID          ModelErrCode = "unmatched_user_id"
+	ErrUnmatchedEmail           ModelErrCode = "unmatched_email"
+	ErrNotAnEmail               ModelErrCode = "not_an_email"
+	ErrNoLink                   ModelErrCode = "no_link"
+	ErrNoLinkOrResigned         ModelErrCode = "no_link_or_resigned"
+	ErrNoLinkOrUnsigned         ModelErrCode = "no_link_or_unsigned"
+	ErrUnsigned                 ModelErrCode = "unsigned"
+	ErrSamePassword             ModelErrCode = "same_password"
+	ErrWrongOldPassword         ModelErrCode = "wrong_old_password"
+	ErrTooShortOrLongPassword   ModelErrCode = "too_short_or_long_password"
+	ErrInvalidPassword          ModelErrCode = "invalid_password"
+	ErrNoLinkOrNoManagerOrFO    ModelErrCode = "no_link_or_no_manager_or_frequent_operation"
+	ErrNoLinkOrManagerExists    ModelErrCode = "no_link_or_manager_exists"
+	ErrCorpManagerExists        ModelErrCode = "corp_manager_exists"
+	ErrCorpManagerDoesNotExist  ModelErrCode = "corp_manager_does_not_exist"
+	ErrInvalidManagerID         ModelErrCode = "invalid_manager_id"
+	ErrDuplicateManagerID       ModelErrCode = "duplicate_manager_id"
+	ErrEmptyPayload             ModelErrCode = "empty_payload"
+	ErrAdminAsManager           ModelErrCode = "admin_as_manager"
+	ErrNotSameCorp              ModelErrCode = "not_same_corp"
+	ErrManyEmployeeManagers     ModelErrCode = "many_employee_managers"
+	ErrOrgEmailNotExists        ModelErrCode = "org_email_not_exists"
+	ErrLinkExists               ModelErrCode = "link_exists"
+	ErrUnsupportedCLALang       ModelErrCode = "unsupported_cla_lang"
+	ErrUnsupportedCLAFormat     ModelErrCode = "unsupported_cla_format"
+	ErrNoCLAField               ModelErrCode = "no_cla_field"
+	ErrManyCLAField             ModelErrCode = "many_cla_field"
+	ErrCLAFieldID               ModelErrCode = "invalid_cla_field_id"
+	ErrNoOrgSignature           ModelErrCode = "missing_org_signature"
+	ErrMissgingCLA              ModelErrCode = "missing_cla"
+	ErrNoLinkOrCLAExists        ModelErrCode = "no_link_or_cla_exists"
+	ErrNoLinkOrUnuploaed        ModelErrCode = "no_link_or_unuploaded"
+	ErrAccountLocked            ModelErrCode = "account_locked"
+	ErrVerificationCodeLocked   ModelErrCode = "verification_code_locked"
+	ErrClientIPLocked           ModelErrCode = "client_ip_locked"
+	ErrFrequentAuthAttempts     ModelErrCode = "frequent_auth_attempts"
+	ErrInvalidSession           ModelErrCode = "invalid_session"
+	ErrInvalidAccessToken       ModelErrCode = "invalid_access_token"
+	ErrNoCorpOIDC               ModelErrCode = "no_corp_oidc"
+	ErrInvalidCorpOIDC          ModelErrCode = "invalid_corp_oidc"
+	ErrNoPrivacyPolicy          ModelErrCode = "no_privacy_policy"
+	ErrUnmatchedPrivacyPolicy   ModelErrCode = "unmatched_privacy_policy"
+	ErrInvalidCLAField          ModelErrCode = "invalid_cla_field"
+	ErrInvalidSigningInfo       ModelErrCode = "invalid_signing_info"
+	ErrNoPDFLayout              ModelErrCode = "no_pdf_layout"
+	ErrNoSigningReceipt         ModelErrCode = "no_signing_receipt"
+	ErrInvalidSignatory         ModelErrCode = "invalid_signatory"
+	ErrNotSignatory             ModelErrCode = "not_signatory"
+	ErrNoLinkOrCounterSigned    ModelErrCode = "no_link_or_counter_signed"
+	ErrNotCounterSigned         ModelErrCode = "not_counter_signed"
+	ErrInvalidCLATemplate       ModelErrCode = "invalid_cla_template"
+	ErrNoCLATemplate            ModelErrCode = "no_cla_template"
+	ErrNoCLATemplateOrCLAExists ModelErrCode = "no_cla_template_or_cla_exists"
+	ErrInvalidRepo              ModelErrCode = "invalid_repo"
+	ErrRepoHasLink              ModelErrCode = "repo_has_link"
+	ErrNoOrgLink                ModelErrCode = "no_org_link"
+	ErrInvalidLinkMode          ModelErrCode = "invalid_link_mode"
+	ErrInvalidOrg               ModelErrCode = "invalid_org"
+	ErrInvalidSuspension        ModelErrCode = "invalid_suspension"
+	ErrNoArchivedLink           ModelErrCode = "no_archived_link"
+	ErrInvalidAllowList         ModelErrCode = "invalid_allow_list"
+	ErrInvalidEmployeePolicy    ModelErrCode = "invalid_employee_policy"
+	ErrInvalidValidity          ModelErrCode = "invalid_corp_signing_validity"
+	ErrInvalidAmendment         ModelErrCode = "invalid_amendment"
+	ErrAmendmentPending         ModelErrCode = "amendment_pending"
+	ErrNoPendingAmendment       ModelErrCode = "no_pending_amendment"
 )
 
 type IModelError interface {
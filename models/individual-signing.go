@@ -12,6 +12,8 @@ func InitializeIndividualSigning(linkID string, cla *CLAInfo) IModelError {
 	return parseDBError(err)
 }
 
+const lengthOfSigningID = 20
+
 type IndividualSigning dbmodels.IndividualSigningInfo
 
 func (this *IndividualSigning) Validate(userID, email string) IModelError {
@@ -23,10 +25,11 @@ func (this *IndividualSigning) Validate(userID, email string) IModelError {
 		return newModelError(ErrUnmatchedUserID, fmt.Errorf("unmatched user id"))
 	}
 
-	return nil
+	return checkPrivacyConsent(&this.PrivacyConsent)
 }
 
 func (this *IndividualSigning) Create(linkID string, enabled bool) IModelError {
+	this.SigningID = util.RandStr(lengthOfSigningID, "alphanum")
 	this.Date = util.Date()
 	this.Enabled = enabled
 
@@ -50,3 +53,44 @@ func IsIndividualSigned(linkID, email string) (bool, IModelError) {
 	}
 	return b, parseDBError(err)
 }
+
+// GetIndividualSigning returns the signing of individual or employee with the signing info.
+func GetIndividualSigning(linkID, email string) (*IndividualSigning, IModelError) {
+	v, err := dbmodels.GetDB().GetIndividualSigning(linkID, email)
+	if err != nil {
+		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return nil, newModelError(ErrNoLink, err)
+		}
+		return nil, parseDBError(err)
+	}
+
+	if v == nil {
+		return nil, newModelError(ErrUnsigned, fmt.Errorf("unsigned"))
+	}
+	return (*IndividualSigning)(v), nil
+}
+
+// UploadIndividualSigningPDF saves the receipt of individual signing.
+func UploadIndividualSigningPDF(linkID, signingID string, pdf []byte) IModelError {
+	err := dbmodels.GetDB().UploadIndividualSigningPDF(linkID, signingID, pdf)
+	return parseDBError(err)
+}
+
+// DeleteIndividualSigningPDF deletes the receipt of individual signing, since
+// the personal data is printed on it.
+func DeleteIndividualSigningPDF(linkID, signingID string) IModelError {
+	err := dbmodels.GetDB().DeleteIndividualSigningPDF(linkID, signingID)
+	return parseDBError(err)
+}
+
+func DownloadIndividualSigningPDF(linkID, signingID, path string) IModelError {
+	err := dbmodels.GetDB().DownloadIndividualSigningPDF(linkID, signingID, path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoSigningReceipt, err)
+	}
+	return parseDBError(err)
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const maxLengthOfSuspensionReason = 200
+
+type LinkExport = dbmodels.LinkExport
+
+type LinkSuspendOption struct {
+	Reason string `json:"reason"`
+	// CheckResult is returned as the result of checking
+	// whether the contributor has signed.
+	CheckResult bool `json:"check_result"`
+}
+
+func (this *LinkSuspendOption) Validate() IModelError {
+	if len([]rune(this.Reason)) > maxLengthOfSuspensionReason {
+		return newModelError(
+			ErrInvalidSuspension,
+			fmt.Errorf("the reason is longer than %d", maxLengthOfSuspensionReason),
+		)
+	}
+	return nil
+}
+
+// Suspend closes the signing of link until it is resumed.
+func (this *LinkSuspendOption) Suspend(linkID, operator string) IModelError {
+	err := dbmodels.GetDB().SuspendLink(linkID, &dbmodels.LinkSuspension{
+		Reason:      this.Reason,
+		CheckResult: this.CheckResult,
+		Operator:    operator,
+		SuspendedAt: util.Now(),
+	})
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func ResumeLink(linkID string) IModelError {
+	err := dbmodels.GetDB().ResumeLink(linkID)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+// RestoreLink restores the archived link if there is not another link
+// for the same org or repo, or for any of the other repos it covers.
+func RestoreLink(link *LinkInfo) IModelError {
+	orgRepos := []OrgRepo{link.OrgRepo}
+	for _, repo := range link.Repos {
+		v := link.OrgRepo
+		v.RepoID = repo
+		orgRepos = append(orgRepos, v)
+	}
+
+	for i := range orgRepos {
+		if _, err := GetLinkID(&orgRepos[i]); err == nil {
+			return newModelError(
+				ErrLinkExists, fmt.Errorf("there is a link for %s", orgRepos[i].OrgRepoID()),
+			)
+		} else if !err.IsErrorOf(ErrNoLink) {
+			return err
+		}
+	}
+
+	err := dbmodels.GetDB().RestoreLink(link.LinkID)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoArchivedLink, err)
+	}
+	return parseDBError(err)
+}
+
+func GetArchivedLink(linkID string) (*LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().GetArchivedLink(linkID)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoArchivedLink, err)
+	}
+	return nil, parseDBError(err)
+}
+
+func ListArchivedLinks(platform string, orgs []string) ([]LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().ListArchivedLinks(&dbmodels.LinkListOption{
+		Platform: platform,
+		Orgs:     orgs,
+	})
+	return v, parseDBError(err)
+}
+
+func ExportLink(linkID string) (*LinkExport, IModelError) {
+	v, err := dbmodels.GetDB().ExportLink(linkID)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+// fakeLinkDB records the active links by the repos they cover.
+type fakeLinkDB struct {
+	dbmodels.IDB
+
+	links    map[string]string
+	restored bool
+}
+
+func (this *fakeLinkDB) GetLinkOfRepo(orgRepo *dbmodels.OrgRepo) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	if v, ok := this.links[orgRepo.RepoID]; ok {
+		return &dbmodels.LinkInfo{LinkID: v}, nil
+	}
+	return nil, dbmodels.NewDBError(dbmodels.ErrNoDBRecord, fmt.Errorf("no record"))
+}
+
+func (this *fakeLinkDB) RestoreLink(linkID string) dbmodels.IDBError {
+	this.restored = true
+	return nil
+}
+
+func TestRestoreLink(t *testing.T) {
+	link := &LinkInfo{
+		LinkID: "archived",
+		OrgInfo: dbmodels.OrgInfo{
+			OrgRepo: dbmodels.OrgRepo{Platform: "github", OrgID: "org", RepoID: "a"},
+		},
+		Repos: []string{"b", "c"},
+	}
+
+	cases := []struct {
+		name     string
+		links    map[string]string
+		restored bool
+	}{
+		{name: "no conflict", links: map[string]string{"d": "active"}, restored: true},
+		{name: "repo is taken", links: map[string]string{"a": "active"}},
+		{name: "extra repo is taken", links: map[string]string{"c": "active"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db := &fakeLinkDB{links: c.links}
+			dbmodels.RegisterDB(db)
+
+			merr := RestoreLink(link)
+			if c.restored {
+				if merr != nil {
+					t.Fatalf("unexpected error: %v", merr)
+				}
+			} else if merr == nil || !merr.IsErrorOf(ErrLinkExists) {
+				t.Fatalf("expect the error of %s, got %v", ErrLinkExists, merr)
+			}
+
+			if db.restored != c.restored {
+				t.Fatalf("expect restored to be %t", c.restored)
+			}
+		})
+	}
+}
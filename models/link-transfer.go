@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+// LinkTransferOption is the org or repo on the same code platform which
+// the link will be moved to.
+type LinkTransferOption struct {
+	OrgID  string `json:"org_id"`
+	RepoID string `json:"repo_id"`
+}
+
+func (this *LinkTransferOption) Validate() IModelError {
+	if this.OrgID == "" || strings.ContainsAny(this.OrgID, "/: ") {
+		return newModelError(ErrInvalidOrg, fmt.Errorf("invalid org: %s", this.OrgID))
+	}
+
+	if this.RepoID != "" {
+		return validateRepoName(this.RepoID)
+	}
+	return nil
+}
+
+// Transfer moves the link to the org or repo of option. The signings are
+// kept since they are bound to the link id.
+func (this *LinkTransferOption) Transfer(linkID string, from *OrgRepo, operator string) IModelError {
+	to := OrgRepo{
+		Platform: from.Platform,
+		OrgID:    this.OrgID,
+		RepoID:   this.RepoID,
+	}
+	if to == *from {
+		return newModelError(ErrInvalidOrg, fmt.Errorf("transfer to the same org and repo"))
+	}
+
+	if _, err := GetLinkID(&to); err == nil {
+		return newModelError(ErrLinkExists, fmt.Errorf("there is a link for the target"))
+	} else if !err.IsErrorOf(ErrNoLink) {
+		return err
+	}
+
+	record := dbmodels.LinkTransfer{
+		From:          from.OrgRepoID(),
+		To:            to.OrgRepoID(),
+		Operator:      operator,
+		TransferredAt: util.Now(),
+	}
+
+	err := dbmodels.GetDB().TransferLink(linkID, from, &to, &record)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func GetLink(linkID string) (*LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().GetLink(linkID)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
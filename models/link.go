@@ -2,12 +2,14 @@ package models
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opensourceways/app-cla-server/dbmodels"
 )
 
 type OrgInfo = dbmodels.OrgInfo
 type OrgRepo = dbmodels.OrgRepo
+type LinkInfo = dbmodels.LinkInfo
 
 type LinkCreateOption struct {
 	Platform string `json:"platform"`
@@ -16,16 +18,40 @@ type LinkCreateOption struct {
 	OrgAlias string `json:"org_alias"`
 	OrgEmail string `json:"org_email"`
 
+	// Mode is one of cla and dco, it is cla if empty.
+	Mode string `json:"mode"`
+
 	IndividualCLA *CLACreateOpt `json:"individual_cla"`
 	CorpCLA       *CLACreateOpt `json:"corp_cla"`
 
 	orgEmailInfo *dbmodels.OrgEmailCreateInfo `json:"-"`
+
+	// set is the clas copied from a link or a cla template, and
+	// templateID is the cla template which the link refers to.
+	set        *CLASet `json:"-"`
+	templateID string  `json:"-"`
 }
 
 func (this *LinkCreateOption) Validate(langs map[string]bool) IModelError {
 	individualcla := this.IndividualCLA
 	corpCLA := this.CorpCLA
 
+	switch this.Mode {
+	case "", dbmodels.LinkModeCLA:
+	case dbmodels.LinkModeDCO:
+		if (individualcla != nil) || (corpCLA != nil) {
+			return newModelError(
+				ErrInvalidLinkMode,
+				fmt.Errorf("the link of dco mode can't have cla"),
+			)
+		}
+		return this.validateOrgEmail()
+	default:
+		return newModelError(
+			ErrInvalidLinkMode, fmt.Errorf("unknown link mode: %s", this.Mode),
+		)
+	}
+
 	if (individualcla == nil) && (corpCLA == nil) {
 		return newModelError(
 			ErrMissgingCLA,
@@ -45,6 +71,63 @@ func (this *LinkCreateOption) Validate(langs map[string]bool) IModelError {
 		}
 	}
 
+	return this.validateOrgEmail()
+}
+
+// ValidateCLASet validates the option of link which will be created with
+// all the clas of the set. Each cla is validated before anything is saved,
+// so that the link will not be created with part of them.
+func (this *LinkCreateOption) ValidateCLASet(set *CLASet, langs map[string]bool) IModelError {
+	if set.IsEmpty() {
+		return newModelError(ErrMissgingCLA, fmt.Errorf("no cla to copy"))
+	}
+
+	for i := range set.IndividualCLAs {
+		if err := set.IndividualCLAs[i].validateCopied("", langs); err != nil {
+			return err
+		}
+	}
+
+	for i := range set.CorpCLAs {
+		if err := set.CorpCLAs[i].validateCopied(dbmodels.ApplyToCorporation, langs); err != nil {
+			return err
+		}
+	}
+
+	this.Mode = dbmodels.LinkModeCLA
+	this.IndividualCLA = nil
+	this.CorpCLA = nil
+	this.set = set
+
+	return this.validateOrgEmail()
+}
+
+// ReferToCLATemplate makes the link follow the cla template. The clas
+// added to the template later will be added to the link too.
+func (this *LinkCreateOption) ReferToCLATemplate(id string) {
+	this.templateID = id
+}
+
+// CLAs returns all the clas of the kind which the link will be created with.
+func (this *LinkCreateOption) CLAs(applyTo string) []CLACreateOpt {
+	if this.set != nil {
+		if applyTo == dbmodels.ApplyToCorporation {
+			return this.set.CorpCLAs
+		}
+		return this.set.IndividualCLAs
+	}
+
+	cla := this.IndividualCLA
+	if applyTo == dbmodels.ApplyToCorporation {
+		cla = this.CorpCLA
+	}
+	if cla == nil {
+		return nil
+	}
+	return []CLACreateOpt{*cla}
+}
+
+func (this *LinkCreateOption) validateOrgEmail() IModelError {
 	orgEmail, err := dbmodels.GetDB().GetOrgEmailInfo(this.OrgEmail)
 	if err != nil {
 		if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
@@ -66,24 +149,19 @@ func (this LinkCreateOption) Create(linkID, submitter string) IModelError {
 	info.OrgEmail = *this.orgEmailInfo
 	info.Submitter = submitter
 
+	info.Mode = this.Mode
+	if this.Mode == "" {
+		info.Mode = dbmodels.LinkModeCLA
+	}
+
 	info.OrgAlias = this.OrgAlias
 	if this.OrgAlias == "" {
 		info.OrgAlias = this.OrgID
 	}
 
-	cla := this.IndividualCLA
-	if cla != nil {
-		info.IndividualCLAs = []dbmodels.CLACreateOption{
-			*cla.toCLACreateOption(),
-		}
-	}
-
-	cla = this.CorpCLA
-	if cla != nil {
-		info.CorpCLAs = []dbmodels.CLACreateOption{
-			*cla.toCLACreateOption(),
-		}
-	}
+	info.TemplateID = this.templateID
+	info.IndividualCLAs = toCLACreateOptions(this.CLAs(dbmodels.ApplyToIndividual))
+	info.CorpCLAs = toCLACreateOptions(this.CLAs(dbmodels.ApplyToCorporation))
 
 	_, err := dbmodels.GetDB().CreateLink(&info)
 	if err == nil {
@@ -97,10 +175,11 @@ func (this LinkCreateOption) Create(linkID, submitter string) IModelError {
 	return parseDBError(err)
 }
 
+// GetLinkID returns the link which is bound to the org or repo explicitly.
 func GetLinkID(orgRepo *OrgRepo) (string, IModelError) {
-	b, err := dbmodels.GetDB().GetLinkID(orgRepo)
+	v, err := dbmodels.GetDB().GetLinkOfRepo(orgRepo)
 	if err == nil {
-		return b, nil
+		return v.LinkID, nil
 	}
 
 	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
@@ -109,6 +188,104 @@ func GetLinkID(orgRepo *OrgRepo) (string, IModelError) {
 	return "", parseDBError(err)
 }
 
+// ResolveLink returns the link which is effective for the org or repo.
+// The link of repo takes precedence over the link of org.
+func ResolveLink(orgRepo *OrgRepo) (*LinkInfo, IModelError) {
+	v, err := dbmodels.GetDB().ResolveLink(orgRepo)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoLink, err)
+	}
+	return nil, parseDBError(err)
+}
+
+// MoveRepoToLink makes the link cover the repo of its org. The repo is removed
+// from the other links and the signings of them are kept.
+func MoveRepoToLink(linkID, repo string) IModelError {
+	if err := validateRepoName(repo); err != nil {
+		return err
+	}
+
+	org, merr := GetOrgOfLink(linkID)
+	if merr != nil {
+		return merr
+	}
+	if org.RepoID == repo {
+		return nil
+	}
+
+	v, err := dbmodels.GetDB().GetLinkOfRepo(
+		&OrgRepo{Platform: org.Platform, OrgID: org.OrgID, RepoID: repo},
+	)
+	if err == nil {
+		if v.RepoID == repo {
+			return newModelError(
+				ErrRepoHasLink, fmt.Errorf("the repo is bound to the link: %s", v.LinkID),
+			)
+		}
+	} else if !err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return parseDBError(err)
+	}
+
+	err = dbmodels.GetDB().MoveRepoToLink(linkID, &org.OrgRepo, repo)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func RemoveRepoFromLink(linkID, repo string) IModelError {
+	err := dbmodels.GetDB().RemoveRepoFromLink(linkID, repo)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+// SetExcludedRepos sets the repos which the link of org doesn't cover.
+func SetExcludedRepos(linkID string, repos []string) IModelError {
+	v := make([]string, 0, len(repos))
+	m := map[string]bool{}
+	for _, item := range repos {
+		if err := validateRepoName(item); err != nil {
+			return err
+		}
+
+		if !m[item] {
+			m[item] = true
+			v = append(v, item)
+		}
+	}
+
+	err := dbmodels.GetDB().SetExcludedRepos(linkID, v)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoOrgLink, err)
+	}
+	return parseDBError(err)
+}
+
+func validateRepoName(repo string) IModelError {
+	if repo == "" || strings.ContainsAny(repo, "/: ") {
+		return newModelError(ErrInvalidRepo, fmt.Errorf("invalid repo: %s", repo))
+	}
+	return nil
+}
+
 func Unlink(linkID string) IModelError {
 	err := dbmodels.GetDB().Unlink(linkID)
 	if err == nil {
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+type PDFLayout = dbmodels.PDFLayout
+
+// SavePDFLayout saves the layout of corporation signing pdf which has been
+// validated by the pdf generator.
+func SavePDFLayout(linkID string, layout []byte, logo []byte) IModelError {
+	err := dbmodels.GetDB().SavePDFLayout(linkID, &dbmodels.PDFLayout{
+		Layout:    string(layout),
+		Logo:      logo,
+		UpdatedAt: util.Now(),
+	})
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
+
+func GetPDFLayout(linkID string) (*PDFLayout, IModelError) {
+	v, err := dbmodels.GetDB().GetPDFLayout(linkID)
+	if err == nil {
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrNoPDFLayout, fmt.Errorf("the pdf layout is not customized"))
+	}
+	return nil, parseDBError(err)
+}
+
+// DeletePDFLayout restores the default layout of corporation signing pdf.
+func DeletePDFLayout(linkID string) IModelError {
+	err := dbmodels.GetDB().DeletePDFLayout(linkID)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrNoLink, err)
+	}
+	return parseDBError(err)
+}
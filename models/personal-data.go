@@ -0,0 +1,135 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+// PurposeOfPersonalData is the purpose of verification code which is used to
+// access or erase the personal data.
+const PurposeOfPersonalData = "personal data"
+
+type PersonalData = dbmodels.PersonalData
+
+type PersonalDataRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+func (this *PersonalDataRequest) Validate() IModelError {
+	if merr := checkEmailFormat(this.Email); merr != nil {
+		return merr
+	}
+
+	return checkVerificationCode(this.Email, this.Code, PurposeOfPersonalData)
+}
+
+type PersonalDataErasure struct {
+	Pseudonym string `json:"pseudonym"`
+
+	// the links on which the individual signings are pseudonymised
+	// or the employee manager records are removed.
+	ErasedLinks []string `json:"erased_links"`
+
+	// the links on which the data is retained, because the email is the
+	// administrator of corporation and the signing of corporation depends on it.
+	RetainedLinks []string `json:"retained_links"`
+}
+
+func GetPersonalData(email string) (*PersonalData, IModelError) {
+	v, err := dbmodels.GetDB().GetPersonalData(email)
+	if err != nil {
+		return nil, parseDBError(err)
+	}
+	return v, nil
+}
+
+// ErasePersonalData pseudonymises the individual signings, deletes their receipts
+// and removes the employee manager records.
+func ErasePersonalData(email string) (*PersonalDataErasure, IModelError) {
+	data, merr := GetPersonalData(email)
+	if merr != nil {
+		return nil, merr
+	}
+
+	r := &PersonalDataErasure{Pseudonym: PseudonymOfEmail(email)}
+	erased := map[string]bool{}
+	retained := map[string]bool{}
+
+	// the receipts are deleted first, because they can't be found
+	// by the email after the signings are pseudonymised.
+	for i := range data.IndividualSignings {
+		item := &data.IndividualSignings[i]
+
+		// the receipt is not generated for the signings before the signing id was introduced.
+		if item.SigningID != "" {
+			if merr := DeleteIndividualSigningPDF(item.LinkID, item.SigningID); merr != nil {
+				return nil, merr
+			}
+		}
+		erased[item.LinkID] = true
+	}
+
+	if len(data.IndividualSignings) > 0 {
+		err := dbmodels.GetDB().PseudonymiseIndividualSignings(email, r.Pseudonym)
+		if err != nil {
+			return nil, parseDBError(err)
+		}
+	}
+
+	managerLinks := []string{}
+	for i := range data.CorpManagers {
+		item := &data.CorpManagers[i]
+		if item.Role == dbmodels.RoleManager {
+			managerLinks = append(managerLinks, item.LinkID)
+			erased[item.LinkID] = true
+		} else {
+			retained[item.LinkID] = true
+		}
+	}
+	for i := range data.CorpSignings {
+		retained[data.CorpSignings[i].LinkID] = true
+	}
+
+	if len(managerLinks) > 0 {
+		if err := dbmodels.GetDB().DeleteEmployeeManagerOfEmail(email); err != nil {
+			return nil, parseDBError(err)
+		}
+
+		// the manager can't access the links any more by the tokens issued before.
+		emails := []string{email}
+		for _, linkID := range managerLinks {
+			if merr := RevokeSessionsOfCorpManagers(linkID, emails); merr != nil {
+				return nil, merr
+			}
+			if merr := RevokePersonalAccessTokensOfCorpManagers(linkID, emails); merr != nil {
+				return nil, merr
+			}
+		}
+	}
+
+	r.ErasedLinks = keysOfSet(erased)
+	r.RetainedLinks = keysOfSet(retained)
+	return r, nil
+}
+
+// PseudonymOfEmail generates the pseudonym which is the same for the same email,
+// so the signing can be proved by the email when necessary.
+func PseudonymOfEmail(email string) string {
+	h := sha256.Sum256([]byte(strings.ToLower(email)))
+
+	return fmt.Sprintf("erased.%s@%s", hex.EncodeToString(h[:12]), util.EmailSuffix(email))
+}
+
+func keysOfSet(m map[string]bool) []string {
+	r := make([]string, 0, len(m))
+	for k := range m {
+		r = append(r, k)
+	}
+	return r
+}
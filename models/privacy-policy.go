@@ -0,0 +1,165 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+const currentPrivacyPolicy = "current"
+
+type PrivacyPolicySummary struct {
+	Version string `json:"version"`
+	Date    string `json:"date"`
+
+	// the key is the language and the value is the hash of document
+	Hashes map[string]string `json:"hashes"`
+}
+
+type PrivacyPolicy struct {
+	Version  string `json:"version"`
+	Date     string `json:"date"`
+	Language string `json:"language"`
+	Hash     string `json:"hash"`
+	Content  string `json:"content"`
+}
+
+type privacyPolicyVersion struct {
+	PrivacyPolicySummary
+
+	contents map[string]string
+}
+
+// privacyPolicies is in chronological order and the last one is the current version.
+var privacyPolicies []privacyPolicyVersion
+
+func InitPrivacyPolicies(cfg *config.PrivacyPolicy) error {
+	v := make([]privacyPolicyVersion, 0, len(cfg.Versions))
+
+	for _, item := range cfg.Versions {
+		p := privacyPolicyVersion{
+			PrivacyPolicySummary: PrivacyPolicySummary{
+				Version: item.Version,
+				Date:    item.Date,
+				Hashes:  make(map[string]string, len(item.Files)),
+			},
+			contents: make(map[string]string, len(item.Files)),
+		}
+
+		for lang, f := range item.Files {
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				return fmt.Errorf("Failed to read privacy policy of version:%s, err:%s", item.Version, err.Error())
+			}
+
+			h := sha256.Sum256(b)
+			p.Hashes[lang] = hex.EncodeToString(h[:])
+			p.contents[lang] = string(b)
+		}
+
+		v = append(v, p)
+	}
+
+	privacyPolicies = v
+	return nil
+}
+
+func ListPrivacyPolicies() []PrivacyPolicySummary {
+	r := make([]PrivacyPolicySummary, 0, len(privacyPolicies))
+	for i := range privacyPolicies {
+		r = append(r, privacyPolicies[i].PrivacyPolicySummary)
+	}
+	return r
+}
+
+// GetPrivacyPolicy returns the document of privacy policy. The version can be "current".
+func GetPrivacyPolicy(version, lang string) (*PrivacyPolicy, IModelError) {
+	p := getPrivacyPolicyVersion(version)
+	if p == nil {
+		return nil, newModelError(ErrNoPrivacyPolicy, fmt.Errorf("no privacy policy of version:%s", version))
+	}
+
+	content, ok := p.contents[lang]
+	if !ok {
+		return nil, newModelError(
+			ErrNoPrivacyPolicy,
+			fmt.Errorf("no privacy policy of version:%s in language:%s", version, lang),
+		)
+	}
+
+	return &PrivacyPolicy{
+		Version:  p.Version,
+		Date:     p.Date,
+		Language: lang,
+		Hash:     p.Hashes[lang],
+		Content:  content,
+	}, nil
+}
+
+func CurrentPrivacyPolicyVersion() string {
+	if n := len(privacyPolicies); n > 0 {
+		return privacyPolicies[n-1].Version
+	}
+	return ""
+}
+
+func getPrivacyPolicyVersion(version string) *privacyPolicyVersion {
+	n := len(privacyPolicies)
+	if n == 0 {
+		return nil
+	}
+
+	if version == currentPrivacyPolicy {
+		return &privacyPolicies[n-1]
+	}
+
+	for i := range privacyPolicies {
+		if privacyPolicies[i].Version == version {
+			return &privacyPolicies[i]
+		}
+	}
+	return nil
+}
+
+// checkPrivacyConsent checks that the signer accepted the current privacy policy
+// in one of its languages. It is not checked when no privacy policy is configured.
+func checkPrivacyConsent(c *dbmodels.PrivacyConsent) IModelError {
+	n := len(privacyPolicies)
+	if n == 0 {
+		return nil
+	}
+
+	p := &privacyPolicies[n-1]
+	if c.PrivacyPolicyVersion != p.Version {
+		return newModelError(
+			ErrUnmatchedPrivacyPolicy,
+			fmt.Errorf("the current version of privacy policy is %s", p.Version),
+		)
+	}
+
+	for _, h := range p.Hashes {
+		if h == c.PrivacyPolicyHash {
+			return nil
+		}
+	}
+
+	return newModelError(ErrUnmatchedPrivacyPolicy, fmt.Errorf("unmatched hash of privacy policy"))
+}
+
+// ListOutdatedPrivacyConsents lists the signers of link who didn't accept the current privacy policy.
+func ListOutdatedPrivacyConsents(linkID string) ([]dbmodels.OutdatedPrivacyConsent, IModelError) {
+	version := CurrentPrivacyPolicyVersion()
+	if version == "" {
+		return nil, newModelError(ErrNoPrivacyPolicy, fmt.Errorf("no privacy policy"))
+	}
+
+	v, err := dbmodels.GetDB().ListOutdatedPrivacyConsents(linkID, version)
+	if err != nil {
+		return nil, parseDBError(err)
+	}
+	return v, nil
+}
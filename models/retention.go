@@ -0,0 +1,72 @@
+package models
+
+import "github.com/opensourceways/app-cla-server/dbmodels"
+
+func PurgeVerificationCodes(expiredBefore int64) (int64, IModelError) {
+	n, err := dbmodels.GetDB().PurgeVerificationCodes(expiredBefore)
+	return n, parseDBError(err)
+}
+
+// PurgeDeletedCorpSignings deletes the pdfs of deleted corporation signings before
+// the records, so the purging can be retried if it fails halfway.
+func PurgeDeletedCorpSignings(deletedBefore int64) (int64, IModelError) {
+	db := dbmodels.GetDB()
+
+	v, err := db.ListDeletedCorpSigningsToPurge(deletedBefore)
+	if err != nil {
+		return 0, parseDBError(err)
+	}
+
+	for i := range v {
+		if err := db.DeleteCorpSigningPDFs(v[i].LinkID, v[i].AdminEmail); err != nil {
+			return 0, parseDBError(err)
+		}
+	}
+
+	n, err := db.PurgeDeletedCorpSignings(deletedBefore)
+	return n, parseDBError(err)
+}
+
+func PurgeAuthRecords(before int64) (int64, IModelError) {
+	n, err := dbmodels.GetDB().PurgeAuthRecords(before)
+	return n, parseDBError(err)
+}
+
+func ListUnlinkedLinks(unlinkedBefore int64) ([]string, IModelError) {
+	v, err := dbmodels.GetDB().ListUnlinkedLinks(unlinkedBefore)
+	return v, parseDBError(err)
+}
+
+func ListLinkIDs() ([]string, IModelError) {
+	v, err := dbmodels.GetDB().ListLinkIDs()
+	return v, parseDBError(err)
+}
+
+// PurgeDataOfLink deletes the receipts of individual signings and the pdfs of
+// corporation signings before the records, so the purging can be retried if it
+// fails halfway.
+func PurgeDataOfLink(linkID string) IModelError {
+	db := dbmodels.GetDB()
+
+	ids, err := db.ListIndividualSigningPDFs(linkID)
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	for _, id := range ids {
+		if merr := DeleteIndividualSigningPDF(linkID, id); merr != nil {
+			return merr
+		}
+	}
+
+	if err := db.DeleteCorpSigningPDFsOfLink(linkID); err != nil {
+		return parseDBError(err)
+	}
+
+	return parseDBError(db.PurgeDataOfLink(linkID))
+}
+
+func AcquireLease(name, holder string, expiry int64) (bool, IModelError) {
+	b, err := dbmodels.GetDB().AcquireLease(name, holder, expiry)
+	return b, parseDBError(err)
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+type Session = dbmodels.Session
+
+func SessionSubjectOfCodePlatformUser(platform, user string) string {
+	return fmt.Sprintf("%s/%s", platform, user)
+}
+
+func SessionSubjectOfCorpManager(linkID, email string) string {
+	return fmt.Sprintf("%s/%s", linkID, strings.ToLower(email))
+}
+
+func CreateSession(opt *Session) IModelError {
+	now := util.Now()
+	opt.CreatedAt = now
+	opt.LastUsed = now
+
+	err := dbmodels.GetDB().CreateSession(opt)
+	return parseDBError(err)
+}
+
+func GetSession(id string) (*Session, IModelError) {
+	v, err := dbmodels.GetDB().GetSession(id)
+	if err == nil {
+		if v.Expiry < util.Now() {
+			return nil, newModelError(ErrInvalidSession, fmt.Errorf("session is expired"))
+		}
+		return v, nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return nil, newModelError(ErrInvalidSession, fmt.Errorf("session is revoked"))
+	}
+	return nil, parseDBError(err)
+}
+
+func RefreshSession(id, ip string, expiry int64) IModelError {
+	err := dbmodels.GetDB().RefreshSession(id, ip, expiry)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return newModelError(ErrInvalidSession, fmt.Errorf("session is revoked"))
+	}
+	return parseDBError(err)
+}
+
+func ListSessions(subject string) ([]Session, IModelError) {
+	v, err := dbmodels.GetDB().ListSessions(subject)
+	return v, parseDBError(err)
+}
+
+func DeleteSession(id string) IModelError {
+	err := dbmodels.GetDB().DeleteSession(id)
+	return parseDBError(err)
+}
+
+// RevokeSessions deletes all the sessions of subject except the one specified.
+func RevokeSessions(subject, except string) IModelError {
+	err := dbmodels.GetDB().DeleteSessions(&dbmodels.SessionDeleteOption{
+		Subjects: []string{subject},
+		Except:   except,
+	})
+	return parseDBError(err)
+}
+
+func RevokeSessionsOfCorpManagers(linkID string, emails []string) IModelError {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	subjects := make([]string, 0, len(emails))
+	for _, item := range emails {
+		subjects = append(subjects, SessionSubjectOfCorpManager(linkID, item))
+	}
+
+	err := dbmodels.GetDB().DeleteSessions(&dbmodels.SessionDeleteOption{
+		LinkID:   linkID,
+		Subjects: subjects,
+	})
+	return parseDBError(err)
+}
+
+func RevokeSessionsOfLink(linkID string) IModelError {
+	err := dbmodels.GetDB().DeleteSessions(&dbmodels.SessionDeleteOption{LinkID: linkID})
+	return parseDBError(err)
+}
@@ -25,6 +25,11 @@ func CreateVerificationCode(email, purpose string, expiry int64) (string, IModel
 }
 
 func checkVerificationCode(email, code, purpose string) IModelError {
+	attempt := NewAuthAttemptOfVerificationCode(email, purpose)
+	if merr := attempt.Check(); merr != nil {
+		return merr
+	}
+
 	vc := dbmodels.VerificationCode{
 		Email:   email,
 		Code:    code,
@@ -33,6 +38,8 @@ func checkVerificationCode(email, code, purpose string) IModelError {
 
 	err := dbmodels.GetDB().GetVerificationCode(&vc)
 	if err == nil {
+		attempt.Succeed()
+
 		if vc.Expiry < util.Now() {
 			return newModelError(ErrVerificationCodeExpired, fmt.Errorf("verification code is expired"))
 		}
@@ -41,6 +48,9 @@ func checkVerificationCode(email, code, purpose string) IModelError {
 	}
 
 	if err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		if merr := attempt.Fail(); merr != nil {
+			return merr
+		}
 		return newModelError(ErrWrongVerificationCode, err)
 	}
 	return parseDBError(err)
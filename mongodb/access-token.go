@@ -0,0 +1,203 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func (this *client) CreatePersonalAccessToken(opt *dbmodels.PersonalAccessToken) dbmodels.IDBError {
+	owner, err := this.encrypt.encryptStr(opt.Owner)
+	if err != nil {
+		return err
+	}
+
+	payload, err := this.encrypt.encryptBytes(opt.Payload)
+	if err != nil {
+		return err
+	}
+
+	info := cAccessToken{
+		ID:         opt.ID,
+		Name:       opt.Name,
+		Owner:      owner,
+		Permission: opt.Permission,
+		LinkID:     opt.LinkID,
+		Hash:       opt.Hash,
+		Links:      opt.Links,
+		Scopes:     opt.Scopes,
+		Expiry:     opt.Expiry,
+		CreatedAt:  opt.CreatedAt,
+	}
+	body, err := structToMap(info)
+	if err != nil {
+		return err
+	}
+	body[fieldPayload] = payload
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		if _, err := this.insertDoc(ctx, this.accessTokenCollection, body); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetPersonalAccessToken(id string) (*dbmodels.PersonalAccessToken, dbmodels.IDBError) {
+	var v cAccessToken
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(ctx, this.accessTokenCollection, bson.M{fieldID: id}, nil, &v)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	r, err := this.toModelOfAccessToken(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := this.encrypt.decryptBytes(v.Payload)
+	if err != nil {
+		return nil, err
+	}
+	r.Payload = payload
+
+	return r, nil
+}
+
+func (this *client) ListPersonalAccessTokens(owner string) ([]dbmodels.PersonalAccessToken, dbmodels.IDBError) {
+	s, err := this.encrypt.encryptStr(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var v []cAccessToken
+	f := func(ctx context.Context) error {
+		return this.getDocs(
+			ctx, this.accessTokenCollection,
+			bson.M{fieldOwner: s}, bson.M{fieldPayload: 0, fieldHash: 0}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	r := make([]dbmodels.PersonalAccessToken, 0, len(v))
+	for i := range v {
+		item, err := this.toModelOfAccessToken(&v[i])
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, *item)
+	}
+
+	return r, nil
+}
+
+func (this *client) UpdatePersonalAccessTokenLastUsed(id string, lastUsed int64) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.accessTokenCollection, bson.M{fieldID: id},
+			bson.M{fieldLastUsed: lastUsed},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) DeletePersonalAccessToken(owner, id string) dbmodels.IDBError {
+	s, err := this.encrypt.encryptStr(owner)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.accessTokenCollection)
+
+		r, err := col.DeleteOne(ctx, bson.M{fieldID: id, fieldOwner: s})
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		if r.DeletedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+// DeletePersonalAccessTokensOfLink pulls the link from the tokens, since the token
+// of community owner may cover several links, then deletes the ones without any link.
+func (this *client) DeletePersonalAccessTokensOfLink(linkID string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.accessTokenCollection)
+
+		_, err := col.UpdateMany(
+			ctx, bson.M{fieldLinks: linkID},
+			bson.M{"$pull": bson.M{fieldLinks: linkID}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		if _, err := col.DeleteMany(ctx, bson.M{fieldLinks: bson.M{"$size": 0}}); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) DeletePersonalAccessTokensOfOwners(owners []string) dbmodels.IDBError {
+	v := make(bson.A, 0, len(owners))
+	for _, item := range owners {
+		s, err := this.encrypt.encryptStr(item)
+		if err != nil {
+			return err
+		}
+		v = append(v, s)
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.accessTokenCollection)
+
+		if _, err := col.DeleteMany(ctx, bson.M{fieldOwner: bson.M{"$in": v}}); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) toModelOfAccessToken(doc *cAccessToken) (*dbmodels.PersonalAccessToken, dbmodels.IDBError) {
+	owner, err := this.encrypt.decryptStr(doc.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbmodels.PersonalAccessToken{
+		ID:         doc.ID,
+		Name:       doc.Name,
+		Owner:      owner,
+		Permission: doc.Permission,
+		LinkID:     doc.LinkID,
+		Hash:       doc.Hash,
+		Links:      doc.Links,
+		Scopes:     doc.Scopes,
+		Expiry:     doc.Expiry,
+		CreatedAt:  doc.CreatedAt,
+		LastUsed:   doc.LastUsed,
+	}, nil
+}
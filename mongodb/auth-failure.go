@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+func (this *client) docFilterOfAuthFailure(kind, key string) (bson.M, dbmodels.IDBError) {
+	k, err := this.encrypt.encryptStr(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{fieldKind: kind, fieldKey: k}, nil
+}
+
+func (this *client) GetAuthFailure(kind, key string) (*dbmodels.AuthFailure, dbmodels.IDBError) {
+	docFilter, err := this.docFilterOfAuthFailure(kind, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var v cAuthFailure
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(ctx, this.authFailureCollection, docFilter, bson.M{fieldKey: 0}, &v)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	return toModelOfAuthFailure(&v), nil
+}
+
+func (this *client) AddAuthFailure(kind, key string, window int64) (*dbmodels.AuthFailure, dbmodels.IDBError) {
+	docFilter, err := this.docFilterOfAuthFailure(kind, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.Now()
+	var v cAuthFailure
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.authFailureCollection)
+
+		// restart counting when the window is over and the account is not locked.
+		filter := bson.M{
+			fieldFirstFailure: bson.M{"$lt": now - window},
+			fieldLockedUntil:  bson.M{"$lt": now},
+		}
+		for k, v := range docFilter {
+			filter[k] = v
+		}
+		reset := bson.M{"$set": bson.M{
+			fieldFailures:     0,
+			fieldFirstFailure: now,
+			fieldNotified:     false,
+		}}
+		if _, err := col.UpdateOne(ctx, filter, reset); err != nil {
+			return newSystemError(err)
+		}
+
+		update := bson.M{
+			"$inc": bson.M{fieldFailures: 1},
+			"$set": bson.M{fieldLastFailure: now},
+			"$setOnInsert": bson.M{
+				fieldFirstFailure: now,
+				fieldLockedUntil:  0,
+				fieldNotified:     false,
+			},
+		}
+		sr := col.FindOneAndUpdate(
+			ctx, docFilter, update,
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		)
+		if err := sr.Decode(&v); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	return toModelOfAuthFailure(&v), nil
+}
+
+func (this *client) LockAuthFailure(kind, key string, lockedUntil int64) dbmodels.IDBError {
+	docFilter, err := this.docFilterOfAuthFailure(kind, key)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.authFailureCollection, docFilter,
+			bson.M{fieldLockedUntil: lockedUntil},
+		)
+	}
+
+	return withContext1(f)
+}
+
+// MarkAuthFailureNotified returns true only for the first call after the lockout,
+// so that the notification is sent once even if there are many instances.
+func (this *client) MarkAuthFailureNotified(kind, key string) (bool, dbmodels.IDBError) {
+	docFilter, err := this.docFilterOfAuthFailure(kind, key)
+	if err != nil {
+		return false, err
+	}
+	docFilter[fieldNotified] = false
+	docFilter[fieldLockedUntil] = bson.M{"$gt": util.Now()}
+
+	changed := false
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.authFailureCollection)
+
+		r, err := col.UpdateOne(ctx, docFilter, bson.M{"$set": bson.M{fieldNotified: true}})
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		changed = r.ModifiedCount > 0
+		return nil
+	}
+
+	if err := withContext1(f); err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+func (this *client) DeleteAuthFailure(kind, key string) dbmodels.IDBError {
+	docFilter, err := this.docFilterOfAuthFailure(kind, key)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.authFailureCollection)
+
+		if _, err := col.DeleteOne(ctx, docFilter); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func toModelOfAuthFailure(doc *cAuthFailure) *dbmodels.AuthFailure {
+	return &dbmodels.AuthFailure{
+		Failures:    doc.Failures,
+		LastFailure: doc.LastFailure,
+		LockedUntil: doc.LockedUntil,
+	}
+}
@@ -121,6 +121,7 @@ func (this *client) GetCLAInfoSigned(linkID, claLang, applyTo string) (*dbmodels
 
 	doc := &(v[0].CLAInfos[0])
 	return &dbmodels.CLAInfo{
+		CLAFormat:        doc.Format,
 		CLAHash:          doc.CLAHash,
 		OrgSignatureHash: doc.OrgSignatureHash,
 		Fields:           toModelOfCLAFields(doc.Fields),
@@ -130,6 +131,7 @@ func (this *client) GetCLAInfoSigned(linkID, claLang, applyTo string) (*dbmodels
 func toDocOfCLAInfo(info *dbmodels.CLAInfo) *DCLAInfo {
 	return &DCLAInfo{
 		Language:         info.CLALang,
+		Format:           info.CLAFormat,
 		CLAHash:          info.CLAHash,
 		OrgSignatureHash: info.OrgSignatureHash,
 		Fields:           toDocOfCLAField(info.Fields),
@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func docFilterOfCLATemplate(id string) bson.M {
+	return bson.M{fieldID: id}
+}
+
+func (this *client) CreateCLATemplate(opt *dbmodels.CLATemplateCreateOption) dbmodels.IDBError {
+	info := cCLATemplate{
+		ID:        opt.ID,
+		Name:      opt.Name,
+		Platform:  opt.Platform,
+		Org:       opt.Org,
+		Submitter: opt.Submitter,
+		CreatedAt: opt.CreatedAt,
+	}
+	body, err := structToMap(info)
+	if err != nil {
+		return err
+	}
+
+	if err := toDocOfCLAs(body, opt.IndividualCLAs, opt.CorpCLAs); err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		if _, err := this.insertDoc(ctx, this.claTemplateCollection, body); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetCLATemplate(id string) (*dbmodels.CLATemplateInfo, dbmodels.IDBError) {
+	project := bson.M{
+		fmt.Sprintf("%s.%s", fieldCorpCLAs, fieldOrgSignature): 0,
+	}
+
+	var v cCLATemplate
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(
+			ctx, this.claTemplateCollection, docFilterOfCLATemplate(id), project, &v,
+		)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	return toModelOfCLATemplate(&v), nil
+}
+
+func (this *client) ListCLATemplates(opt *dbmodels.CLATemplateListOption) ([]dbmodels.CLATemplateInfo, dbmodels.IDBError) {
+	owners := bson.A{
+		bson.M{fieldOrg: "", fieldSubmitter: opt.Submitter},
+	}
+	if len(opt.Orgs) > 0 {
+		owners = append(owners, bson.M{fieldOrg: bson.M{"$in": opt.Orgs}})
+	}
+
+	filter := bson.M{
+		fieldPlatform: opt.Platform,
+		"$or":         owners,
+	}
+
+	// the text of cla is too big to be listed
+	project := bson.M{
+		fmt.Sprintf("%s.text", fieldIndividualCLAs):            0,
+		fmt.Sprintf("%s.text", fieldCorpCLAs):                  0,
+		fmt.Sprintf("%s.%s", fieldCorpCLAs, fieldOrgSignature): 0,
+	}
+
+	var v []cCLATemplate
+	f := func(ctx context.Context) error {
+		return this.getDocs(ctx, this.claTemplateCollection, filter, project, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	r := make([]dbmodels.CLATemplateInfo, 0, len(v))
+	for i := range v {
+		r = append(r, *toModelOfCLATemplate(&v[i]))
+	}
+	return r, nil
+}
+
+func (this *client) DeleteCLATemplate(id string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.claTemplateCollection)
+
+		r, err := col.DeleteOne(ctx, docFilterOfCLATemplate(id))
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		if r.DeletedCount == 0 {
+			return errNoDBRecord
+		}
+
+		// the links keep the clas copied from the template.
+		_, err = this.collection(this.linkCollection).UpdateMany(
+			ctx, bson.M{fieldTemplateID: id},
+			bson.M{"$unset": bson.M{fieldTemplateID: ""}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) AddCLAToTemplate(id, applyTo string, cla *dbmodels.CLACreateOption) dbmodels.IDBError {
+	body, err := toDocOfCLA(cla)
+	if err != nil {
+		return err
+	}
+
+	claField := fieldNameOfCLA(applyTo)
+
+	docFilter := docFilterOfCLATemplate(id)
+	arrayFilterByElemMatch(claField, false, elemFilterOfCLA(cla.Language), docFilter)
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.pushArrayElem(
+			ctx, this.claTemplateCollection, claField, docFilter, body,
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) DeleteCLAOfTemplate(id, applyTo, language string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.pullArrayElem(
+			ctx, this.claTemplateCollection, fieldNameOfCLA(applyTo),
+			docFilterOfCLATemplate(id), elemFilterOfCLA(language),
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) DownloadOrgSignatureOfTemplate(id, language string) ([]byte, dbmodels.IDBError) {
+	var v []cCLATemplate
+
+	f := func(ctx context.Context) error {
+		return this.getArrayElem(
+			ctx, this.claTemplateCollection, fieldCorpCLAs,
+			docFilterOfCLATemplate(id), elemFilterOfCLA(language),
+			bson.M{fmt.Sprintf("%s.%s", fieldCorpCLAs, fieldOrgSignature): 1},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	if len(v) == 0 {
+		return nil, errNoDBRecord
+	}
+
+	if len(v[0].CorpCLAs) == 0 {
+		return nil, nil
+	}
+	return v[0].CorpCLAs[0].OrgSignature, nil
+}
+
+func toModelOfCLATemplate(doc *cCLATemplate) *dbmodels.CLATemplateInfo {
+	return &dbmodels.CLATemplateInfo{
+		CLATemplate: dbmodels.CLATemplate{
+			ID:        doc.ID,
+			Name:      doc.Name,
+			Platform:  doc.Platform,
+			Org:       doc.Org,
+			Submitter: doc.Submitter,
+			CreatedAt: doc.CreatedAt,
+		},
+		IndividualCLAs: toModelOfCLAs(doc.IndividualCLAs),
+		CorpCLAs:       toModelOfCLAs(doc.CorpCLAs),
+	}
+}
@@ -100,10 +100,15 @@ func (this *client) GetCLAByType(orgRepo *dbmodels.OrgRepo, applyTo string) (str
 		}
 	}
 
+	link, err := this.ResolveLink(orgRepo)
+	if err != nil {
+		return "", nil, err
+	}
+
 	var v cLink
 	f := func(ctx context.Context) dbmodels.IDBError {
 		return this.getDoc(
-			ctx, this.linkCollection, docFilterOfLink(orgRepo), project, &v,
+			ctx, this.linkCollection, docFilterOfCLA(link.LinkID), project, &v,
 		)
 	}
 
@@ -181,6 +186,7 @@ func (this *client) GetCLAInfoToSign(linkID, claLang, applyTo string) (*dbmodels
 
 	item := &(doc[0])
 	return &dbmodels.CLAInfo{
+		CLAFormat:        item.Format,
 		CLAHash:          item.CLAHash,
 		OrgSignatureHash: item.OrgSignatureHash,
 		Fields:           toModelOfCLAFields(item.Fields),
@@ -223,6 +229,10 @@ func toModelOfCLAFields(fields []dField) []dbmodels.Field {
 			Type:        v.Type,
 			Description: v.Description,
 			Required:    v.Required,
+			Options:     v.Options,
+			Pattern:     v.Pattern,
+			MinLength:   v.MinLength,
+			MaxLength:   v.MaxLength,
 		})
 	}
 	return fs
@@ -241,6 +251,7 @@ func toModelOfCLAs(data []dCLA) []dbmodels.CLADetail {
 
 		cla.URL = item.URL
 		cla.Language = item.Language
+		cla.Format = item.Format
 
 		if len(item.Fields) > 0 {
 			cla.Fields = toModelOfCLAFields(item.Fields)
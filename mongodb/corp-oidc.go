@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func docFilterOfCorpOIDC(linkID, corpID string) bson.M {
+	return bson.M{
+		fieldLinkID: linkID,
+		fieldCorpID: corpID,
+	}
+}
+
+func (this *client) SaveCorpOIDC(opt *dbmodels.CorpOIDC) dbmodels.IDBError {
+	secret, err := this.encrypt.encryptBytes([]byte(opt.ClientSecret))
+	if err != nil {
+		return err
+	}
+
+	info := cCorpOIDC{
+		LinkID:    opt.LinkID,
+		CorpID:    opt.CorpID,
+		Issuer:    opt.Issuer,
+		ClientID:  opt.ClientID,
+		UpdatedAt: opt.UpdatedAt,
+	}
+	body, err := structToMap(info)
+	if err != nil {
+		return err
+	}
+	body[fieldClientSecret] = secret
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		_, err := this.replaceDoc(
+			ctx, this.corpOIDCCollection,
+			docFilterOfCorpOIDC(opt.LinkID, opt.CorpID), body,
+		)
+		return err
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetCorpOIDC(linkID, corpID string) (*dbmodels.CorpOIDC, dbmodels.IDBError) {
+	var v cCorpOIDC
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(
+			ctx, this.corpOIDCCollection,
+			docFilterOfCorpOIDC(linkID, corpID), nil, &v,
+		)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	return this.toModelOfCorpOIDC(&v)
+}
+
+func (this *client) ListCorpOIDCs(corpID string) ([]dbmodels.CorpOIDC, dbmodels.IDBError) {
+	var v []cCorpOIDC
+
+	f := func(ctx context.Context) error {
+		return this.getDocs(
+			ctx, this.corpOIDCCollection, bson.M{fieldCorpID: corpID}, nil, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	r := make([]dbmodels.CorpOIDC, 0, len(v))
+	for i := range v {
+		item, err := this.toModelOfCorpOIDC(&v[i])
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, *item)
+	}
+
+	return r, nil
+}
+
+func (this *client) DeleteCorpOIDC(linkID, corpID string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.corpOIDCCollection)
+
+		r, err := col.DeleteOne(ctx, docFilterOfCorpOIDC(linkID, corpID))
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		if r.DeletedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) toModelOfCorpOIDC(doc *cCorpOIDC) (*dbmodels.CorpOIDC, dbmodels.IDBError) {
+	secret, err := this.encrypt.decryptBytes(doc.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbmodels.CorpOIDC{
+		LinkID:       doc.LinkID,
+		CorpID:       doc.CorpID,
+		Issuer:       doc.Issuer,
+		ClientID:     doc.ClientID,
+		ClientSecret: string(secret),
+		UpdatedAt:    doc.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+const (
+	fieldChanges    = "changes"
+	fieldPrevious   = "previous"
+	fieldReviewedBy = "reviewed_by"
+	fieldReviewedAt = "reviewed_at"
+	fieldReason     = "reason"
+)
+
+func (this *client) SetAmendmentApproval(linkID string, required bool) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfCLA(linkID), bson.M{fieldAmendApproval: required},
+		)
+	}
+
+	return withContext1(f)
+}
+
+// AddCorpSigningAmendment records the amendment if there is not a pending one.
+func (this *client) AddCorpSigningAmendment(linkID, email string, v *dbmodels.CorpSigningAmendment, info dbmodels.TypeSigningInfo) dbmodels.IDBError {
+	doc, err := this.toDocOfCorpSigningAmendment(v)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$push": bson.M{elemOfSignings(fieldAmendments): doc},
+	}
+	if info != nil {
+		si, err := this.encrypt.encryptSigningInfo(&info)
+		if err != nil {
+			return err
+		}
+		update["$set"] = bson.M{elemOfSignings(fieldInfo): si}
+	}
+
+	elemFilter := elemFilterOfCorpSigning(email)
+
+	docFilter := docFilterOfSigning(linkID)
+	docFilter[fieldSignings] = bson.M{"$elemMatch": bson.M{
+		fieldCorpID:                         elemFilter[fieldCorpID],
+		memberNameOfAmendments(fieldStatus): bson.M{"$ne": dbmodels.AmendmentPending},
+	}}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateCorpSigningElem(
+			ctx, docFilter, update, bson.A{arrayFilterOfSigning(elemFilter)},
+		)
+	}
+
+	return withContext1(f)
+}
+
+// ReviewCorpSigningAmendment approves or rejects the pending amendment.
+func (this *client) ReviewCorpSigningAmendment(linkID, email string, v *dbmodels.CorpSigningAmendment, info dbmodels.TypeSigningInfo) dbmodels.IDBError {
+	elemOfAmendments := func(k string) string {
+		return fmt.Sprintf("%s.$[a].%s", elemOfSignings(fieldAmendments), k)
+	}
+
+	set := bson.M{
+		elemOfAmendments(fieldStatus):     v.Status,
+		elemOfAmendments(fieldReviewedBy): v.ReviewedBy,
+		elemOfAmendments(fieldReviewedAt): v.ReviewedAt,
+		elemOfAmendments(fieldReason):     v.Reason,
+	}
+
+	if info != nil {
+		// the changes are recomputed against the current values when it is applied.
+		changes, err := this.encrypt.encryptSigningInfo(&v.Changes)
+		if err != nil {
+			return err
+		}
+		set[elemOfAmendments(fieldChanges)] = changes
+
+		previous, err := this.encrypt.encryptSigningInfo(&v.Previous)
+		if err != nil {
+			return err
+		}
+		set[elemOfAmendments(fieldPrevious)] = previous
+
+		si, err := this.encrypt.encryptSigningInfo(&info)
+		if err != nil {
+			return err
+		}
+		set[elemOfSignings(fieldInfo)] = si
+	}
+
+	pending := bson.M{
+		fieldID:     v.ID,
+		fieldStatus: dbmodels.AmendmentPending,
+	}
+
+	elemFilter := elemFilterOfCorpSigning(email)
+
+	docFilter := docFilterOfSigning(linkID)
+	docFilter[fieldSignings] = bson.M{"$elemMatch": bson.M{
+		fieldCorpID:     elemFilter[fieldCorpID],
+		fieldAmendments: bson.M{"$elemMatch": pending},
+	}}
+
+	arrayFilterOfAmendment := bson.M{}
+	for k, v := range pending {
+		arrayFilterOfAmendment["a."+k] = v
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateCorpSigningElem(
+			ctx, docFilter, bson.M{"$set": set},
+			bson.A{arrayFilterOfSigning(elemFilter), arrayFilterOfAmendment},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) ListCorpSigningAmendments(linkID, email string) ([]dbmodels.CorpSigningAmendment, dbmodels.IDBError) {
+	var v []cCorpSigning
+
+	f := func(ctx context.Context) error {
+		return this.getArrayElem(
+			ctx, this.corpSigningCollection, fieldSignings,
+			docFilterOfSigning(linkID), elemFilterOfCorpSigning(email),
+			bson.M{memberNameOfSignings(fieldAmendments): 1}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	if len(v) == 0 {
+		return nil, errNoDBRecord
+	}
+
+	signings := v[0].Signings
+	if len(signings) == 0 {
+		return nil, nil
+	}
+
+	items := signings[0].Amendments
+	r := make([]dbmodels.CorpSigningAmendment, 0, len(items))
+	for i := range items {
+		item, err := this.toModelOfCorpSigningAmendment(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, *item)
+	}
+
+	return r, nil
+}
+
+func (this *client) toDocOfCorpSigningAmendment(v *dbmodels.CorpSigningAmendment) (bson.M, dbmodels.IDBError) {
+	doc, err := structToMap(dCorpSigningAmendment{
+		ID:          v.ID,
+		Status:      v.Status,
+		SubmittedBy: v.SubmittedBy,
+		SubmittedAt: v.SubmittedAt,
+		ReviewedBy:  v.ReviewedBy,
+		ReviewedAt:  v.ReviewedAt,
+		Reason:      v.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if doc[fieldChanges], err = this.encrypt.encryptSigningInfo(&v.Changes); err != nil {
+		return nil, err
+	}
+
+	if len(v.Previous) > 0 {
+		if doc[fieldPrevious], err = this.encrypt.encryptSigningInfo(&v.Previous); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+func (this *client) toModelOfCorpSigningAmendment(v *dCorpSigningAmendment) (*dbmodels.CorpSigningAmendment, dbmodels.IDBError) {
+	changes, err := this.encrypt.decryptSigningInfo(v.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &dbmodels.CorpSigningAmendment{
+		ID:          v.ID,
+		Status:      v.Status,
+		Changes:     *changes,
+		SubmittedBy: v.SubmittedBy,
+		SubmittedAt: v.SubmittedAt,
+		ReviewedBy:  v.ReviewedBy,
+		ReviewedAt:  v.ReviewedAt,
+		Reason:      v.Reason,
+	}
+
+	if len(v.Previous) > 0 {
+		previous, err := this.encrypt.decryptSigningInfo(v.Previous)
+		if err != nil {
+			return nil, err
+		}
+		r.Previous = *previous
+	}
+
+	return r, nil
+}
+
+func memberNameOfAmendments(key string) string {
+	return fmt.Sprintf("%s.%s", fieldAmendments, key)
+}
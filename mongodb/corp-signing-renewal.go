@@ -0,0 +1,131 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+// SetCorpSigningValidity sets the validity period of corporation signing.
+// The validity is removed if it is nil.
+func (this *client) SetCorpSigningValidity(linkID string, v *dbmodels.CorpSigningValidity) dbmodels.IDBError {
+	update := bson.M{"$unset": bson.M{fieldValidity: ""}}
+	if v != nil {
+		doc, err := structToMap(dCorpSigningValidity{
+			ValidityDays:     v.ValidityDays,
+			GraceDays:        v.GraceDays,
+			RemindBeforeDays: v.RemindBeforeDays,
+		})
+		if err != nil {
+			return err
+		}
+
+		update = bson.M{"$set": bson.M{fieldValidity: doc}}
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		r, err := this.collection(this.linkCollection).UpdateOne(ctx, docFilterOfCLA(linkID), update)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) ListLinksWithCorpSigningValidity() ([]dbmodels.LinkInfo, dbmodels.IDBError) {
+	filter := bson.M{
+		fieldLinkStatus: linkStatusReady,
+		fieldValidity:   bson.M{"$type": "object"},
+	}
+
+	return this.getAllLinks(filter, projectOfLinkInfo())
+}
+
+// RenewCorpSigning starts a new validity period of the signing. The counter-signature
+// is cleared since it is made on the pdf of previous period.
+func (this *client) RenewCorpSigning(linkID, email string, v *dbmodels.CorpSigningRenewal) dbmodels.IDBError {
+	doc, err := structToMap(dCorpSigningRenewal{
+		PreviousValidFrom: v.PreviousValidFrom,
+		RenewedAt:         v.RenewedAt,
+		Operator:          v.Operator,
+	})
+	if err != nil {
+		return err
+	}
+
+	elemFilter := elemFilterOfCorpSigning(email)
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	update := bson.M{
+		"$set": bson.M{
+			elemOfSignings(fieldValidFrom):   v.RenewedAt,
+			elemOfSignings(fieldReminders):   0,
+			elemOfSignings(fieldCounterSign): nil,
+		},
+		"$push": bson.M{elemOfSignings(fieldRenewals): doc},
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateCorpSigningElem(
+			ctx, docFilter, update, bson.A{arrayFilterOfSigning(elemFilter)},
+		)
+	}
+
+	return withContext1(f)
+}
+
+// UpdateCorpSigningRenewalReminders records the number of renewal reminders which
+// have been sent to the corporation administrator in the current period. It changes
+// the number only if it is still prev, and returns false otherwise.
+func (this *client) UpdateCorpSigningRenewalReminders(linkID, email string, prev, n int) (bool, dbmodels.IDBError) {
+	elemFilter := elemFilterOfCorpSigning(email)
+	if prev == 0 {
+		// the signing which was signed before the validity is introduced has no number.
+		elemFilter[fieldReminders] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		elemFilter[fieldReminders] = prev
+	}
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateArrayElem(
+			ctx, this.corpSigningCollection, fieldSignings, docFilter,
+			elemFilter, bson.M{fieldReminders: n},
+		)
+	}
+
+	err := withContext1(f)
+	if err != nil && err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func toModelOfCorpSigningRenewals(v []dCorpSigningRenewal) []dbmodels.CorpSigningRenewal {
+	if len(v) == 0 {
+		return nil
+	}
+
+	r := make([]dbmodels.CorpSigningRenewal, 0, len(v))
+	for i := range v {
+		item := &v[i]
+		r = append(r, dbmodels.CorpSigningRenewal{
+			PreviousValidFrom: item.PreviousValidFrom,
+			RenewedAt:         item.RenewedAt,
+			Operator:          item.Operator,
+		})
+	}
+
+	return r
+}
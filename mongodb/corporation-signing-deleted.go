@@ -7,6 +7,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
 )
 
 func (this *client) getCorpSigning(linkID, email string) (*dCorpSigning, dbmodels.IDBError) {
@@ -45,6 +46,8 @@ func (this *client) DeleteCorpSigning(linkID, email string) dbmodels.IDBError {
 		return nil
 	}
 
+	data.DeletedAt = util.Now()
+
 	doc, err := structToMap(data)
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/opensourceways/app-cla-server/dbmodels"
 )
@@ -31,6 +32,10 @@ func (c *client) SignCorpCLA(linkID string, info *dbmodels.CorpSigningCreateOpt)
 		AdminEmail:  email,
 		AdminName:   info.AdminName,
 		Date:        info.Date,
+		ValidFrom:   info.ValidFrom,
+
+		PrivacyVersion: info.PrivacyPolicyVersion,
+		PrivacyHash:    info.PrivacyPolicyHash,
 	}
 	doc, err := structToMap(signing)
 	if err != nil {
@@ -209,6 +214,10 @@ func (this *client) GetCorpSigningDetail(linkID, email string) ([]dbmodels.Field
 	info := &dbmodels.CorpSigningCreateOpt{
 		CorporationSigningBasicInfo: *bi,
 		Info:                        *si,
+		PrivacyConsent: dbmodels.PrivacyConsent{
+			PrivacyPolicyVersion: signing.PrivacyVersion,
+			PrivacyPolicyHash:    signing.PrivacyHash,
+		},
 	}
 	return toModelOfCLAFields(clas[0].Fields), info, nil
 }
@@ -219,13 +228,82 @@ func (c *client) toDBModelCorporationSigningBasicInfo(cs *dCorpSigning) (*dbmode
 		return nil, err
 	}
 
-	return &dbmodels.CorporationSigningBasicInfo{
+	bi := &dbmodels.CorporationSigningBasicInfo{
 		CLALanguage:     cs.CLALanguage,
 		AdminEmail:      email,
 		AdminName:       cs.AdminName,
 		CorporationName: cs.CorpName,
 		Date:            cs.Date,
-	}, nil
+
+		ValidFrom:        cs.ValidFrom,
+		RenewalReminders: cs.RenewalReminders,
+		Renewals:         toModelOfCorpSigningRenewals(cs.Renewals),
+	}
+
+	if v := cs.CounterSignature; v != nil {
+		bi.CounterSignature = &dbmodels.CounterSignature{
+			Signatory: v.Signatory,
+			Name:      v.Name,
+			Title:     v.Title,
+			Date:      v.Date,
+			Digest:    v.Digest,
+		}
+	}
+
+	return bi, nil
+}
+
+// CounterSignCorpCLA records the counter-signature if the signing has not been counter-signed.
+func (this *client) CounterSignCorpCLA(linkID, email string, v *dbmodels.CounterSignature) dbmodels.IDBError {
+	doc, err := structToMap(dCounterSignature{
+		Signatory: v.Signatory,
+		Name:      v.Name,
+		Title:     v.Title,
+		Date:      v.Date,
+		Digest:    v.Digest,
+	})
+	if err != nil {
+		return err
+	}
+
+	elemFilter := elemFilterOfCorpSigning(email)
+	elemFilter[fieldCounterSign] = nil
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateArrayElem(
+			ctx, this.corpSigningCollection, fieldSignings, docFilter,
+			elemFilter, bson.M{fieldCounterSign: doc},
+		)
+	}
+
+	return withContext1(f)
+}
+
+// CancelCounterSignature removes the counter-signature only if it is the one of v.
+func (this *client) CancelCounterSignature(linkID, email string, v *dbmodels.CounterSignature) dbmodels.IDBError {
+	member := func(k string) string {
+		return fmt.Sprintf("%s.%s", fieldCounterSign, k)
+	}
+
+	elemFilter := elemFilterOfCorpSigning(email)
+	elemFilter[member(fieldSignatory)] = v.Signatory
+	elemFilter[member(fieldDate)] = v.Date
+	elemFilter[member(fieldDigest)] = v.Digest
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateArrayElem(
+			ctx, this.corpSigningCollection, fieldSignings, docFilter,
+			elemFilter, bson.M{fieldCounterSign: nil},
+		)
+	}
+
+	return withContext1(f)
 }
 
 func projectOfCorpSigning() bson.M {
@@ -235,5 +313,41 @@ func projectOfCorpSigning() bson.M {
 		memberNameOfSignings(fieldCorp):  1,
 		memberNameOfSignings(fieldDate):  1,
 		memberNameOfSignings(fieldLang):  1,
+
+		memberNameOfSignings(fieldCounterSign): 1,
+		memberNameOfSignings(fieldValidFrom):   1,
+		memberNameOfSignings(fieldReminders):   1,
+		memberNameOfSignings(fieldRenewals):    1,
+	}
+}
+
+// elemOfSignings returns the key of the signing matched by the array filter named i.
+func elemOfSignings(key string) string {
+	return fmt.Sprintf("%s.$[i].%s", fieldSignings, key)
+}
+
+// arrayFilterOfSigning converts the filter of signing to the array filter named i.
+func arrayFilterOfSigning(elemFilter bson.M) bson.M {
+	r := bson.M{}
+	for k, v := range elemFilter {
+		r["i."+k] = v
+	}
+	return r
+}
+
+func (this *client) updateCorpSigningElem(ctx context.Context, docFilter, update bson.M, arrayFilters bson.A) dbmodels.IDBError {
+	r, err := this.collection(this.corpSigningCollection).UpdateOne(
+		ctx, docFilter, update,
+		&options.UpdateOptions{
+			ArrayFilters: &options.ArrayFilters{Filters: arrayFilters},
+		},
+	)
+	if err != nil {
+		return newSystemError(err)
+	}
+
+	if r.MatchedCount == 0 {
+		return errNoDBRecord
 	}
+	return nil
 }
@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func (this *client) SetEmployeePolicy(linkID, policy string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfCLA(linkID), bson.M{fieldEmployeePolicy: policy},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) valueOfAllowedEmployee(v *dbmodels.AllowedEmployee) (string, dbmodels.IDBError) {
+	if v.Type == dbmodels.AllowedByEmail {
+		return this.encrypt.encryptStr(v.Value)
+	}
+	return v.Value, nil
+}
+
+func (this *client) AddEmployeesToAllowList(linkID, corpEmail string, v []dbmodels.AllowedEmployeeInfo) dbmodels.IDBError {
+	corpID := genCorpID(corpEmail)
+
+	items := make(bson.A, 0, len(v))
+	for i := range v {
+		item := &v[i]
+
+		value, err := this.valueOfAllowedEmployee(&item.AllowedEmployee)
+		if err != nil {
+			return err
+		}
+
+		doc, err := structToMap(dAllowedEmployee{
+			CorpID:  corpID,
+			Type:    item.Type,
+			Value:   value,
+			AddedBy: item.AddedBy,
+			AddedAt: item.AddedAt,
+		})
+		if err != nil {
+			return err
+		}
+		items = append(items, doc)
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.pushArrayElems(
+			ctx, this.corpSigningCollection, fieldAllowList,
+			docFilterOfSigning(linkID), items,
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) RemoveEmployeeFromAllowList(linkID, corpEmail string, v *dbmodels.AllowedEmployee) dbmodels.IDBError {
+	value, err := this.valueOfAllowedEmployee(v)
+	if err != nil {
+		return err
+	}
+
+	elemFilter := bson.M{
+		fieldCorpID: genCorpID(corpEmail),
+		"type":      v.Type,
+		"value":     value,
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.pullArrayElem(
+			ctx, this.corpSigningCollection, fieldAllowList,
+			docFilterOfSigning(linkID), elemFilter,
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) ListAllowedEmployees(linkID, corpEmail string) ([]dbmodels.AllowedEmployeeInfo, dbmodels.IDBError) {
+	var v []cCorpSigning
+
+	f := func(ctx context.Context) error {
+		return this.getArrayElem(
+			ctx, this.corpSigningCollection, fieldAllowList,
+			docFilterOfSigning(linkID), filterOfCorpID(corpEmail),
+			bson.M{fieldAllowList: 1}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	if len(v) == 0 {
+		return nil, errNoDBRecord
+	}
+
+	items := v[0].AllowList
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	r := make([]dbmodels.AllowedEmployeeInfo, 0, len(items))
+	for i := range items {
+		item := &items[i]
+
+		value := item.Value
+		if item.Type == dbmodels.AllowedByEmail {
+			s, err := this.encrypt.decryptStr(value)
+			if err != nil {
+				return nil, err
+			}
+			value = s
+		}
+
+		r = append(r, dbmodels.AllowedEmployeeInfo{
+			AllowedEmployee: dbmodels.AllowedEmployee{
+				Type:  item.Type,
+				Value: value,
+			},
+			AddedBy: item.AddedBy,
+			AddedAt: item.AddedAt,
+		})
+	}
+	return r, nil
+}
@@ -0,0 +1,202 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+// ListPendingEmployeeSignings lists the employee signings waiting for the activation
+// of the link, or of all the links if the link id is empty. The disabled signing
+// which was signed before the approval is tracked is pending since its signing date.
+func (this *client) ListPendingEmployeeSignings(linkID string) ([]dbmodels.PendingEmployeeSigning, dbmodels.IDBError) {
+	key := fmt.Sprintf("%s.%s", fieldApproval, fieldPendingSince)
+
+	docFilter := bson.M{
+		fieldLinkStatus: linkStatusReady,
+		fieldSignings: bson.M{"$elemMatch": bson.M{
+			fieldEnabled: false,
+			"$or": bson.A{
+				bson.M{key: bson.M{"$gt": 0}},
+				bson.M{fieldApproval: bson.M{"$exists": false}},
+			},
+		}},
+	}
+	if linkID != "" {
+		docFilter[fieldLinkID] = linkID
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": docFilter},
+		bson.M{"$project": bson.M{
+			fieldLinkID: 1,
+			fieldSignings: bson.M{"$filter": bson.M{
+				"input": "$" + fieldSignings,
+				"cond": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$$this." + fieldEnabled, false}},
+					bson.M{"$or": bson.A{
+						bson.M{"$gt": bson.A{"$$this." + key, 0}},
+						bson.M{"$eq": bson.A{bson.M{"$type": "$$this." + fieldApproval}, "missing"}},
+					}},
+				}},
+			}},
+		}},
+	}
+
+	var v []cIndividualSigning
+	f := func(ctx context.Context) error {
+		cursor, err := this.collection(this.individualSigningCollection).Aggregate(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+
+		return cursor.All(ctx, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	var r []dbmodels.PendingEmployeeSigning
+	for i := range v {
+		for j := range v[i].Signings {
+			item := &v[i].Signings[j]
+			if item.Enabled {
+				continue
+			}
+
+			approval := item.Approval
+			if approval == nil {
+				t, err := time.ParseInLocation("2006-01-02", item.Date, time.Local)
+				if err != nil {
+					continue
+				}
+				approval = &dEmployeeApproval{PendingSince: t.Unix()}
+			}
+
+			email, err := this.encrypt.decryptStr(item.Email)
+			if err != nil {
+				return nil, err
+			}
+
+			r = append(r, dbmodels.PendingEmployeeSigning{
+				LinkID: v[i].LinkID,
+				IndividualSigningBasicInfo: dbmodels.IndividualSigningBasicInfo{
+					SigningID: item.SigningID,
+					ID:        item.ID,
+					Email:     email,
+					Name:      item.Name,
+					Date:      item.Date,
+				},
+				EmployeeApprovalState: dbmodels.EmployeeApprovalState{
+					PendingSince: approval.PendingSince,
+					Reminders:    approval.Reminders,
+					RemindedAt:   approval.RemindedAt,
+					EscalatedAt:  approval.EscalatedAt,
+				},
+			})
+		}
+	}
+
+	return r, nil
+}
+
+// UpdateEmployeeApprovalState changes the approval state of the pending employee
+// signing from old to v. It returns false if the state is not old any more, such as
+// another worker has changed it or the signing is not pending. The state of the
+// signing signed before the approval is tracked is saved at the first time.
+func (this *client) UpdateEmployeeApprovalState(linkID, email string, old, v *dbmodels.EmployeeApprovalState) (bool, dbmodels.IDBError) {
+	elemFilter, err := this.elemFilterOfIndividualSigning(email)
+	if err != nil {
+		return false, err
+	}
+	elemFilter[fieldEnabled] = false
+
+	doc, err := structToMap(dEmployeeApproval{
+		PendingSince: v.PendingSince,
+		Reminders:    v.Reminders,
+		RemindedAt:   v.RemindedAt,
+		EscalatedAt:  v.EscalatedAt,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	arrayFilter := bson.M{}
+	for k, v := range elemFilter {
+		arrayFilter["i."+k] = v
+	}
+
+	prev := func(k string) string {
+		return fmt.Sprintf("i.%s.%s", fieldApproval, k)
+	}
+	arrayFilter["$or"] = bson.A{
+		bson.M{"i." + fieldApproval: bson.M{"$exists": false}},
+		bson.M{
+			prev(fieldPendingSince):  old.PendingSince,
+			prev(fieldRemindedTimes): old.Reminders,
+			prev(fieldRemindedAt):    old.RemindedAt,
+			prev(fieldEscalatedAt):   old.EscalatedAt,
+		},
+	}
+
+	changed := false
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.individualSigningCollection)
+
+		r, err := col.UpdateOne(
+			ctx, docFilter,
+			bson.M{"$set": bson.M{
+				fmt.Sprintf("%s.$[i].%s", fieldSignings, fieldApproval): doc,
+			}},
+			&options.UpdateOptions{
+				ArrayFilters: &options.ArrayFilters{
+					Filters: bson.A{arrayFilter},
+				},
+			},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		changed = r.ModifiedCount > 0
+		return nil
+	}
+
+	err = withContext1(f)
+	return changed, err
+}
+
+// ResolveEmployeeApproval enables or disables the employee signing which is waiting
+// for the activation. It fails if the signing is not pending any more, such as it
+// has been enabled or disabled by the managers, so it can be resolved only once.
+func (this *client) ResolveEmployeeApproval(linkID, email, signingID string, enabled bool) dbmodels.IDBError {
+	elemFilter, err := this.elemFilterOfIndividualSigning(email)
+	if err != nil {
+		return err
+	}
+	elemFilter[fieldSigningID] = signingID
+	elemFilter[fieldEnabled] = false
+	elemFilter[fieldApproval] = bson.M{"$ne": nil}
+
+	docFilter := docFilterOfSigning(linkID)
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateArrayElem(
+			ctx, this.individualSigningCollection, fieldSignings, docFilter,
+			elemFilter, bson.M{fieldEnabled: enabled, fieldApproval: nil, fieldByAllowList: false},
+		)
+	}
+
+	return withContext1(f)
+}
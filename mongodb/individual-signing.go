@@ -6,6 +6,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
 )
 
 func (c *client) elemFilterOfIndividualSigning(email string) (bson.M, dbmodels.IDBError) {
@@ -41,11 +42,20 @@ func (this *client) SignIndividualCLA(linkID string, info *dbmodels.IndividualSi
 	signing := dIndividualSigning{
 		CLALanguage: info.CLALanguage,
 		CorpID:      genCorpID(info.Email),
+		SigningID:   info.SigningID,
 		ID:          info.ID,
 		Name:        info.Name,
 		Email:       email,
 		Date:        info.Date,
 		Enabled:     info.Enabled,
+		ByAllowList: info.ActivatedByAllowList,
+
+		PrivacyVersion: info.PrivacyPolicyVersion,
+		PrivacyHash:    info.PrivacyPolicyHash,
+	}
+	if !info.Enabled {
+		// the employee signing waits for the activation of managers
+		signing.Approval = &dEmployeeApproval{PendingSince: util.Now()}
 	}
 	doc, err := structToMap(signing)
 	if err != nil {
@@ -95,7 +105,7 @@ func (this *client) UpdateIndividualSigning(linkID, email string, enabled bool)
 	f := func(ctx context.Context) dbmodels.IDBError {
 		return this.updateArrayElem(
 			ctx, this.individualSigningCollection, fieldSignings, docFilter,
-			elemFilter, bson.M{fieldEnabled: enabled},
+			elemFilter, bson.M{fieldEnabled: enabled, fieldApproval: nil, fieldByAllowList: false},
 		)
 	}
 
@@ -139,11 +149,14 @@ func (this *client) ListIndividualSigning(linkID, corpEmail, claLang string) ([]
 	}
 
 	project := bson.M{
-		memberNameOfSignings(fieldID):      1,
-		memberNameOfSignings(fieldEmail):   1,
-		memberNameOfSignings(fieldName):    1,
-		memberNameOfSignings(fieldEnabled): 1,
-		memberNameOfSignings(fieldDate):    1,
+		memberNameOfSignings(fieldSigningID): 1,
+		memberNameOfSignings(fieldID):        1,
+		memberNameOfSignings(fieldEmail):     1,
+		memberNameOfSignings(fieldName):      1,
+		memberNameOfSignings(fieldEnabled):   1,
+		memberNameOfSignings(fieldDate):      1,
+
+		memberNameOfSignings(fieldByAllowList): 1,
 	}
 
 	var v []cIndividualSigning
@@ -172,13 +185,70 @@ func (this *client) ListIndividualSigning(linkID, corpEmail, claLang string) ([]
 		}
 
 		r = append(r, dbmodels.IndividualSigningBasicInfo{
-			ID:      item.ID,
-			Email:   email,
-			Name:    item.Name,
-			Enabled: item.Enabled,
-			Date:    item.Date,
+			SigningID: item.SigningID,
+			ID:        item.ID,
+			Email:     email,
+			Name:      item.Name,
+			Enabled:   item.Enabled,
+			Date:      item.Date,
+
+			ActivatedByAllowList: item.ByAllowList,
 		})
 	}
 
 	return r, nil
 }
+
+func (this *client) GetIndividualSigning(linkID, email string) (*dbmodels.IndividualSigningInfo, dbmodels.IDBError) {
+	elemFilter, err := this.elemFilterOfIndividualSigning(email)
+	if err != nil {
+		return nil, err
+	}
+
+	project := bson.M{
+		fieldSignings: 1,
+	}
+
+	var v []cIndividualSigning
+	f := func(ctx context.Context) error {
+		return this.getArrayElem(
+			ctx, this.individualSigningCollection, fieldSignings,
+			docFilterOfSigning(linkID), elemFilter, project, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	if len(v) == 0 {
+		return nil, errNoDBRecord
+	}
+
+	docs := v[0].Signings
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	item := &docs[0]
+
+	si, err := this.encrypt.decryptSigningInfo(item.SigningInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbmodels.IndividualSigningInfo{
+		IndividualSigningBasicInfo: dbmodels.IndividualSigningBasicInfo{
+			SigningID: item.SigningID,
+			ID:        item.ID,
+			Email:     email,
+			Name:      item.Name,
+			Enabled:   item.Enabled,
+			Date:      item.Date,
+		},
+		CLALanguage: item.CLALanguage,
+		Info:        *si,
+		PrivacyConsent: dbmodels.PrivacyConsent{
+			PrivacyPolicyVersion: item.PrivacyVersion,
+			PrivacyPolicyHash:    item.PrivacyHash,
+		},
+	}, nil
+}
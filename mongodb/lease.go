@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+const fieldHolder = "holder"
+
+// AcquireLease makes sure only one of the instances holds the lease at any time.
+// The lease can be taken by the others after it is expired.
+func (this *client) AcquireLease(name, holder string, expiry int64) (bool, dbmodels.IDBError) {
+	acquired := false
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.leaseCollection)
+
+		// the name is the _id, so there is only one lease of it.
+		upsert := true
+		_, err := col.UpdateOne(
+			ctx, bson.M{"_id": name},
+			bson.M{"$setOnInsert": bson.M{fieldHolder: "", fieldExpiry: int64(0)}},
+			&options.UpdateOptions{Upsert: &upsert},
+		)
+		// the other instance inserts it at the same time.
+		if err != nil && !isDuplicateKeyError(err) {
+			return newSystemError(err)
+		}
+
+		r, err := col.UpdateOne(
+			ctx,
+			bson.M{
+				"_id": name,
+				"$or": bson.A{
+					bson.M{fieldHolder: holder},
+					bson.M{fieldExpiry: bson.M{"$lt": util.Now()}},
+				},
+			},
+			bson.M{"$set": bson.M{fieldHolder: holder, fieldExpiry: expiry}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		acquired = r.MatchedCount > 0
+		return nil
+	}
+
+	err := withContext1(f)
+	return acquired, err
+}
+
+func isDuplicateKeyError(err error) bool {
+	we, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+
+	for _, item := range we.WriteErrors {
+		if item.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func docFilterOfArchivedLink(linkID string) bson.M {
+	return bson.M{
+		fieldLinkID:     linkID,
+		fieldLinkStatus: linkStatusDeleted,
+	}
+}
+
+func (this *client) SuspendLink(linkID string, v *dbmodels.LinkSuspension) dbmodels.IDBError {
+	doc, err := structToMap(dLinkSuspension{
+		Reason:      v.Reason,
+		CheckResult: v.CheckResult,
+		Operator:    v.Operator,
+		SuspendedAt: v.SuspendedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfCLA(linkID), bson.M{fieldSuspension: doc},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) ResumeLink(linkID string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		r, err := this.collection(this.linkCollection).UpdateOne(
+			ctx, docFilterOfCLA(linkID), bson.M{"$unset": bson.M{fieldSuspension: ""}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+// RestoreLink restores the archived link and its signings. The signing docs
+// are restored first, so it can be retried if it fails halfway.
+func (this *client) RestoreLink(linkID string) dbmodels.IDBError {
+	update := bson.M{
+		"$set":   bson.M{fieldLinkStatus: linkStatusReady},
+		"$unset": bson.M{fieldUnlinkedAt: ""},
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		for _, item := range []string{this.corpSigningCollection, this.individualSigningCollection} {
+			_, err := this.collection(item).UpdateOne(ctx, docFilterOfArchivedLink(linkID), update)
+			if err != nil {
+				return newSystemError(err)
+			}
+		}
+
+		r, err := this.collection(this.linkCollection).UpdateOne(
+			ctx, docFilterOfArchivedLink(linkID), update,
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetArchivedLink(linkID string) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	return this.getLink(docFilterOfArchivedLink(linkID))
+}
+
+func (this *client) ListArchivedLinks(opt *dbmodels.LinkListOption) ([]dbmodels.LinkInfo, dbmodels.IDBError) {
+	filter := bson.M{
+		fieldPlatform:   opt.Platform,
+		fieldOrg:        bson.M{"$in": opt.Orgs},
+		fieldLinkStatus: linkStatusDeleted,
+	}
+
+	return this.getAllLinks(filter, projectOfLinkInfo())
+}
+
+// ExportLink exports the link and its signings whatever the status of link is.
+func (this *client) ExportLink(linkID string) (*dbmodels.LinkExport, dbmodels.IDBError) {
+	docFilter := bson.M{fieldLinkID: linkID}
+
+	var link cLink
+	var individual cIndividualSigning
+	var corp cCorpSigning
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		err := this.getDoc(ctx, this.linkCollection, docFilter, projectOfLinkInfo(), &link)
+		if err != nil {
+			return err
+		}
+
+		err = this.getDoc(
+			ctx, this.individualSigningCollection, docFilter,
+			bson.M{fieldSignings: 1}, &individual,
+		)
+		if err != nil && !err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return err
+		}
+
+		err = this.getDoc(
+			ctx, this.corpSigningCollection, docFilter,
+			bson.M{fieldSignings: 1}, &corp,
+		)
+		if err != nil && !err.IsErrorOf(dbmodels.ErrNoDBRecord) {
+			return err
+		}
+		return nil
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	r := &dbmodels.LinkExport{LinkInfo: toModelOfLinkInfo(&link)}
+
+	for i := range individual.Signings {
+		item := &individual.Signings[i]
+
+		email, err := this.encrypt.decryptStr(item.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		r.IndividualSignings = append(r.IndividualSignings, dbmodels.IndividualSigningBasicInfo{
+			SigningID: item.SigningID,
+			ID:        item.ID,
+			Email:     email,
+			Name:      item.Name,
+			Date:      item.Date,
+			Enabled:   item.Enabled,
+		})
+	}
+
+	for i := range corp.Signings {
+		bi, err := this.toDBModelCorporationSigningBasicInfo(&corp.Signings[i])
+		if err != nil {
+			return nil, err
+		}
+		r.CorpSignings = append(r.CorpSignings, *bi)
+	}
+
+	return r, nil
+}
+
+func toModelOfLinkSuspension(v *dLinkSuspension) *dbmodels.LinkSuspension {
+	return &dbmodels.LinkSuspension{
+		Reason:      v.Reason,
+		CheckResult: v.CheckResult,
+		Operator:    v.Operator,
+		SuspendedAt: v.SuspendedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func docFilterOfOrgLinks(platform, org string) bson.M {
+	return bson.M{
+		fieldPlatform:   platform,
+		fieldOrg:        org,
+		fieldLinkStatus: linkStatusReady,
+	}
+}
+
+// GetLinkOfRepo returns the link which is bound to the repo explicitly.
+// It returns the org-level link if the repo of orgRepo is empty.
+func (this *client) GetLinkOfRepo(orgRepo *dbmodels.OrgRepo) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	if orgRepo.RepoID == "" {
+		return this.getLink(docFilterOfLink(orgRepo))
+	}
+
+	filter := docFilterOfOrgLinks(orgRepo.Platform, orgRepo.OrgID)
+	filter["$or"] = bson.A{
+		bson.M{fieldRepo: orgRepo.RepoID},
+		bson.M{fieldRepos: orgRepo.RepoID},
+	}
+
+	v, err := this.getAllLinks(filter, projectOfLinkInfo())
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, errNoDBRecord
+	}
+
+	// the link of repo takes precedence over the one including the repo.
+	// The order of links is not stable, so the smallest link id is chosen
+	// if there are more than one links including the repo.
+	r := -1
+	for i := range v {
+		if v[i].RepoID == orgRepo.RepoID {
+			return &v[i], nil
+		}
+
+		if r < 0 || v[i].LinkID < v[r].LinkID {
+			r = i
+		}
+	}
+	return &v[r], nil
+}
+
+// ResolveLink returns the link which is effective for the repo.
+// The link of repo is preferred, then the link of org which doesn't exclude the repo.
+func (this *client) ResolveLink(orgRepo *dbmodels.OrgRepo) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	v, err := this.GetLinkOfRepo(orgRepo)
+	if err == nil || !err.IsErrorOf(dbmodels.ErrNoDBRecord) || orgRepo.RepoID == "" {
+		return v, err
+	}
+
+	filter := docFilterOfOrgLinks(orgRepo.Platform, orgRepo.OrgID)
+	filter[fieldRepo] = ""
+	filter[fieldExcludedRepos] = bson.M{"$ne": orgRepo.RepoID}
+
+	return this.getLink(filter)
+}
+
+// MoveRepoToLink removes the repo from the other links of org and makes
+// the link cover it. The signings of all the links are kept.
+// orgRepo is the one of link, its repo is empty if the link is for org.
+// It is done in a transaction, so the repo is never covered by two links
+// or by none of them.
+func (this *client) MoveRepoToLink(linkID string, orgRepo *dbmodels.OrgRepo, repo string) dbmodels.IDBError {
+	f := func(ctx mongo.SessionContext) error {
+		col := this.collection(this.linkCollection)
+
+		filter := docFilterOfOrgLinks(orgRepo.Platform, orgRepo.OrgID)
+		filter[fieldLinkID] = bson.M{"$ne": linkID}
+		_, err := col.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{fieldRepos: repo}})
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		filter = docFilterOfOrgLinks(orgRepo.Platform, orgRepo.OrgID)
+		filter[fieldLinkID] = linkID
+
+		update := bson.M{"$pull": bson.M{fieldExcludedRepos: repo}}
+		if orgRepo.RepoID != "" {
+			update = bson.M{"$addToSet": bson.M{fieldRepos: repo}}
+		}
+
+		r, err := col.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return this.doTransaction1(f)
+}
+
+func (this *client) RemoveRepoFromLink(linkID, repo string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.linkCollection)
+
+		r, err := col.UpdateOne(
+			ctx, docFilterOfCLA(linkID), bson.M{"$pull": bson.M{fieldRepos: repo}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) SetExcludedRepos(linkID string, repos []string) dbmodels.IDBError {
+	docFilter := docFilterOfCLA(linkID)
+	docFilter[fieldRepo] = ""
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilter, bson.M{fieldExcludedRepos: repos},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) getLink(filter bson.M) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	var v cLink
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(ctx, this.linkCollection, filter, projectOfLinkInfo(), &v)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	r := toModelOfLinkInfo(&v)
+	return &r, nil
+}
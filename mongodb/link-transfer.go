@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func (this *client) GetLink(linkID string) (*dbmodels.LinkInfo, dbmodels.IDBError) {
+	return this.getLink(docFilterOfCLA(linkID))
+}
+
+// TransferLink moves the link from one org or repo to another one. The repos
+// covered or excluded by the link are cleared if the repo is changed, since
+// they are the repos of the original org. The org identity saved in the other
+// collections is rewritten in the same transaction.
+func (this *client) TransferLink(linkID string, from, to *dbmodels.OrgRepo, record *dbmodels.LinkTransfer) dbmodels.IDBError {
+	doc, err := structToMap(dLinkTransfer{
+		From:          record.From,
+		To:            record.To,
+		Operator:      record.Operator,
+		TransferredAt: record.TransferredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	docFilter := docFilterOfLink(from)
+	docFilter[fieldLinkID] = linkID
+
+	update := bson.M{
+		"$set": bson.M{
+			fieldPlatform: to.Platform,
+			fieldOrg:      to.OrgID,
+			fieldRepo:     to.RepoID,
+		},
+		"$push": bson.M{fieldTransfers: doc},
+	}
+	if from.RepoID != to.RepoID {
+		update["$unset"] = bson.M{
+			fieldRepos:         "",
+			fieldExcludedRepos: "",
+		}
+	}
+
+	f := func(ctx mongo.SessionContext) error {
+		r, err := this.collection(this.linkCollection).UpdateOne(ctx, docFilter, update)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if r.MatchedCount == 0 {
+			return errNoDBRecord
+		}
+
+		// the org identity of corp signing is used when the corp manager logins.
+		_, err = this.collection(this.corpSigningCollection).UpdateMany(
+			ctx, bson.M{fieldLinkID: linkID},
+			bson.M{"$set": bson.M{fieldOrgIdentity: to.OrgRepoID()}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return this.doTransaction1(f)
+}
+
+func toModelOfLinkTransfers(v []dLinkTransfer) []dbmodels.LinkTransfer {
+	if len(v) == 0 {
+		return nil
+	}
+
+	r := make([]dbmodels.LinkTransfer, 0, len(v))
+	for i := range v {
+		item := &v[i]
+		r = append(r, dbmodels.LinkTransfer{
+			From:          item.From,
+			To:            item.To,
+			Operator:      item.Operator,
+			TransferredAt: item.TransferredAt,
+		})
+	}
+	return r
+}
@@ -7,6 +7,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
 )
 
 func docFilterOfLink(orgRepo *dbmodels.OrgRepo) bson.M {
@@ -18,21 +19,6 @@ func docFilterOfLink(orgRepo *dbmodels.OrgRepo) bson.M {
 	}
 }
 
-func (this *client) GetLinkID(orgRepo *dbmodels.OrgRepo) (string, dbmodels.IDBError) {
-	var v cLink
-	f := func(ctx context.Context) dbmodels.IDBError {
-		return this.getDoc(
-			ctx, this.linkCollection, docFilterOfLink(orgRepo), bson.M{fieldLinkID: 1}, &v,
-		)
-	}
-
-	if err := withContext1(f); err != nil {
-		return "", err
-	}
-
-	return v.LinkID, nil
-}
-
 func (this *client) CreateLink(info *dbmodels.LinkCreateOption) (string, dbmodels.IDBError) {
 	doc, err := toDocOfLink(info)
 	if err != nil {
@@ -58,7 +44,10 @@ func (this *client) CreateLink(info *dbmodels.LinkCreateOption) (string, dbmodel
 }
 
 func (this *client) Unlink(linkID string) dbmodels.IDBError {
-	status := bson.M{fieldLinkStatus: linkStatusDeleted}
+	status := bson.M{
+		fieldLinkStatus: linkStatusDeleted,
+		fieldUnlinkedAt: util.Now(),
+	}
 	docFilter := bson.M{fieldLinkID: linkID}
 
 	f := func(ctx context.Context) dbmodels.IDBError {
@@ -87,6 +76,7 @@ func (this *client) GetOrgOfLink(linkID string) (*dbmodels.OrgInfo, dbmodels.IDB
 			bson.M{
 				fieldIndividualCLAs: 0,
 				fieldCorpCLAs:       0,
+				fieldPDFLayout:      0,
 				fmt.Sprintf("%s.%s", fieldOrgEmail, fieldToken): 0,
 			}, &v,
 		)
@@ -107,24 +97,29 @@ func (this *client) ListLinks(opt *dbmodels.LinkListOption) ([]dbmodels.LinkInfo
 		fieldLinkStatus: linkStatusReady,
 	}
 
-	project := bson.M{
-		fieldIndividualCLAs: 0,
-		fieldCorpCLAs:       0,
-		fmt.Sprintf("%s.%s", fieldOrgEmail, fieldToken): 0,
-	}
-
-	return this.getAllLinks(filter, project)
+	return this.getAllLinks(filter, projectOfLinkInfo())
 }
 
 func (this *client) GetAllLinks() ([]dbmodels.LinkInfo, dbmodels.IDBError) {
 	project := bson.M{
 		fieldIndividualCLAs: 0,
 		fieldCorpCLAs:       0,
+		fieldPDFLayout:      0,
 		fieldOrgEmail:       0,
 	}
 	return this.getAllLinks(bson.M{fieldLinkStatus: linkStatusReady}, project)
 }
 
+// ListLinksOfCLATemplate lists the links which refer to the cla template.
+func (this *client) ListLinksOfCLATemplate(id string) ([]dbmodels.LinkInfo, dbmodels.IDBError) {
+	filter := bson.M{
+		fieldTemplateID: id,
+		fieldLinkStatus: linkStatusReady,
+	}
+
+	return this.getAllLinks(filter, projectOfLinkInfo())
+}
+
 func (this *client) getAllLinks(filter, project bson.M) ([]dbmodels.LinkInfo, dbmodels.IDBError) {
 	var v []cLink
 	f := func(ctx context.Context) error {
@@ -142,17 +137,57 @@ func (this *client) getAllLinks(filter, project bson.M) ([]dbmodels.LinkInfo, db
 
 	r := make([]dbmodels.LinkInfo, 0, n)
 	for i := range v {
-		item := &v[i]
-		r = append(r, dbmodels.LinkInfo{
-			LinkID:    item.LinkID,
-			OrgInfo:   toModelOfOrgInfo(item),
-			Submitter: item.Submitter,
-		})
+		r = append(r, toModelOfLinkInfo(&v[i]))
 	}
 
 	return r, nil
 }
 
+func projectOfLinkInfo() bson.M {
+	return bson.M{
+		fieldIndividualCLAs: 0,
+		fieldCorpCLAs:       0,
+		fieldPDFLayout:      0,
+		fmt.Sprintf("%s.%s", fieldOrgEmail, fieldToken): 0,
+	}
+}
+
+func toModelOfLinkInfo(doc *cLink) dbmodels.LinkInfo {
+	r := dbmodels.LinkInfo{
+		LinkID:            doc.LinkID,
+		OrgInfo:           toModelOfOrgInfo(doc),
+		Submitter:         doc.Submitter,
+		Mode:              doc.Mode,
+		EmployeePolicy:    doc.EmployeePolicy,
+		AmendmentApproval: doc.AmendmentApproval,
+		Repos:             doc.Repos,
+		ExcludedRepos:     doc.ExcludedRepos,
+		Transfers:         toModelOfLinkTransfers(doc.Transfers),
+		TemplateID:        doc.TemplateID,
+	}
+
+	if v := doc.CorpSigningValidity; v != nil {
+		r.CorpSigningValidity = &dbmodels.CorpSigningValidity{
+			ValidityDays:     v.ValidityDays,
+			GraceDays:        v.GraceDays,
+			RemindBeforeDays: v.RemindBeforeDays,
+		}
+	}
+
+	switch {
+	case doc.LinkStatus == linkStatusDeleted:
+		r.Status = dbmodels.LinkStatusArchived
+		r.ArchivedAt = doc.UnlinkedAt
+	case doc.Suspension != nil:
+		r.Status = dbmodels.LinkStatusSuspended
+		r.Suspension = toModelOfLinkSuspension(doc.Suspension)
+	default:
+		r.Status = dbmodels.LinkStatusActive
+	}
+
+	return r
+}
+
 func toDocOfLink(info *dbmodels.LinkCreateOption) (bson.M, dbmodels.IDBError) {
 	opt := cLink{
 		LinkID:     info.LinkID,
@@ -161,7 +196,9 @@ func toDocOfLink(info *dbmodels.LinkCreateOption) (bson.M, dbmodels.IDBError) {
 		Repo:       info.RepoID,
 		OrgAlias:   info.OrgAlias,
 		Submitter:  info.Submitter,
+		Mode:       info.Mode,
 		LinkStatus: linkStatusReady,
+		TemplateID: info.TemplateID,
 	}
 	body, err := structToMap(opt)
 	if err != nil {
@@ -174,6 +211,15 @@ func toDocOfLink(info *dbmodels.LinkCreateOption) (bson.M, dbmodels.IDBError) {
 	}
 	body[fieldOrgEmail] = orgEmail
 
+	if err := toDocOfCLAs(body, info.IndividualCLAs, info.CorpCLAs); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// toDocOfCLAs sets the clas to the doc of link or cla template.
+func toDocOfCLAs(body bson.M, individualCLAs, corpCLAs []dbmodels.CLACreateOption) dbmodels.IDBError {
 	convertCLAs := func(field string, v []dbmodels.CLACreateOption) dbmodels.IDBError {
 		clas := make(bson.A, 0, len(v))
 		for i := range v {
@@ -188,19 +234,19 @@ func toDocOfLink(info *dbmodels.LinkCreateOption) (bson.M, dbmodels.IDBError) {
 		return nil
 	}
 
-	if len(info.IndividualCLAs) > 0 {
-		if err := convertCLAs(fieldIndividualCLAs, info.IndividualCLAs); err != nil {
-			return nil, err
+	if len(individualCLAs) > 0 {
+		if err := convertCLAs(fieldIndividualCLAs, individualCLAs); err != nil {
+			return err
 		}
 	}
 
-	if len(info.CorpCLAs) > 0 {
-		if err := convertCLAs(fieldCorpCLAs, info.CorpCLAs); err != nil {
-			return nil, err
+	if len(corpCLAs) > 0 {
+		if err := convertCLAs(fieldCorpCLAs, corpCLAs); err != nil {
+			return err
 		}
 	}
 
-	return body, nil
+	return nil
 }
 
 func toDocOfCLA(cla *dbmodels.CLACreateOption) (bson.M, dbmodels.IDBError) {
@@ -210,6 +256,7 @@ func toDocOfCLA(cla *dbmodels.CLACreateOption) (bson.M, dbmodels.IDBError) {
 		DCLAInfo: DCLAInfo{
 			Fields:           toDocOfCLAField(cla.Fields),
 			Language:         cla.Language,
+			Format:           cla.Format,
 			CLAHash:          cla.CLAHash,
 			OrgSignatureHash: cla.OrgSignatureHash,
 		},
@@ -240,6 +287,10 @@ func toDocOfCLAField(fs []dbmodels.Field) []dField {
 			Type:        item.Type,
 			Description: item.Description,
 			Required:    item.Required,
+			Options:     item.Options,
+			Pattern:     item.Pattern,
+			MinLength:   item.MinLength,
+			MaxLength:   item.MaxLength,
 		})
 	}
 	return fields
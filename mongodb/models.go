@@ -38,6 +38,52 @@ const (
 	fieldCorp           = "corp"
 	fieldEnabled        = "enabled"
 	fieldInfo           = "info"
+	fieldKind           = "kind"
+	fieldKey            = "key"
+	fieldFailures       = "failures"
+	fieldFirstFailure   = "first_failure"
+	fieldLastFailure    = "last_failure"
+	fieldLockedUntil    = "locked_until"
+	fieldNotified       = "notified"
+	fieldSubject        = "subject"
+	fieldIP             = "ip"
+	fieldLastUsed       = "last_used"
+	fieldOwner          = "owner"
+	fieldLinks          = "links"
+	fieldHash           = "hash"
+	fieldPayload        = "payload"
+	fieldClientSecret   = "client_secret"
+	fieldDeletedAt      = "deleted_at"
+	fieldUnlinkedAt     = "unlinked_at"
+	fieldPrivacyVersion = "privacy_version"
+	fieldPDFLayout      = "pdf_layout"
+	fieldLogo           = "logo"
+	fieldSigningID      = "signing_id"
+	fieldSignatories    = "signatories"
+	fieldCounterSign    = "counter_signature"
+	fieldSignatory      = "signatory"
+	fieldDigest         = "digest"
+	fieldSubmitter      = "submitter"
+	fieldTemplateID     = "template_id"
+	fieldRepos          = "repos"
+	fieldExcludedRepos  = "excluded_repos"
+	fieldTransfers      = "transfers"
+	fieldSuspension     = "suspension"
+	fieldAllowList      = "allow_list"
+	fieldEmployeePolicy = "employee_policy"
+	fieldApproval       = "approval"
+	fieldByAllowList    = "by_allow_list"
+	fieldPendingSince   = "pending_since"
+	fieldRemindedTimes  = "reminders"
+	fieldRemindedAt     = "reminded_at"
+	fieldEscalatedAt    = "escalated_at"
+	fieldValidFrom      = "valid_from"
+	fieldRenewals       = "renewals"
+	fieldReminders      = "renewal_reminders"
+	fieldValidity       = "corp_signing_validity"
+	fieldAmendments     = "amendments"
+	fieldAmendApproval  = "amendment_approval"
+	fieldStatus         = "status"
 
 	// 'ready' means the doc is ready to record the signing data currently.
 	// 'deleted' means the signing data is invalid.
@@ -58,6 +104,65 @@ type cVerificationCode struct {
 	Expiry  int64  `bson:"expiry" json:"expiry" required:"true"`
 }
 
+type cAuthFailure struct {
+	Kind         string `bson:"kind" json:"kind" required:"true"`
+	Key          string `bson:"key" json:"key" required:"true"`
+	Failures     int    `bson:"failures" json:"failures"`
+	FirstFailure int64  `bson:"first_failure" json:"first_failure"`
+	LastFailure  int64  `bson:"last_failure" json:"last_failure"`
+	LockedUntil  int64  `bson:"locked_until" json:"locked_until"`
+	Notified     bool   `bson:"notified" json:"notified"`
+}
+
+type cSession struct {
+	ID         string `bson:"id" json:"id" required:"true"`
+	Subject    string `bson:"subject" json:"subject" required:"true"`
+	Permission string `bson:"permission" json:"permission" required:"true"`
+	LinkID     string `bson:"link_id" json:"link_id,omitempty"`
+	IP         string `bson:"ip" json:"ip"`
+	CreatedAt  int64  `bson:"created_at" json:"created_at"`
+	LastUsed   int64  `bson:"last_used" json:"last_used"`
+	Expiry     int64  `bson:"expiry" json:"expiry" required:"true"`
+}
+
+type cAccessToken struct {
+	ID         string   `bson:"id" json:"id" required:"true"`
+	Name       string   `bson:"name" json:"name" required:"true"`
+	Owner      string   `bson:"owner" json:"owner" required:"true"`
+	Permission string   `bson:"permission" json:"permission" required:"true"`
+	LinkID     string   `bson:"link_id" json:"link_id,omitempty"`
+	Hash       string   `bson:"hash" json:"hash" required:"true"`
+	Links      []string `bson:"links" json:"links" required:"true"`
+	Scopes     []string `bson:"scopes" json:"scopes" required:"true"`
+	Expiry     int64    `bson:"expiry" json:"expiry"`
+	CreatedAt  int64    `bson:"created_at" json:"created_at"`
+	LastUsed   int64    `bson:"last_used" json:"last_used"`
+
+	Payload []byte `bson:"payload" json:"-"`
+}
+
+type cCorpOIDC struct {
+	LinkID    string `bson:"link_id" json:"link_id" required:"true"`
+	CorpID    string `bson:"corp_id" json:"corp_id" required:"true"`
+	Issuer    string `bson:"issuer" json:"issuer" required:"true"`
+	ClientID  string `bson:"client_id" json:"client_id" required:"true"`
+	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
+
+	ClientSecret []byte `bson:"client_secret" json:"-"`
+}
+
+type cCLATemplate struct {
+	ID        string `bson:"id" json:"id" required:"true"`
+	Name      string `bson:"name" json:"name" required:"true"`
+	Platform  string `bson:"platform" json:"platform" required:"true"`
+	Org       string `bson:"org" json:"org"`
+	Submitter string `bson:"submitter" json:"submitter" required:"true"`
+	CreatedAt int64  `bson:"created_at" json:"created_at"`
+
+	IndividualCLAs []dCLA `bson:"individual_clas" json:"-"`
+	CorpCLAs       []dCLA `bson:"corp_clas" json:"-"`
+}
+
 type cIndividualSigning struct {
 	LinkID     string `bson:"link_id" json:"link_id" required:"true"`
 	LinkStatus string `bson:"link_status" json:"link_status" required:"true"`
@@ -69,6 +174,7 @@ type cIndividualSigning struct {
 type dIndividualSigning struct {
 	CLALanguage string `bson:"lang" json:"lang" required:"true"`
 	CorpID      string `bson:"corp_id" json:"corp_id" required:"true"`
+	SigningID   string `bson:"signing_id" json:"signing_id,omitempty"`
 
 	ID      string `bson:"id" json:"id" required:"true"`
 	Name    string `bson:"name" json:"name" required:"true"`
@@ -76,9 +182,24 @@ type dIndividualSigning struct {
 	Date    string `bson:"date" json:"date" required:"true"`
 	Enabled bool   `bson:"enabled" json:"enabled"`
 
+	ByAllowList bool `bson:"by_allow_list" json:"by_allow_list,omitempty"`
+
+	PrivacyVersion string `bson:"privacy_version" json:"privacy_version,omitempty"`
+	PrivacyHash    string `bson:"privacy_hash" json:"privacy_hash,omitempty"`
+
+	// Approval is set when the employee signing is waiting for the activation.
+	Approval *dEmployeeApproval `bson:"approval" json:"approval,omitempty"`
+
 	SigningInfo []byte `bson:"info" json:"-"`
 }
 
+type dEmployeeApproval struct {
+	PendingSince int64 `bson:"pending_since" json:"pending_since" required:"true"`
+	Reminders    int   `bson:"reminders" json:"reminders"`
+	RemindedAt   int64 `bson:"reminded_at" json:"reminded_at"`
+	EscalatedAt  int64 `bson:"escalated_at" json:"escalated_at"`
+}
+
 type cCorpSigning struct {
 	LinkID     string `bson:"link_id" json:"link_id" required:"true"`
 	LinkStatus string `bson:"link_status" json:"link_status" required:"true"`
@@ -90,7 +211,18 @@ type cCorpSigning struct {
 	CLAInfos []DCLAInfo     `bson:"cla_infos" json:"cla_infos,omitempty"`
 	Signings []dCorpSigning `bson:"signings" json:"-"`
 	Managers []dCorpManager `bson:"corp_managers" json:"-"`
-	Deleted  []dCorpSigning `bson:"deleted" json:"-"`
+
+	AllowList []dAllowedEmployee `bson:"allow_list" json:"-"`
+	Deleted   []dCorpSigning     `bson:"deleted" json:"-"`
+}
+
+type dAllowedEmployee struct {
+	CorpID string `bson:"corp_id" json:"corp_id" required:"true"`
+	Type   string `bson:"type" json:"type" required:"true"`
+	// Value is encrypted if the type is email.
+	Value   string `bson:"value" json:"value" required:"true"`
+	AddedBy string `bson:"added_by" json:"added_by"`
+	AddedAt int64  `bson:"added_at" json:"added_at"`
 }
 
 type dCorpSigning struct {
@@ -102,9 +234,52 @@ type dCorpSigning struct {
 	AdminName  string `bson:"name" json:"name" required:"true"`
 	Date       string `bson:"date" json:"date" required:"true"`
 
+	PrivacyVersion string `bson:"privacy_version" json:"privacy_version,omitempty"`
+	PrivacyHash    string `bson:"privacy_hash" json:"privacy_hash,omitempty"`
+
+	// it is set when the signing is moved to the deleted list.
+	DeletedAt int64 `bson:"deleted_at" json:"deleted_at,omitempty"`
+
+	// it is set when the community counter-signs the signing.
+	CounterSignature *dCounterSignature `bson:"counter_signature" json:"-"`
+
+	// it is the time when the signing is signed or renewed last time.
+	ValidFrom        int64                 `bson:"valid_from" json:"valid_from,omitempty"`
+	RenewalReminders int                   `bson:"renewal_reminders" json:"-"`
+	Renewals         []dCorpSigningRenewal `bson:"renewals" json:"-"`
+
+	Amendments []dCorpSigningAmendment `bson:"amendments" json:"-"`
+
 	SigningInfo []byte `bson:"info" json:"-"`
 }
 
+type dCounterSignature struct {
+	Signatory string `bson:"signatory" json:"signatory" required:"true"`
+	Name      string `bson:"name" json:"name" required:"true"`
+	Title     string `bson:"title" json:"title"`
+	Date      string `bson:"date" json:"date" required:"true"`
+	Digest    string `bson:"digest" json:"digest" required:"true"`
+}
+
+type dCorpSigningRenewal struct {
+	PreviousValidFrom int64  `bson:"previous_valid_from" json:"previous_valid_from"`
+	RenewedAt         int64  `bson:"renewed_at" json:"renewed_at" required:"true"`
+	Operator          string `bson:"operator" json:"operator" required:"true"`
+}
+
+// dCorpSigningAmendment is the amendment of contact information. The values are encrypted.
+type dCorpSigningAmendment struct {
+	ID          string `bson:"id" json:"id" required:"true"`
+	Status      string `bson:"status" json:"status" required:"true"`
+	Changes     []byte `bson:"changes" json:"-"`
+	Previous    []byte `bson:"previous" json:"-"`
+	SubmittedBy string `bson:"submitted_by" json:"submitted_by" required:"true"`
+	SubmittedAt int64  `bson:"submitted_at" json:"submitted_at"`
+	ReviewedBy  string `bson:"reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt  int64  `bson:"reviewed_at" json:"reviewed_at,omitempty"`
+	Reason      string `bson:"reason" json:"reason,omitempty"`
+}
+
 type dCorpManager struct {
 	ID               string `bson:"id" json:"id" required:"true"`
 	Name             string `bson:"name" json:"name" required:"true"`
@@ -126,6 +301,7 @@ type DCLAInfo struct {
 	Language         string   `bson:"lang" json:"lang" required:"true"`
 	CLAHash          string   `bson:"cla_hash" json:"cla_hash" required:"true"`
 	OrgSignatureHash string   `bson:"signature_hash" json:"signature_hash,omitempty"`
+	Format           string   `bson:"format" json:"format,omitempty"`
 }
 
 type cLink struct {
@@ -137,11 +313,64 @@ type cLink struct {
 	Repo      string `bson:"repo" json:"repo"`
 	OrgAlias  string `bson:"org_alias" json:"org_alias"`
 	Submitter string `bson:"submitter" json:"submitter" required:"true"`
+	Mode      string `bson:"mode" json:"mode"`
+
+	EmployeePolicy string `bson:"employee_policy" json:"employee_policy,omitempty"`
+
+	CorpSigningValidity *dCorpSigningValidity `bson:"corp_signing_validity" json:"-"`
+	AmendmentApproval   bool                  `bson:"amendment_approval" json:"amendment_approval,omitempty"`
+
+	// Repos is the other repos of org which the link of repo covers.
+	// ExcludedRepos is the repos which the link of org doesn't cover.
+	Repos         []string `bson:"repos" json:"repos,omitempty"`
+	ExcludedRepos []string `bson:"excluded_repos" json:"excluded_repos,omitempty"`
+
+	Transfers []dLinkTransfer `bson:"transfers" json:"-"`
+
+	TemplateID string `bson:"template_id" json:"template_id,omitempty"`
+
+	UnlinkedAt int64            `bson:"unlinked_at" json:"unlinked_at,omitempty"`
+	Suspension *dLinkSuspension `bson:"suspension" json:"-"`
 
 	OrgEmail cOrgEmail `bson:"org_email" json:"-"`
 
 	IndividualCLAs []dCLA `bson:"individual_clas" json:"-"`
 	CorpCLAs       []dCLA `bson:"corp_clas" json:"-"`
+
+	PDFLayout   *dPDFLayout  `bson:"pdf_layout" json:"-"`
+	Signatories []dSignatory `bson:"signatories" json:"-"`
+}
+
+type dLinkSuspension struct {
+	Reason      string `bson:"reason" json:"reason"`
+	CheckResult bool   `bson:"check_result" json:"check_result"`
+	Operator    string `bson:"operator" json:"operator" required:"true"`
+	SuspendedAt int64  `bson:"suspended_at" json:"suspended_at"`
+}
+
+type dCorpSigningValidity struct {
+	ValidityDays     int `bson:"validity_days" json:"validity_days" required:"true"`
+	GraceDays        int `bson:"grace_days" json:"grace_days"`
+	RemindBeforeDays int `bson:"remind_before_days" json:"remind_before_days"`
+}
+
+type dLinkTransfer struct {
+	From          string `bson:"from" json:"from" required:"true"`
+	To            string `bson:"to" json:"to" required:"true"`
+	Operator      string `bson:"operator" json:"operator" required:"true"`
+	TransferredAt int64  `bson:"transferred_at" json:"transferred_at"`
+}
+
+type dSignatory struct {
+	ID    string `bson:"id" json:"id" required:"true"`
+	Name  string `bson:"name" json:"name" required:"true"`
+	Title string `bson:"title" json:"title"`
+}
+
+type dPDFLayout struct {
+	Layout    string `bson:"layout" json:"layout" required:"true"`
+	Logo      []byte `bson:"logo" json:"-"`
+	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
 }
 
 type dCLA struct {
@@ -158,6 +387,11 @@ type dField struct {
 	Type        string `bson:"type" json:"type" required:"true"`
 	Description string `bson:"desc" json:"desc,omitempty"`
 	Required    bool   `bson:"required" json:"required"`
+
+	Options   []string `bson:"options" json:"options,omitempty"`
+	Pattern   string   `bson:"pattern" json:"pattern,omitempty"`
+	MinLength int      `bson:"min_len" json:"min_len,omitempty"`
+	MaxLength int      `bson:"max_len" json:"max_len,omitempty"`
 }
 
 func memberNameOfSignings(key string) string {
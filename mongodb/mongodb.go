@@ -26,6 +26,12 @@ type client struct {
 	linkCollection              string
 	corpSigningCollection       string
 	individualSigningCollection string
+	authFailureCollection       string
+	sessionCollection           string
+	accessTokenCollection       string
+	corpOIDCCollection          string
+	claTemplateCollection       string
+	leaseCollection             string
 }
 
 func Initialize(cfg *config.MongodbConfig, encryptionKey, nonce string) (*client, error) {
@@ -62,6 +68,12 @@ func Initialize(cfg *config.MongodbConfig, encryptionKey, nonce string) (*client
 		linkCollection:              cfg.LinkCollection,
 		corpSigningCollection:       cfg.CorpSigningCollection,
 		individualSigningCollection: cfg.IndividualSigningCollection,
+		authFailureCollection:       cfg.AuthFailureCollection,
+		sessionCollection:           cfg.SessionCollection,
+		accessTokenCollection:       cfg.AccessTokenCollection,
+		corpOIDCCollection:          cfg.CorpOIDCCollection,
+		claTemplateCollection:       cfg.CLATemplateCollection,
+		leaseCollection:             cfg.LeaseCollection,
 	}
 	return cli, nil
 }
@@ -92,6 +104,20 @@ func (this *client) doTransaction(f func(mongo.SessionContext) error) error {
 	return err
 }
 
+// doTransaction1 is same as doTransaction except that it keeps
+// the IDBError returned by f.
+func (this *client) doTransaction1(f func(mongo.SessionContext) error) dbmodels.IDBError {
+	err := this.doTransaction(f)
+	if err == nil {
+		return nil
+	}
+
+	if v, ok := err.(dbmodels.IDBError); ok {
+		return v
+	}
+	return newSystemError(err)
+}
+
 func objectIDToUID(oid primitive.ObjectID) string {
 	return oid.Hex()
 }
@@ -0,0 +1,73 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func docFilterOfReadyLink(linkID string) bson.M {
+	return bson.M{
+		fieldLinkID:     linkID,
+		fieldLinkStatus: linkStatusReady,
+	}
+}
+
+func (this *client) SavePDFLayout(linkID string, layout *dbmodels.PDFLayout) dbmodels.IDBError {
+	doc, err := structToMap(dPDFLayout{
+		Layout:    layout.Layout,
+		UpdatedAt: layout.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if len(layout.Logo) > 0 {
+		doc[fieldLogo] = layout.Logo
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfReadyLink(linkID),
+			bson.M{fieldPDFLayout: doc},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetPDFLayout(linkID string) (*dbmodels.PDFLayout, dbmodels.IDBError) {
+	var v cLink
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(
+			ctx, this.linkCollection, docFilterOfReadyLink(linkID),
+			bson.M{fieldPDFLayout: 1}, &v,
+		)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	if v.PDFLayout == nil {
+		return nil, errNoDBRecord
+	}
+
+	return &dbmodels.PDFLayout{
+		Layout:    v.PDFLayout.Layout,
+		Logo:      v.PDFLayout.Logo,
+		UpdatedAt: v.PDFLayout.UpdatedAt,
+	}, nil
+}
+
+func (this *client) DeletePDFLayout(linkID string) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfReadyLink(linkID),
+			bson.M{fieldPDFLayout: nil},
+		)
+	}
+
+	return withContext1(f)
+}
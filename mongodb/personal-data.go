@@ -0,0 +1,247 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+// GetPersonalData returns the data of email on all the links whatever the status of link is.
+func (this *client) GetPersonalData(email string) (*dbmodels.PersonalData, dbmodels.IDBError) {
+	r := &dbmodels.PersonalData{}
+
+	if err := this.getIndividualSigningsOfEmail(email, r); err != nil {
+		return nil, err
+	}
+
+	if err := this.getCorpSigningsOfEmail(email, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (this *client) getIndividualSigningsOfEmail(email string, r *dbmodels.PersonalData) dbmodels.IDBError {
+	elemFilter, err := this.elemFilterOfIndividualSigning(email)
+	if err != nil {
+		return err
+	}
+
+	docFilter := bson.M{}
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	var v []cIndividualSigning
+	f := func(ctx context.Context) error {
+		return this.getArrayElem(
+			ctx, this.individualSigningCollection, fieldSignings,
+			docFilter, elemFilter, bson.M{fieldLinkID: 1, fieldSignings: 1}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return newSystemError(err)
+	}
+
+	for i := range v {
+		for j := range v[i].Signings {
+			item := &v[i].Signings[j]
+
+			info, err := this.encrypt.decryptSigningInfo(item.SigningInfo)
+			if err != nil {
+				return err
+			}
+
+			r.IndividualSignings = append(r.IndividualSignings, dbmodels.IndividualSigningOfLink{
+				LinkID: v[i].LinkID,
+				IndividualSigningInfo: dbmodels.IndividualSigningInfo{
+					IndividualSigningBasicInfo: dbmodels.IndividualSigningBasicInfo{
+						ID:      item.ID,
+						Email:   email,
+						Name:    item.Name,
+						Date:    item.Date,
+						Enabled: item.Enabled,
+					},
+					CLALanguage: item.CLALanguage,
+					Info:        *info,
+					PrivacyConsent: dbmodels.PrivacyConsent{
+						PrivacyPolicyVersion: item.PrivacyVersion,
+						PrivacyPolicyHash:    item.PrivacyHash,
+					},
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+func (this *client) getCorpSigningsOfEmail(email string, r *dbmodels.PersonalData) dbmodels.IDBError {
+	encryptedEmail, err := this.encrypt.encryptStr(email)
+	if err != nil {
+		return err
+	}
+
+	elemFilter := bson.M{fieldEmail: encryptedEmail}
+	docFilter := bson.M{
+		"$or": bson.A{
+			bson.M{fieldSignings: bson.M{"$elemMatch": elemFilter}},
+			bson.M{fieldCorpManagers: bson.M{"$elemMatch": elemFilter}},
+		},
+	}
+
+	project := bson.M{
+		fieldLinkID:                         1,
+		fieldSignings:                       1,
+		memberNameOfCorpManager(fieldID):    1,
+		memberNameOfCorpManager(fieldName):  1,
+		memberNameOfCorpManager(fieldEmail): 1,
+		memberNameOfCorpManager(fieldRole):  1,
+	}
+
+	var v []cCorpSigning
+	f := func(ctx context.Context) error {
+		return this.getMultiArrays(
+			ctx, this.corpSigningCollection, docFilter,
+			map[string]bson.M{
+				fieldSignings:     elemFilter,
+				fieldCorpManagers: elemFilter,
+			},
+			project, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return newSystemError(err)
+	}
+
+	for i := range v {
+		doc := &v[i]
+
+		for j := range doc.Signings {
+			item := &doc.Signings[j]
+
+			bi, err := this.toDBModelCorporationSigningBasicInfo(item)
+			if err != nil {
+				return err
+			}
+
+			info, err := this.encrypt.decryptSigningInfo(item.SigningInfo)
+			if err != nil {
+				return err
+			}
+
+			r.CorpSignings = append(r.CorpSignings, dbmodels.CorpSigningOfLink{
+				LinkID: doc.LinkID,
+				CorpSigningCreateOpt: dbmodels.CorpSigningCreateOpt{
+					CorporationSigningBasicInfo: *bi,
+					Info:                        *info,
+					PrivacyConsent: dbmodels.PrivacyConsent{
+						PrivacyPolicyVersion: item.PrivacyVersion,
+						PrivacyPolicyHash:    item.PrivacyHash,
+					},
+				},
+			})
+		}
+
+		for j := range doc.Managers {
+			item := &doc.Managers[j]
+
+			r.CorpManagers = append(r.CorpManagers, dbmodels.CorpManagerOfLink{
+				LinkID: doc.LinkID,
+				CorporationManagerListResult: dbmodels.CorporationManagerListResult{
+					ID:    fmt.Sprintf("%s_%s", item.ID, genCorpID(email)),
+					Name:  item.Name,
+					Email: email,
+					Role:  item.Role,
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// PseudonymiseIndividualSignings replaces the email of signer with the pseudonym and removes
+// the other personal data. The language and date of signing are kept as the proof.
+func (this *client) PseudonymiseIndividualSignings(email, pseudonym string) dbmodels.IDBError {
+	elemFilter, err := this.elemFilterOfIndividualSigning(email)
+	if err != nil {
+		return err
+	}
+
+	encryptedPseudonym, err := this.encrypt.encryptStr(pseudonym)
+	if err != nil {
+		return err
+	}
+
+	info, err := this.encrypt.encryptSigningInfo(&dbmodels.TypeSigningInfo{})
+	if err != nil {
+		return err
+	}
+
+	docFilter := bson.M{}
+	arrayFilterByElemMatch(fieldSignings, true, elemFilter, docFilter)
+
+	cmd := bson.M{}
+	for k, v := range map[string]interface{}{
+		fieldEmail: encryptedPseudonym,
+		fieldName:  "",
+		fieldID:    "",
+		fieldInfo:  info,
+	} {
+		cmd[fmt.Sprintf("%s.$[i].%s", fieldSignings, k)] = v
+	}
+
+	arrayFilter := bson.M{}
+	for k, v := range elemFilter {
+		arrayFilter["i."+k] = v
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.individualSigningCollection)
+
+		_, err := col.UpdateMany(
+			ctx, docFilter, bson.M{"$set": cmd},
+			&options.UpdateOptions{
+				ArrayFilters: &options.ArrayFilters{Filters: bson.A{arrayFilter}},
+			},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) DeleteEmployeeManagerOfEmail(email string) dbmodels.IDBError {
+	encryptedEmail, err := this.encrypt.encryptStr(email)
+	if err != nil {
+		return err
+	}
+
+	elemFilter := bson.M{
+		fieldEmail: encryptedEmail,
+		fieldRole:  dbmodels.RoleManager,
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.corpSigningCollection)
+
+		_, err := col.UpdateMany(
+			ctx, bson.M{fieldCorpManagers: bson.M{"$elemMatch": elemFilter}},
+			bson.M{"$pull": bson.M{fieldCorpManagers: elemFilter}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
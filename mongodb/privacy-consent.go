@@ -0,0 +1,101 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+const (
+	signingTypeIndividual  = "individual"
+	signingTypeCorporation = "corporation"
+)
+
+// ListOutdatedPrivacyConsents returns the signers of link who didn't accept the version of privacy policy.
+func (this *client) ListOutdatedPrivacyConsents(linkID, version string) ([]dbmodels.OutdatedPrivacyConsent, dbmodels.IDBError) {
+	var r []dbmodels.OutdatedPrivacyConsent
+
+	var individuals []cIndividualSigning
+	if err := this.getSigningsOfOutdatedPrivacy(this.individualSigningCollection, linkID, version, &individuals); err != nil {
+		return nil, err
+	}
+	for i := range individuals {
+		for j := range individuals[i].Signings {
+			item := &individuals[i].Signings[j]
+
+			email, err := this.encrypt.decryptStr(item.Email)
+			if err != nil {
+				return nil, err
+			}
+
+			r = append(r, dbmodels.OutdatedPrivacyConsent{
+				Type:  signingTypeIndividual,
+				Email: email,
+				Name:  item.Name,
+				Date:  item.Date,
+				PrivacyConsent: dbmodels.PrivacyConsent{
+					PrivacyPolicyVersion: item.PrivacyVersion,
+					PrivacyPolicyHash:    item.PrivacyHash,
+				},
+			})
+		}
+	}
+
+	var corps []cCorpSigning
+	if err := this.getSigningsOfOutdatedPrivacy(this.corpSigningCollection, linkID, version, &corps); err != nil {
+		return nil, err
+	}
+	for i := range corps {
+		for j := range corps[i].Signings {
+			item := &corps[i].Signings[j]
+
+			email, err := this.encrypt.decryptStr(item.AdminEmail)
+			if err != nil {
+				return nil, err
+			}
+
+			r = append(r, dbmodels.OutdatedPrivacyConsent{
+				Type:  signingTypeCorporation,
+				Email: email,
+				Name:  item.AdminName,
+				Date:  item.Date,
+				PrivacyConsent: dbmodels.PrivacyConsent{
+					PrivacyPolicyVersion: item.PrivacyVersion,
+					PrivacyPolicyHash:    item.PrivacyHash,
+				},
+			})
+		}
+	}
+
+	return r, nil
+}
+
+func (this *client) getSigningsOfOutdatedPrivacy(collection, linkID, version string, result interface{}) dbmodels.IDBError {
+	pipeline := bson.A{
+		bson.M{"$match": docFilterOfSigning(linkID)},
+		bson.M{"$project": bson.M{
+			fieldSignings: bson.M{"$filter": bson.M{
+				"input": "$" + fieldSignings,
+				"cond": bson.M{"$ne": bson.A{
+					"$$this." + fieldPrivacyVersion, version,
+				}},
+			}},
+		}},
+	}
+
+	f := func(ctx context.Context) error {
+		cursor, err := this.collection(collection).Aggregate(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+
+		return cursor.All(ctx, result)
+	}
+
+	if err := withContext(f); err != nil {
+		return newSystemError(err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+func (this *client) PurgeVerificationCodes(expiredBefore int64) (int64, dbmodels.IDBError) {
+	return this.deleteDocs(
+		this.vcCollection, bson.M{fieldExpiry: bson.M{"$lt": expiredBefore}},
+	)
+}
+
+func (this *client) PurgeAuthRecords(before int64) (int64, dbmodels.IDBError) {
+	n, err := this.deleteDocs(
+		this.authFailureCollection,
+		bson.M{
+			fieldLastFailure: bson.M{"$lt": before},
+			fieldLockedUntil: bson.M{"$lt": util.Now()},
+		},
+	)
+	if err != nil {
+		return n, err
+	}
+
+	m, err := this.deleteDocs(
+		this.sessionCollection, bson.M{fieldExpiry: bson.M{"$lt": before}},
+	)
+	n += m
+	if err != nil {
+		return n, err
+	}
+
+	// the personal access token never expires if the expiry is 0
+	m, err = this.deleteDocs(
+		this.accessTokenCollection,
+		bson.M{fieldExpiry: bson.M{"$gt": 0, "$lt": before}},
+	)
+	return n + m, err
+}
+
+func (this *client) PurgeDeletedCorpSignings(deletedBefore int64) (int64, dbmodels.IDBError) {
+	elemFilter := bson.M{fieldDeletedAt: bson.M{"$gt": 0, "$lt": deletedBefore}}
+	docFilter := bson.M{fieldDeleted: bson.M{"$elemMatch": elemFilter}}
+
+	var n int64
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.corpSigningCollection)
+
+		// the signings deleted before the deleted_at was introduced
+		// start to be counted from now on.
+		_, err := col.UpdateMany(
+			ctx,
+			bson.M{fieldDeleted: bson.M{"$elemMatch": bson.M{fieldDeletedAt: bson.M{"$exists": false}}}},
+			bson.M{"$set": bson.M{fmt.Sprintf("%s.$[d].%s", fieldDeleted, fieldDeletedAt): util.Now()}},
+			&options.UpdateOptions{
+				ArrayFilters: &options.ArrayFilters{
+					Filters: bson.A{bson.M{"d." + fieldDeletedAt: bson.M{"$exists": false}}},
+				},
+			},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		var v []struct {
+			Num int64 `bson:"num"`
+		}
+		pipeline := bson.A{
+			bson.M{"$match": docFilter},
+			bson.M{"$unwind": "$" + fieldDeleted},
+			bson.M{"$replaceRoot": bson.M{"newRoot": "$" + fieldDeleted}},
+			bson.M{"$match": elemFilter},
+			bson.M{"$count": "num"},
+		}
+		cursor, err := col.Aggregate(ctx, pipeline)
+		if err != nil {
+			return newSystemError(err)
+		}
+		if err := cursor.All(ctx, &v); err != nil {
+			return newSystemError(err)
+		}
+		if len(v) == 0 {
+			return nil
+		}
+
+		if _, err := col.UpdateMany(ctx, docFilter, bson.M{"$pull": bson.M{fieldDeleted: elemFilter}}); err != nil {
+			return newSystemError(err)
+		}
+
+		n = v[0].Num
+		return nil
+	}
+
+	err := withContext1(f)
+	return n, err
+}
+
+func (this *client) ListDeletedCorpSigningsToPurge(deletedBefore int64) ([]dbmodels.DeletedCorpSigning, dbmodels.IDBError) {
+	key := func(k string) string {
+		return fmt.Sprintf("%s.%s", fieldDeleted, k)
+	}
+
+	project := bson.M{
+		fieldLinkID:                       1,
+		memberNameOfSignings(fieldCorpID): 1,
+		key(fieldCorpID):                  1,
+		key(fieldEmail):                   1,
+		key(fieldDeletedAt):               1,
+	}
+
+	docFilter := bson.M{fieldDeleted: bson.M{"$elemMatch": bson.M{
+		fieldDeletedAt: bson.M{"$gt": 0, "$lt": deletedBefore},
+	}}}
+
+	var v []cCorpSigning
+	f := func(ctx context.Context) error {
+		return this.getDocs(ctx, this.corpSigningCollection, docFilter, project, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	var r []dbmodels.DeletedCorpSigning
+	for i := range v {
+		doc := &v[i]
+
+		// the corporations whose files are still used
+		kept := map[string]bool{}
+		for j := range doc.Signings {
+			kept[doc.Signings[j].CorpID] = true
+		}
+		for j := range doc.Deleted {
+			if item := &doc.Deleted[j]; item.DeletedAt <= 0 || item.DeletedAt >= deletedBefore {
+				kept[item.CorpID] = true
+			}
+		}
+
+		for j := range doc.Deleted {
+			item := &doc.Deleted[j]
+			if kept[item.CorpID] {
+				continue
+			}
+			kept[item.CorpID] = true
+
+			email, err := this.encrypt.decryptStr(item.AdminEmail)
+			if err != nil {
+				return nil, err
+			}
+
+			r = append(r, dbmodels.DeletedCorpSigning{
+				LinkID:     doc.LinkID,
+				AdminEmail: email,
+			})
+		}
+	}
+
+	return r, nil
+}
+
+func (this *client) ListUnlinkedLinks(unlinkedBefore int64) ([]string, dbmodels.IDBError) {
+	var v []cLink
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.linkCollection)
+
+		// the links unlinked before the unlinked_at was introduced
+		// start to be counted from now on.
+		_, err := col.UpdateMany(
+			ctx,
+			bson.M{
+				fieldLinkStatus: linkStatusDeleted,
+				fieldUnlinkedAt: bson.M{"$exists": false},
+			},
+			bson.M{"$set": bson.M{fieldUnlinkedAt: util.Now()}},
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		err = this.getDocs(
+			ctx, this.linkCollection,
+			bson.M{
+				fieldLinkStatus: linkStatusDeleted,
+				fieldUnlinkedAt: bson.M{"$lt": unlinkedBefore},
+			},
+			bson.M{fieldLinkID: 1}, &v,
+		)
+		if err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]string, 0, len(v))
+	for i := range v {
+		r = append(r, v[i].LinkID)
+	}
+	return r, nil
+}
+
+func (this *client) ListLinkIDs() ([]string, dbmodels.IDBError) {
+	var v []cLink
+
+	f := func(ctx context.Context) error {
+		return this.getDocs(ctx, this.linkCollection, bson.M{}, bson.M{fieldLinkID: 1}, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	r := make([]string, 0, len(v))
+	for i := range v {
+		r = append(r, v[i].LinkID)
+	}
+	return r, nil
+}
+
+func (this *client) PurgeDataOfLink(linkID string) dbmodels.IDBError {
+	docFilter := bson.M{fieldLinkID: linkID}
+
+	// the link doc is deleted at last, so the purging can be retried if it fails halfway.
+	collections := []string{
+		this.corpSigningCollection,
+		this.individualSigningCollection,
+		this.corpPDFCollection,
+		this.corpOIDCCollection,
+		this.sessionCollection,
+		this.accessTokenCollection,
+		this.linkCollection,
+	}
+
+	for _, item := range collections {
+		filter := docFilter
+		if item == this.linkCollection {
+			filter = bson.M{fieldLinkID: linkID, fieldLinkStatus: linkStatusDeleted}
+		}
+
+		if _, err := this.deleteDocs(item, filter); err != nil {
+			return newSystemError(
+				fmt.Errorf("purge data of link:%s in %s failed, %s", linkID, item, err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (this *client) deleteDocs(collection string, filter bson.M) (int64, dbmodels.IDBError) {
+	var n int64
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		r, err := this.collection(collection).DeleteMany(ctx, filter)
+		if err != nil {
+			return newSystemError(err)
+		}
+
+		n = r.DeletedCount
+		return nil
+	}
+
+	err := withContext1(f)
+	return n, err
+}
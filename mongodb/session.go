@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+func (this *client) CreateSession(opt *dbmodels.Session) dbmodels.IDBError {
+	subject, err := this.encrypt.encryptStr(opt.Subject)
+	if err != nil {
+		return err
+	}
+
+	info := cSession{
+		ID:         opt.ID,
+		Subject:    subject,
+		Permission: opt.Permission,
+		LinkID:     opt.LinkID,
+		IP:         opt.IP,
+		CreatedAt:  opt.CreatedAt,
+		LastUsed:   opt.LastUsed,
+		Expiry:     opt.Expiry,
+	}
+	body, err := structToMap(info)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.sessionCollection)
+
+		// delete the expired sessions.
+		col.DeleteMany(ctx, bson.M{fieldExpiry: bson.M{"$lt": util.Now()}})
+
+		if _, err := this.insertDoc(ctx, this.sessionCollection, body); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetSession(id string) (*dbmodels.Session, dbmodels.IDBError) {
+	var v cSession
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(ctx, this.sessionCollection, bson.M{fieldID: id}, nil, &v)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	return this.toModelOfSession(&v)
+}
+
+func (this *client) RefreshSession(id, ip string, expiry int64) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.sessionCollection, bson.M{fieldID: id},
+			bson.M{
+				fieldIP:       ip,
+				fieldLastUsed: util.Now(),
+				fieldExpiry:   expiry,
+			},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) ListSessions(subject string) ([]dbmodels.Session, dbmodels.IDBError) {
+	s, err := this.encrypt.encryptStr(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		fieldSubject: s,
+		fieldExpiry:  bson.M{"$gte": util.Now()},
+	}
+
+	var v []cSession
+	f := func(ctx context.Context) error {
+		return this.getDocs(ctx, this.sessionCollection, filter, nil, &v)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, newSystemError(err)
+	}
+
+	r := make([]dbmodels.Session, 0, len(v))
+	for i := range v {
+		item, err := this.toModelOfSession(&v[i])
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, *item)
+	}
+
+	return r, nil
+}
+
+func (this *client) DeleteSession(id string) dbmodels.IDBError {
+	return this.deleteSessions(bson.M{fieldID: id})
+}
+
+func (this *client) DeleteSessions(opt *dbmodels.SessionDeleteOption) dbmodels.IDBError {
+	filter := bson.M{}
+
+	if opt.LinkID != "" {
+		filter[fieldLinkID] = opt.LinkID
+	}
+
+	if len(opt.Subjects) > 0 {
+		subjects := make(bson.A, 0, len(opt.Subjects))
+		for _, item := range opt.Subjects {
+			s, err := this.encrypt.encryptStr(item)
+			if err != nil {
+				return err
+			}
+			subjects = append(subjects, s)
+		}
+		filter[fieldSubject] = bson.M{"$in": subjects}
+	}
+
+	if len(filter) == 0 {
+		return nil
+	}
+
+	if opt.Except != "" {
+		filter[fieldID] = bson.M{"$ne": opt.Except}
+	}
+
+	return this.deleteSessions(filter)
+}
+
+func (this *client) deleteSessions(filter bson.M) dbmodels.IDBError {
+	f := func(ctx context.Context) dbmodels.IDBError {
+		col := this.collection(this.sessionCollection)
+
+		if _, err := col.DeleteMany(ctx, filter); err != nil {
+			return newSystemError(err)
+		}
+		return nil
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) toModelOfSession(doc *cSession) (*dbmodels.Session, dbmodels.IDBError) {
+	subject, err := this.encrypt.decryptStr(doc.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbmodels.Session{
+		ID:         doc.ID,
+		Subject:    subject,
+		Permission: doc.Permission,
+		LinkID:     doc.LinkID,
+		IP:         doc.IP,
+		CreatedAt:  doc.CreatedAt,
+		LastUsed:   doc.LastUsed,
+		Expiry:     doc.Expiry,
+	}, nil
+}
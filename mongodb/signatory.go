@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+)
+
+func (this *client) SaveSignatories(linkID string, v []dbmodels.Signatory) dbmodels.IDBError {
+	items := make(bson.A, 0, len(v))
+	for i := range v {
+		item := &v[i]
+
+		doc, err := structToMap(dSignatory{
+			ID:    item.ID,
+			Name:  item.Name,
+			Title: item.Title,
+		})
+		if err != nil {
+			return err
+		}
+		items = append(items, doc)
+	}
+
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.updateDoc(
+			ctx, this.linkCollection, docFilterOfReadyLink(linkID),
+			bson.M{fieldSignatories: items},
+		)
+	}
+
+	return withContext1(f)
+}
+
+func (this *client) GetSignatories(linkID string) ([]dbmodels.Signatory, dbmodels.IDBError) {
+	var v cLink
+	f := func(ctx context.Context) dbmodels.IDBError {
+		return this.getDoc(
+			ctx, this.linkCollection, docFilterOfReadyLink(linkID),
+			bson.M{fieldSignatories: 1}, &v,
+		)
+	}
+
+	if err := withContext1(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]dbmodels.Signatory, 0, len(v.Signatories))
+	for _, item := range v.Signatories {
+		r = append(r, dbmodels.Signatory{
+			ID:    item.ID,
+			Name:  item.Name,
+			Title: item.Title,
+		})
+	}
+	return r, nil
+}
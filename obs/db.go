@@ -53,10 +53,169 @@ func (fs fileStorage) ListCorporationsWithPDFUploaded(linkID string) ([]string,
 	return result, nil
 }
 
+func (fs fileStorage) UploadArchivedCorporationSigningPDF(linkID, email, version string, pdf []byte) dbmodels.IDBError {
+	err := fs.c.WriteObject(buildArchivedCorpSigningPDFPath(linkID, email, version), pdf)
+	return toDBError(err)
+}
+
+func (fs fileStorage) DownloadArchivedCorporationSigningPDF(linkID, email, version, path string) dbmodels.IDBError {
+	err := fs.c.ReadObject(buildArchivedCorpSigningPDFPath(linkID, email, version), path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsObjectNotFound() {
+		return dbmodels.NewDBError(dbmodels.ErrNoDBRecord, err)
+	}
+	return toDBError(err)
+}
+
+func (fs fileStorage) UploadCorpSigningAmendmentPDF(linkID, email, id string, pdf []byte) dbmodels.IDBError {
+	err := fs.c.WriteObject(buildCorpSigningAmendmentPDFPath(linkID, email, id), pdf)
+	return toDBError(err)
+}
+
+func (fs fileStorage) DownloadCorpSigningAmendmentPDF(linkID, email, id, path string) dbmodels.IDBError {
+	err := fs.c.ReadObject(buildCorpSigningAmendmentPDFPath(linkID, email, id), path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsObjectNotFound() {
+		return dbmodels.NewDBError(dbmodels.ErrNoDBRecord, err)
+	}
+	return toDBError(err)
+}
+
+func (fs fileStorage) UploadIndividualSigningPDF(linkID, signingID string, pdf []byte) dbmodels.IDBError {
+	err := fs.c.WriteObject(buildIndividualSigningPDFPath(linkID, signingID), pdf)
+	return toDBError(err)
+}
+
+func (fs fileStorage) DownloadIndividualSigningPDF(linkID, signingID, path string) dbmodels.IDBError {
+	err := fs.c.ReadObject(buildIndividualSigningPDFPath(linkID, signingID), path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsObjectNotFound() {
+		return dbmodels.NewDBError(dbmodels.ErrNoDBRecord, err)
+	}
+	return toDBError(err)
+}
+
+// DeleteIndividualSigningPDF deletes the receipt of individual signing.
+// It succeeds if the receipt doesn't exist.
+func (fs fileStorage) DeleteIndividualSigningPDF(linkID, signingID string) dbmodels.IDBError {
+	err := fs.c.DeleteObject(buildIndividualSigningPDFPath(linkID, signingID))
+	return toDBError(err)
+}
+
+// ListIndividualSigningPDFs returns the signing ids of receipts saved for the link.
+func (fs fileStorage) ListIndividualSigningPDFs(linkID string) ([]string, dbmodels.IDBError) {
+	prefix := buildIndividualSigningPDFPath(linkID, "")
+
+	r, err := fs.c.ListObject(prefix)
+	if err != nil {
+		return nil, toDBError(err)
+	}
+
+	result := make([]string, 0, len(r))
+	for _, item := range r {
+		result = append(result, strings.TrimPrefix(item, prefix))
+	}
+	return result, nil
+}
+
+func (fs fileStorage) UploadCounterSignedPDF(linkID, adminEmail string, pdf []byte) dbmodels.IDBError {
+	err := fs.c.WriteObject(buildCounterSignedPDFPath(linkID, adminEmail), pdf)
+	return toDBError(err)
+}
+
+func (fs fileStorage) DownloadCounterSignedPDF(linkID, email, path string) dbmodels.IDBError {
+	err := fs.c.ReadObject(buildCounterSignedPDFPath(linkID, email), path)
+	if err == nil {
+		return nil
+	}
+
+	if err.IsObjectNotFound() {
+		return dbmodels.NewDBError(dbmodels.ErrNoDBRecord, err)
+	}
+	return toDBError(err)
+}
+
+// DeleteCorpSigningPDFs deletes the pdfs of corporation signing, which include the
+// counter-signed, archived and amendment pdfs. It succeeds if they don't exist.
+func (fs fileStorage) DeleteCorpSigningPDFs(linkID, email string) dbmodels.IDBError {
+	paths := []string{
+		buildCorpSigningPDFPath(linkID, email),
+		buildCounterSignedPDFPath(linkID, email),
+	}
+	for _, item := range paths {
+		if err := fs.c.DeleteObject(item); err != nil {
+			return toDBError(err)
+		}
+	}
+
+	return fs.deleteObjects(
+		buildArchivedCorpSigningPDFPath(linkID, email, ""),
+		buildCorpSigningAmendmentPDFPath(linkID, email, ""),
+	)
+}
+
+// DeleteCorpSigningPDFsOfLink deletes the pdfs of all the corporation signings of link.
+func (fs fileStorage) DeleteCorpSigningPDFsOfLink(linkID string) dbmodels.IDBError {
+	return fs.deleteObjects(
+		buildCorpSigningPDFPath(linkID, ""),
+		buildCounterSignedPDFPath(linkID, ""),
+		fmt.Sprintf("archived/%s/", linkID),
+		fmt.Sprintf("amendment/%s/", linkID),
+	)
+}
+
+// deleteObjects deletes all the objects whose path starts with one of the prefixes.
+func (fs fileStorage) deleteObjects(prefixes ...string) dbmodels.IDBError {
+	for _, prefix := range prefixes {
+		v, err := fs.c.ListObject(prefix)
+		if err != nil {
+			return toDBError(err)
+		}
+
+		for _, item := range v {
+			if err := fs.c.DeleteObject(item); err != nil {
+				return toDBError(err)
+			}
+		}
+	}
+	return nil
+}
+
 func buildCorpSigningPDFPath(linkID string, email string) string {
 	return fmt.Sprintf("%s/%s", linkID, util.EmailSuffix(email))
 }
 
+// buildIndividualSigningPDFPath returns the path of the receipt of individual signing.
+// It is not under the directory of link which lists the pdfs of corporations.
+func buildIndividualSigningPDFPath(linkID, signingID string) string {
+	return fmt.Sprintf("individual/%s/%s", linkID, signingID)
+}
+
+// buildCounterSignedPDFPath returns the path of the fully executed pdf of corporation signing.
+func buildCounterSignedPDFPath(linkID, email string) string {
+	return fmt.Sprintf("counter-signed/%s/%s", linkID, util.EmailSuffix(email))
+}
+
+// buildArchivedCorpSigningPDFPath returns the path of the pdf of corporation signing
+// which has been replaced by the renewal.
+func buildArchivedCorpSigningPDFPath(linkID, email, version string) string {
+	return fmt.Sprintf("archived/%s/%s/%s", linkID, util.EmailSuffix(email), version)
+}
+
+// buildCorpSigningAmendmentPDFPath returns the path of the amendment page of corporation signing.
+func buildCorpSigningAmendmentPDFPath(linkID, email, id string) string {
+	return fmt.Sprintf("amendment/%s/%s/%s", linkID, util.EmailSuffix(email), id)
+}
+
 func toDBError(err error) dbmodels.IDBError {
 	if err == nil {
 		return nil
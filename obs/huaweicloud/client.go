@@ -116,6 +116,16 @@ func (cli *client) ListObject(pathPrefix string) ([]string, error) {
 	return r, nil
 }
 
+func (cli *client) DeleteObject(path string) error {
+	input := sdk.DeleteObjectInput{
+		Bucket: cli.bucket,
+		Key:    path,
+	}
+
+	_, err := cli.c.DeleteObject(&input)
+	return err
+}
+
 func newSSECHeader(key string) sdk.ISseHeader {
 	if key == "" {
 		return nil
@@ -12,6 +12,7 @@ type OBS interface {
 	ReadObject(path, localPath string) OBSError
 	HasObject(string) (bool, error)
 	ListObject(pathPrefix string) ([]string, error)
+	DeleteObject(path string) error
 }
 
 var instances = map[string]OBS{}
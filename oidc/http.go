@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// the addresses which the client refuses to connect, since the issuer is
+// configured by the corporation managers and must not reach the internal network.
+var nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::1/128",
+	"::/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+var httpClient = newHTTPClient()
+
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		// check the address resolved, so a public host name can't be rebound to an internal one.
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("the address:%s is not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("too many redirects")
+			}
+			return checkURL(req.URL.String())
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	for _, item := range nonPublicNetworks {
+		if item.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkURL makes sure the endpoint of provider is requested over https.
+func checkURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid url: %s", err.Error())
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("the url:%s should be an absolute https url", s)
+	}
+	return nil
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	r := make([]*net.IPNet, 0, len(cidrs))
+	for _, item := range cidrs {
+		_, v, err := net.ParseCIDR(item)
+		if err != nil {
+			panic(err)
+		}
+		r = append(r, v)
+	}
+	return r
+}
@@ -0,0 +1,108 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type keySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+func fetchKeys(url string) (*keySet, error) {
+	var v keySet
+	if err := getJSON(url, &v); err != nil {
+		return nil, fmt.Errorf("fetch jwks failed: %s", err.Error())
+	}
+	return &v, nil
+}
+
+func (this *keySet) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	for i := range this.Keys {
+		k := &this.Keys[i]
+		if (kid != "" && k.Kid != kid) || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA:
+			if k.Kty == "RSA" {
+				return k.rsaKey()
+			}
+
+		case *jwt.SigningMethodECDSA:
+			if k.Kty == "EC" {
+				return k.ecdsaKey()
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no matched key for the id token")
+}
+
+func (this *jsonWebKey) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(this.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeBigInt(this.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (this *jsonWebKey) ecdsaKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch this.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", this.Crv)
+	}
+
+	x, err := decodeBigInt(this.X)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeBigInt(this.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %s", err.Error())
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,180 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	liboauth2 "golang.org/x/oauth2"
+)
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// Claims is the identity of user which is fetched from the id token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type Provider struct {
+	issuer  string
+	jwksURL string
+	cfg     *liboauth2.Config
+}
+
+type discovery struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+// NewProvider fetches the configuration of the issuer by OpenID Connect Discovery.
+// The issuer and the endpoints of it should be public https urls.
+func NewProvider(cfg Config) (*Provider, error) {
+	if err := checkURL(cfg.Issuer); err != nil {
+		return nil, err
+	}
+
+	wellKnown := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+
+	var d discovery
+	if err := getJSON(wellKnown, &d); err != nil {
+		return nil, fmt.Errorf("discover oidc provider failed: %s", err.Error())
+	}
+
+	if d.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("the issuer:%s doesn't match the expected one:%s", d.Issuer, cfg.Issuer)
+	}
+
+	if d.AuthURL == "" || d.TokenURL == "" || d.JWKSURL == "" {
+		return nil, fmt.Errorf("incomplete configuration of oidc provider")
+	}
+
+	for _, item := range []string{d.AuthURL, d.TokenURL, d.JWKSURL} {
+		if err := checkURL(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Provider{
+		issuer:  d.Issuer,
+		jwksURL: d.JWKSURL,
+		cfg: &liboauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"openid", "email"},
+			Endpoint: liboauth2.Endpoint{
+				AuthURL:  d.AuthURL,
+				TokenURL: d.TokenURL,
+			},
+		},
+	}, nil
+}
+
+func (this *Provider) AuthCodeURL(state, nonce string) string {
+	return this.cfg.AuthCodeURL(state, liboauth2.SetAuthURLParam("nonce", nonce))
+}
+
+// Exchange fetches the id token by the code and verifies it.
+func (this *Provider) Exchange(code, nonce string) (*Claims, error) {
+	ctx := context.WithValue(context.Background(), liboauth2.HTTPClient, httpClient)
+
+	token, err := this.cfg.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve token: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("no id token")
+	}
+
+	return this.verify(rawIDToken, nonce)
+}
+
+func (this *Provider) verify(rawIDToken, nonce string) (*Claims, error) {
+	keys, err := fetchKeys(this.jwksURL)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := jwt.Parse(rawIDToken, keys.keyFunc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %s", err.Error())
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok || !t.Valid {
+		return nil, fmt.Errorf("invalid id token")
+	}
+
+	if !claims.VerifyIssuer(this.issuer, true) {
+		return nil, fmt.Errorf("unexpected issuer of id token")
+	}
+
+	if !hasAudience(claims["aud"], this.cfg.ClientID) {
+		return nil, fmt.Errorf("unexpected audience of id token")
+	}
+
+	if v, _ := claims["nonce"].(string); v == "" || v != nonce {
+		return nil, fmt.Errorf("unmatched nonce of id token")
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("id token without expiry")
+	}
+
+	r := &Claims{}
+	r.Subject, _ = claims["sub"].(string)
+	r.Email, _ = claims["email"].(string)
+
+	// some providers return the email_verified as string
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		r.EmailVerified = v
+	case string:
+		r.EmailVerified = v == "true"
+	}
+
+	return r, nil
+}
+
+func hasAudience(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getJSON(url string, v interface{}) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
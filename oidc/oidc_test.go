@@ -0,0 +1,156 @@
+package oidc
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/opensourceways/app-cla-server/oidc/oidctest"
+)
+
+const (
+	testClientID     = "client"
+	testClientSecret = "secret"
+)
+
+// newTestProvider starts the local provider and lets the client trust it,
+// since the default client refuses to connect the loopback address.
+func newTestProvider(t *testing.T) (*oidctest.Server, *Provider, func()) {
+	s, err := oidctest.NewServer(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := httpClient
+	httpClient = s.Client()
+
+	cleanup := func() {
+		httpClient = old
+		s.Close()
+	}
+
+	p, err := NewProvider(Config{
+		Issuer:       s.Issuer(),
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  "https://cla.example.com/oidc/callback",
+	})
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	return s, p, cleanup
+}
+
+func TestExchange(t *testing.T) {
+	s, p, cleanup := newTestProvider(t)
+	defer cleanup()
+
+	s.Authorize("code", map[string]interface{}{
+		"sub":            "u1",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"nonce":          "nonce",
+	})
+
+	claims, err := p.Exchange("code", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.Subject != "u1" || claims.Email != "alice@example.com" || !claims.EmailVerified {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := p.Exchange("code", "nonce"); err == nil {
+		t.Fatal("the code should be used only once")
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims map[string]interface{}
+		errMsg string
+	}{
+		{
+			name:   "unmatched nonce",
+			claims: map[string]interface{}{"nonce": "other"},
+			errMsg: "nonce",
+		},
+		{
+			name:   "other audience",
+			claims: map[string]interface{}{"nonce": "nonce", "aud": "other"},
+			errMsg: "audience",
+		},
+		{
+			name:   "other issuer",
+			claims: map[string]interface{}{"nonce": "nonce", "iss": "https://other.example.com"},
+			errMsg: "issuer",
+		},
+		{
+			name:   "expired",
+			claims: map[string]interface{}{"nonce": "nonce", "exp": 1},
+			errMsg: "expired",
+		},
+	}
+
+	s, p, cleanup := newTestProvider(t)
+	defer cleanup()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s.Authorize(c.name, c.claims)
+
+			_, err := p.Exchange(c.name, "nonce")
+			if err == nil || !strings.Contains(err.Error(), c.errMsg) {
+				t.Fatalf("expect error about %s, got: %v", c.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestNewProviderRequiresHTTPS(t *testing.T) {
+	for _, issuer := range []string{"http://example.com", "example.com", ""} {
+		if _, err := NewProvider(Config{Issuer: issuer}); err == nil {
+			t.Fatalf("the issuer:%s should be rejected", issuer)
+		}
+	}
+}
+
+func TestDefaultClientRejectsLoopback(t *testing.T) {
+	s, err := oidctest.NewServer(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	_, err = NewProvider(Config{Issuer: s.Issuer()})
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("the loopback address should be refused, got: %v", err)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	}
+
+	for s, expect := range cases {
+		if v := isPublicIP(net.ParseIP(s)); v != expect {
+			t.Errorf("isPublicIP(%s) = %v, expect %v", s, v, expect)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package oidctest provides a local OpenID Connect provider which is
+// used in place of the identity provider of corporation in tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyID = "oidctest"
+
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	key   *rsa.PrivateKey
+	lock  sync.Mutex
+	codes map[string]jwt.MapClaims
+}
+
+// NewServer starts the provider over https. The client of it trusts its certificate.
+func NewServer(clientID, clientSecret string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]jwt.MapClaims{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/token", s.token)
+
+	s.Server = httptest.NewTLSServer(mux)
+	return s, nil
+}
+
+func (this *Server) Issuer() string {
+	return this.URL
+}
+
+// Authorize registers the code which can be exchanged once for the id token with the claims.
+// The iss, aud, iat and exp are set to the valid ones unless they are specified.
+func (this *Server) Authorize(code string, claims map[string]interface{}) {
+	now := time.Now()
+	v := jwt.MapClaims{
+		"iss": this.Issuer(),
+		"aud": this.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, item := range claims {
+		v[k] = item
+	}
+
+	this.lock.Lock()
+	this.codes[code] = v
+	this.lock.Unlock()
+}
+
+func (this *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 this.Issuer(),
+		"authorization_endpoint": this.URL + "/authorize",
+		"token_endpoint":         this.URL + "/token",
+		"jwks_uri":               this.URL + "/jwks",
+	})
+}
+
+func (this *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := &this.key.PublicKey
+
+	writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (this *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id != this.ClientID || secret != this.ClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	this.lock.Lock()
+	claims, ok := this.codes[code]
+	delete(this.codes, code)
+	this.lock.Unlock()
+
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = keyID
+
+	idToken, err := t.SignedString(this.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
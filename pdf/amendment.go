@@ -0,0 +1,84 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/opensourceways/gofpdf"
+
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+// GenPDFForCorpSigningAmendment generates the page which records the previous and
+// amended values of the contact fields changed by the amendment.
+func (this *pdfGenerator) GenPDFForCorpSigningAmendment(linkID string, orgInfo *models.OrgInfo, v *models.AmendedCorpSigning) ([]byte, error) {
+	c, err := this.generatorOfLink(linkID, v.Signing.CLALanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := c.begin()
+	c.amendmentPage(pdf, orgInfo.OrgAlias, v)
+
+	if pdf.Err() {
+		return nil, fmt.Errorf("failed to generate amendment page: %s", pdf.Error().Error())
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate amendment page: %s", err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+func (this *corpSigningPDF) amendmentPage(pdf *gofpdf.Fpdf, org string, v *models.AmendedCorpSigning) {
+	t := &this.amendment
+	amendment := &v.Amendment
+
+	pdf.AddPage()
+
+	this.titleWithSubtitle(pdf, org, t.Title)
+
+	setFont(pdf, this.welcomeFont)
+	multlines(pdf, this.gh, t.Statement)
+
+	date := amendment.ReviewedAt
+	if date == 0 {
+		date = amendment.SubmittedAt
+	}
+
+	setFont(pdf, this.contactFont)
+	this.contactItem(pdf, t.Corporation, v.Signing.CorporationName, "")
+	this.contactItem(pdf, t.SigningDate, v.Signing.Date, "")
+	this.contactItem(pdf, t.AmendmentID, amendment.ID, "")
+	this.contactItem(pdf, t.Date, time.Unix(date, 0).Format("2006-01-02"), "")
+	pdf.Ln(-1)
+
+	fields := BuildCorpContact(v.Fields)
+	for i := range fields {
+		item := &fields[i]
+
+		value, ok := amendment.Changes[item.ID]
+		if !ok {
+			continue
+		}
+
+		f := this.contactFont
+		this.setFontWithStyle(pdf, f.font, "B", f.size)
+		pdf.CellFormat(0, this.gh, item.Title, "", 1, "L", false, 0, "")
+		setFont(pdf, f)
+
+		this.contactItem(pdf, t.Previous, this.amendedValue(item, amendment.Previous[item.ID]), "")
+		this.contactItem(pdf, t.Amended, this.amendedValue(item, value), "")
+		pdf.Ln(-1)
+	}
+}
+
+func (this *corpSigningPDF) amendedValue(field *models.CLAField, value string) string {
+	if field.Type == dbmodels.FieldTypeCheckbox {
+		return this.checkbox[models.IsFieldChecked(value)]
+	}
+	return value
+}
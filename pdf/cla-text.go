@@ -0,0 +1,110 @@
+package pdf
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/gofpdf"
+
+	"github.com/opensourceways/app-cla-server/clatext"
+)
+
+const (
+	indentOfListItem = 8.0
+	indentOfQuote    = 8.0
+)
+
+// claDocument prints the structured cla text, such as the one written in markdown.
+func (this *corpSigningPDF) claDocument(pdf *gofpdf.Fpdf, doc *clatext.Document) {
+	gh := this.gh
+	size := this.claFont.size
+	lm, _, rm, _ := pdf.GetMargins()
+
+	for i := range doc.Blocks {
+		item := &doc.Blocks[i]
+
+		switch item.Kind {
+		case clatext.BlockHeading:
+			hs := size + sizeIncrementOfHeading(item.Level)
+			pdf.Ln(gh / 2)
+			this.writeSpans(pdf, item.Spans, hs, "B")
+			pdf.Ln(gh * hs / size)
+			pdf.Ln(gh / 2)
+
+		case clatext.BlockListItem:
+			indent := lm + float64(item.Level)*indentOfListItem
+
+			marker := "-"
+			if item.Ordered {
+				marker = fmt.Sprintf("%d.", item.Number)
+			}
+
+			pdf.SetLeftMargin(indent + indentOfListItem)
+			pdf.SetX(indent)
+			this.setCLAFont(pdf, "", size)
+			pdf.CellFormat(indentOfListItem, gh, marker, "", 0, "L", false, 0, "")
+
+			this.writeSpans(pdf, item.Spans, size, "")
+			pdf.Ln(gh)
+			pdf.SetLeftMargin(lm)
+
+		case clatext.BlockQuote:
+			pdf.SetLeftMargin(lm + indentOfQuote)
+			pdf.SetX(lm + indentOfQuote)
+			this.writeSpans(pdf, item.Spans, size, "I")
+			pdf.Ln(gh)
+			pdf.SetLeftMargin(lm)
+			pdf.Ln(gh / 2)
+
+		case clatext.BlockRule:
+			w, _ := pdf.GetPageSize()
+			y := pdf.GetY() + gh/2
+			pdf.Line(lm, y, w-rm, y)
+			pdf.Ln(gh)
+
+		default:
+			this.writeSpans(pdf, item.Spans, size, "")
+			pdf.Ln(gh)
+			pdf.Ln(gh / 2)
+		}
+	}
+
+	setFont(pdf, this.claFont)
+	pdf.Ln(-1)
+}
+
+func (this *corpSigningPDF) writeSpans(pdf *gofpdf.Fpdf, spans []clatext.Span, size float64, style string) {
+	h := this.gh * size / this.claFont.size
+
+	for _, sp := range spans {
+		s := style
+		if sp.Bold && s != "B" {
+			s = "B" + s
+		}
+		if sp.Italic && s != "I" && s != "BI" {
+			s += "I"
+		}
+
+		if sp.Link != "" {
+			this.setCLAFont(pdf, s+"U", size)
+			pdf.WriteLinkString(h, sp.Text, sp.Link)
+		} else {
+			this.setCLAFont(pdf, s, size)
+			pdf.Write(h, sp.Text)
+		}
+	}
+}
+
+func (this *corpSigningPDF) setCLAFont(pdf *gofpdf.Fpdf, style string, size float64) {
+	this.setFontWithStyle(pdf, this.claFont.font, style, size)
+}
+
+func sizeIncrementOfHeading(level int) float64 {
+	switch level {
+	case 1:
+		return 4
+	case 2:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,302 @@
+package pdf
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+// coreFonts is the fonts built in the pdf which only support the latin scripts.
+var coreFonts = map[string]bool{
+	"courier":      true,
+	"helvetica":    true,
+	"arial":        true,
+	"times":        true,
+	"symbol":       true,
+	"zapfdingbats": true,
+}
+
+// pdfConfig is the profiles of pdf languages. Adding a language only needs
+// a new profile, the fonts of it and the templates.
+type pdfConfig struct {
+	FontDir   string        `json:"font_dir" required:"true"`
+	Languages []langProfile `json:"languages" required:"true"`
+}
+
+type langProfile struct {
+	// Language is the language of cla, such as english.
+	Language string `json:"language" required:"true"`
+
+	// Fonts is the UTF-8 fonts used by the profile. The core fonts,
+	// such as Arial and Times, don't need to be declared.
+	Fonts []fontFile `json:"fonts"`
+
+	Page      pageLayout `json:"page"`
+	Styles    fontStyles `json:"styles" required:"true"`
+	Templates templates  `json:"templates" required:"true"`
+	Texts     texts      `json:"texts" required:"true"`
+}
+
+type fontFile struct {
+	Family string `json:"family" required:"true"`
+	// Style is one of "", "B", "I" and "BI".
+	Style string `json:"style"`
+	File  string `json:"file" required:"true"`
+}
+
+type pageLayout struct {
+	// Size is the page size, such as A4, Letter.
+	Size string `json:"size"`
+	// Orientation is P(portrait) or L(landscape).
+	Orientation string `json:"orientation"`
+	// LineHeight is the height of line in mm.
+	LineHeight float64 `json:"line_height"`
+	// the margins in mm, the default is 10.
+	LeftMargin  float64 `json:"left_margin"`
+	TopMargin   float64 `json:"top_margin"`
+	RightMargin float64 `json:"right_margin"`
+}
+
+type font struct {
+	Font string  `json:"font" required:"true"`
+	Size float64 `json:"size" required:"true"`
+}
+
+type fontStyles struct {
+	// Header is the font of the text in header, it is the one of footer if it is not set.
+	Header      font `json:"header"`
+	Footer      font `json:"footer" required:"true"`
+	Title       font `json:"title" required:"true"`
+	Welcome     font `json:"welcome" required:"true"`
+	Contact     font `json:"contact" required:"true"`
+	Declaration font `json:"declaration" required:"true"`
+	CLA         font `json:"cla" required:"true"`
+	URL         font `json:"url" required:"true"`
+	Signature   font `json:"signature" required:"true"`
+}
+
+type templates struct {
+	Welcome     string `json:"welcome" required:"true"`
+	Declaration string `json:"declaration" required:"true"`
+}
+
+type texts struct {
+	Subtitle string `json:"subtitle" required:"true"`
+	// FooterNumber is the format of page number, such as "Page %d".
+	FooterNumber string `json:"footer_number" required:"true"`
+	// SignatureItems is the items of signature page, each of which is
+	// a pair of community and corporation. The first one is the header.
+	SignatureItems [][]string `json:"signature_items" required:"true"`
+	SignatureDate  string     `json:"signature_date" required:"true"`
+	Checked        string     `json:"checked" required:"true"`
+	Unchecked      string     `json:"unchecked" required:"true"`
+
+	Receipt     receiptTexts     `json:"receipt"`
+	CounterSign counterSignTexts `json:"counter_sign"`
+	Amendment   amendmentTexts   `json:"amendment"`
+}
+
+// receiptTexts is the texts of the receipt of individual and employee signing.
+// The english ones are used if they are not set.
+type receiptTexts struct {
+	Title     string `json:"title"`
+	SigningID string `json:"signing_id"`
+	Date      string `json:"date"`
+	Platform  string `json:"platform"`
+	Account   string `json:"account"`
+}
+
+// counterSignTexts is the texts of the counter-signature page which is appended
+// to the pdf uploaded by corporation. The english ones are used if they are not set.
+type counterSignTexts struct {
+	Title       string `json:"title"`
+	Statement   string `json:"statement"`
+	Corporation string `json:"corporation"`
+	SigningDate string `json:"signing_date"`
+	Digest      string `json:"digest"`
+	Signature   string `json:"signature"`
+	Signatory   string `json:"signatory"`
+	Position    string `json:"position"`
+	Date        string `json:"date"`
+}
+
+// amendmentTexts is the texts of the page which records the amendment of contact
+// information of corporation signing. The english ones are used if they are not set.
+type amendmentTexts struct {
+	Title       string `json:"title"`
+	Statement   string `json:"statement"`
+	Corporation string `json:"corporation"`
+	SigningDate string `json:"signing_date"`
+	AmendmentID string `json:"amendment_id"`
+	Date        string `json:"date"`
+	Previous    string `json:"previous"`
+	Amended     string `json:"amended"`
+}
+
+func loadPDFConfig(path string) (*pdfConfig, error) {
+	cfg := &pdfConfig{}
+	if err := util.LoadFromYaml(path, cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (cfg *pdfConfig) validate() error {
+	if util.IsNotDir(cfg.FontDir) {
+		return fmt.Errorf("The font directory:%s is not exist", cfg.FontDir)
+	}
+
+	langs := map[string]bool{}
+	for i := range cfg.Languages {
+		item := &cfg.Languages[i]
+		item.Language = strings.ToLower(item.Language)
+
+		if langs[item.Language] {
+			return fmt.Errorf("duplicate pdf language:%s", item.Language)
+		}
+		langs[item.Language] = true
+
+		if err := item.validate(cfg.FontDir); err != nil {
+			return fmt.Errorf("invalid pdf language:%s, %s", item.Language, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (p *langProfile) validate(fontDir string) error {
+	p.Page.setDefault()
+	p.Texts.Receipt.setDefault()
+	p.Texts.CounterSign.setDefault()
+	p.Texts.Amendment.setDefault()
+	p.Styles.setDefault()
+
+	families := map[string]bool{}
+	for _, item := range p.Fonts {
+		if !isValidFontStyle(item.Style) {
+			return fmt.Errorf("invalid style:%s of font:%s", item.Style, item.Family)
+		}
+
+		if util.IsFileNotExist(filepath.Join(fontDir, item.File)) {
+			return fmt.Errorf("the font file:%s is not exist", item.File)
+		}
+
+		if item.Style == "" {
+			families[item.Family] = true
+		}
+	}
+
+	s := &p.Styles
+	for _, item := range []font{
+		s.Header, s.Footer, s.Title, s.Welcome, s.Contact,
+		s.Declaration, s.CLA, s.URL, s.Signature,
+	} {
+		if !coreFonts[strings.ToLower(item.Font)] && !families[item.Font] {
+			return fmt.Errorf("the regular style of font:%s is not declared", item.Font)
+		}
+	}
+
+	for _, item := range []string{p.Templates.Welcome, p.Templates.Declaration} {
+		if util.IsFileNotExist(item) {
+			return fmt.Errorf("the template:%s is not exist", item)
+		}
+	}
+
+	if len(p.Texts.SignatureItems) == 0 {
+		return fmt.Errorf("missing signature items")
+	}
+	for _, item := range p.Texts.SignatureItems {
+		if len(item) != 2 {
+			return fmt.Errorf("each signature item should have 2 parts")
+		}
+	}
+
+	if !strings.Contains(p.Texts.FooterNumber, "%d") {
+		return fmt.Errorf("the footer number should contain %%d")
+	}
+
+	return nil
+}
+
+func (s *fontStyles) setDefault() {
+	if s.Header.Font == "" {
+		s.Header = s.Footer
+	}
+}
+
+func (p *pageLayout) setDefault() {
+	if p.Size == "" {
+		p.Size = "A4"
+	}
+	if p.Orientation == "" {
+		p.Orientation = "P"
+	}
+	if p.LineHeight <= 0 {
+		p.LineHeight = 5
+	}
+	if p.LeftMargin <= 0 {
+		p.LeftMargin = 10
+	}
+	if p.TopMargin <= 0 {
+		p.TopMargin = 10
+	}
+	if p.RightMargin <= 0 {
+		p.RightMargin = 10
+	}
+}
+
+func (r *receiptTexts) setDefault() {
+	setDefaultTexts(map[*string]string{
+		&r.Title:     "Signed Copy of Contributor License Agreement",
+		&r.SigningID: "Signing ID",
+		&r.Date:      "Date",
+		&r.Platform:  "Code Platform",
+		&r.Account:   "Account",
+	})
+}
+
+func (c *counterSignTexts) setDefault() {
+	setDefaultTexts(map[*string]string{
+		&c.Title:       "Counter-signature of Community",
+		&c.Statement:   "The community has reviewed the Agreement signed by the corporation below, and the authorized signatory of community counter-signs it. The Agreement is fully executed on the date of counter-signature.",
+		&c.Corporation: "Corporation",
+		&c.SigningDate: "Signed by Corporation",
+		&c.Digest:      "Digest (SHA-256)",
+		&c.Signature:   "Signature",
+		&c.Signatory:   "Signatory",
+		&c.Position:    "Title",
+		&c.Date:        "Date",
+	})
+}
+
+func (a *amendmentTexts) setDefault() {
+	setDefaultTexts(map[*string]string{
+		&a.Title:       "Amendment of Contact Information",
+		&a.Statement:   "The contact information of the corporation below, which was provided when the Agreement was signed, is amended as follows. The other terms of the Agreement remain unchanged.",
+		&a.Corporation: "Corporation",
+		&a.SigningDate: "Signed by Corporation",
+		&a.AmendmentID: "Amendment ID",
+		&a.Date:        "Date",
+		&a.Previous:    "Previous",
+		&a.Amended:     "Amended",
+	})
+}
+
+// setDefaultTexts sets the text to the default one if it is empty.
+func setDefaultTexts(texts map[*string]string) {
+	for v, def := range texts {
+		if *v == "" {
+			*v = def
+		}
+	}
+}
+
+func isValidFontStyle(s string) bool {
+	return s == "" || s == "B" || s == "I" || s == "BI"
+}
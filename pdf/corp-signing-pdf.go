@@ -3,9 +3,22 @@ package pdf
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/opensourceways/gofpdf"
+
+	"github.com/opensourceways/app-cla-server/clatext"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+const (
+	// widthOfContactTitle is the width of the cell which shows the title of contact item.
+	widthOfContactTitle = 50
+
+	// gapOfSignatureItems is the gap between the signature items of community and corporation.
+	gapOfSignatureItems = 5
 )
 
 type fontInfo struct {
@@ -20,6 +33,7 @@ type corpSigningPDF struct {
 	declaration *template.Template
 	gh          float64
 
+	headerFont    fontInfo
 	footerFont    fontInfo
 	titleFont     fontInfo
 	welcomeFont   fontInfo
@@ -29,24 +43,47 @@ type corpSigningPDF struct {
 	urlFont       fontInfo
 	signatureFont fontInfo
 
+	// fontStyles is the styles of each UTF-8 font which are declared.
+	// The core fonts support all the styles.
+	fontStyles map[string]map[string]bool
+
 	subtitle     string
 	footerNumber func(int) string
 
 	signatureItems [][]string
 	signatureDate  string
-	newPDF         func() *gofpdf.Fpdf
+
+	// checkbox is the text of checkbox field which is checked(true) or not(false)
+	checkbox map[bool]string
+
+	receipt     receiptTexts
+	counterSign counterSignTexts
+	amendment   amendmentTexts
+
+	newPDF func() *gofpdf.Fpdf
+
+	// layout is the layout customized by the owner of link, it is nil by default.
+	layout *pdfLayout
 }
 
 func (this *corpSigningPDF) begin() *gofpdf.Fpdf {
 	pdf := this.newPDF()
 
+	this.setHeader(pdf)
+
 	pdf.SetFooterFunc(func() {
-		// Position at 1.5 cm from bottom
-		pdf.SetY(-15)
-		// Arial italic 8
-		pdf.SetFont(this.footerFont.font, "I", this.footerFont.size)
+		// italic 8
+		this.setFontWithStyle(pdf, this.footerFont.font, "I", this.footerFont.size)
 		// Text color in gray
 		pdf.SetTextColor(128, 128, 128)
+
+		if s := this.footerText(); s != "" {
+			pdf.SetY(-20)
+			pdf.CellFormat(0, 5, s, "", 1, "C", false, 0, "")
+		}
+
+		// Position at 1.5 cm from bottom
+		pdf.SetY(-15)
 		// Page number
 		pdf.CellFormat(
 			0, 10, this.footerNumber(pdf.PageNo()),
@@ -57,6 +94,16 @@ func (this *corpSigningPDF) begin() *gofpdf.Fpdf {
 	return pdf
 }
 
+func (this *corpSigningPDF) setHeader(pdf *gofpdf.Fpdf) {
+	if this.layout != nil && len(this.layout.logo) > 0 {
+		this.layout.registerLogo(pdf)
+	}
+
+	pdf.SetHeaderFunc(func() {
+		this.header(pdf)
+	})
+}
+
 func (this *corpSigningPDF) end(pdf *gofpdf.Fpdf, path string) error {
 	if pdf.Err() {
 		return fmt.Errorf("Failed to geneate pdf: %s", pdf.Error().Error())
@@ -65,14 +112,16 @@ func (this *corpSigningPDF) end(pdf *gofpdf.Fpdf, path string) error {
 	return pdf.OutputFileAndClose(path)
 }
 
-func (this *corpSigningPDF) firstPage(pdf *gofpdf.Fpdf, title string) {
-	pdf.AddPage()
+func (this *corpSigningPDF) title(pdf *gofpdf.Fpdf, title string) {
+	this.titleWithSubtitle(pdf, title, this.subtitle)
+}
 
+func (this *corpSigningPDF) titleWithSubtitle(pdf *gofpdf.Fpdf, title, subtitle string) {
 	setFont(pdf, this.titleFont)
 
 	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
 
-	pdf.CellFormat(0, 5, this.subtitle, "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, subtitle, "", 1, "C", false, 0, "")
 
 	pdf.Ln(-1)
 }
@@ -98,24 +147,44 @@ func (this *corpSigningPDF) welcome(pdf *gofpdf.Fpdf, org, email string) {
 	multlines(pdf, this.gh, buf.String())
 }
 
-func (this *corpSigningPDF) contact(pdf *gofpdf.Fpdf, items map[string]string, orders []string, titles map[string]string) {
-	gh := this.gh
+func (this *corpSigningPDF) contact(pdf *gofpdf.Fpdf, items map[string]string, fields []models.CLAField) {
+	setFont(pdf, this.contactFont)
 
-	f := func(title, value string) {
-		pdf.CellFormat(50, gh, fmt.Sprintf("%s:", title), "", 0, "R", false, 0, "")
+	for i := range fields {
+		item := &fields[i]
+		v := items[item.ID]
 
-		pdf.Cell(2, gh, " ")
+		switch item.Type {
+		case dbmodels.FieldTypeURL:
+			this.contactItem(pdf, item.Title, v, v)
 
-		pdf.MultiCell(130, gh, value, "B", "L", false)
+		case dbmodels.FieldTypeCheckbox:
+			this.contactItem(pdf, item.Title, this.checkbox[models.IsFieldChecked(v)], "")
 
-		pdf.Ln(-1)
+		default:
+			this.contactItem(pdf, item.Title, v, "")
+		}
 	}
+}
 
-	setFont(pdf, this.contactFont)
+// contactItem prints a pair of title and value, the value is a link if link is not empty.
+func (this *corpSigningPDF) contactItem(pdf *gofpdf.Fpdf, title, value, link string) {
+	gh := this.gh
+
+	// the width of value which leaves a space at the end of line
+	w := contentWidth(pdf) - widthOfContactTitle - 10
+
+	pdf.CellFormat(widthOfContactTitle, gh, fmt.Sprintf("%s:", title), "", 0, "R", false, 0, "")
 
-	for _, i := range orders {
-		f(titles[i], items[i])
+	pdf.Cell(2, gh, " ")
+
+	if link != "" && pdf.GetStringWidth(value) <= w {
+		pdf.CellFormat(w, gh, value, "B", 1, "L", false, 0, link)
+	} else {
+		pdf.MultiCell(w, gh, value, "B", "L", false)
 	}
+
+	pdf.Ln(-1)
 }
 
 func (this *corpSigningPDF) declare(pdf *gofpdf.Fpdf) {
@@ -131,9 +200,15 @@ func (this *corpSigningPDF) declare(pdf *gofpdf.Fpdf) {
 	multlines(pdf, this.gh, buf.String())
 }
 
-func (this *corpSigningPDF) cla(pdf *gofpdf.Fpdf, content string) {
+func (this *corpSigningPDF) cla(pdf *gofpdf.Fpdf, format, content string) {
 	setFont(pdf, this.claFont)
-	multlines(pdf, this.gh, content)
+
+	if format == "" || format == clatext.FormatPlain {
+		multlines(pdf, this.gh, content)
+		return
+	}
+
+	this.claDocument(pdf, clatext.Parse(format, content))
 }
 
 func (this *corpSigningPDF) projectURL(pdf *gofpdf.Fpdf, url string) {
@@ -164,11 +239,11 @@ func (this *corpSigningPDF) genSignatureItems(pdf *gofpdf.Fpdf, items [][]string
 	pdf.AddPage()
 	setFont(pdf, this.signatureFont)
 
-	w := 92.5
+	w := widthOfSignatureItem(pdf)
 	gh := this.gh
 
 	pdf.CellFormat(w, gh, items[0][0], "", 0, "C", false, 0, "")
-	pdf.Cell(5, gh, "")
+	pdf.Cell(gapOfSignatureItems, gh, "")
 
 	pdf.CellFormat(w, gh, items[0][1], "", 1, "C", false, 0, "")
 	pdf.Ln(10)
@@ -179,7 +254,7 @@ func (this *corpSigningPDF) genSignatureItems(pdf *gofpdf.Fpdf, items [][]string
 }
 
 func addSignatureItem(pdf *gofpdf.Fpdf, gh float64, ltitle, rtitle, lvalue, rvalue string) {
-	w := 92.5
+	w := widthOfSignatureItem(pdf)
 
 	b := ""
 	if ltitle != "" {
@@ -187,12 +262,12 @@ func addSignatureItem(pdf *gofpdf.Fpdf, gh float64, ltitle, rtitle, lvalue, rval
 	}
 
 	pdf.Cell(w, gh, ltitle)
-	pdf.Cell(5, gh, "")
+	pdf.Cell(gapOfSignatureItems, gh, "")
 	pdf.CellFormat(w, gh, rtitle, "", 1, "L", false, 0, "")
 	pdf.Ln(-1)
 
 	pdf.CellFormat(w, gh, lvalue, b, 0, "L", false, 0, "")
-	pdf.Cell(5, gh, "")
+	pdf.Cell(gapOfSignatureItems, gh, "")
 	pdf.CellFormat(w, gh, rvalue, b, 1, "L", false, 0, "")
 	pdf.Ln(-1)
 }
@@ -207,3 +282,28 @@ func multlines(pdf *gofpdf.Fpdf, gh float64, content string) {
 func setFont(pdf *gofpdf.Fpdf, font fontInfo) {
 	pdf.SetFont(font.font, "", font.size)
 }
+
+// setFontWithStyle sets the font with the style which falls back to the regular
+// one if the font doesn't support it. The underline is always supported.
+func (this *corpSigningPDF) setFontWithStyle(pdf *gofpdf.Fpdf, font, style string, size float64) {
+	underline := ""
+	if strings.HasSuffix(style, "U") {
+		style, underline = strings.TrimSuffix(style, "U"), "U"
+	}
+
+	if !coreFonts[strings.ToLower(font)] && !this.fontStyles[font][style] {
+		style = ""
+	}
+
+	pdf.SetFont(font, style+underline, size)
+}
+
+func contentWidth(pdf *gofpdf.Fpdf) float64 {
+	w, _ := pdf.GetPageSize()
+	lm, _, rm, _ := pdf.GetMargins()
+	return w - lm - rm
+}
+
+func widthOfSignatureItem(pdf *gofpdf.Fpdf) float64 {
+	return (contentWidth(pdf) - gapOfSignatureItems) / 2
+}
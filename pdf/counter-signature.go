@@ -0,0 +1,167 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/opensourceways/gofpdf"
+
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+const (
+	nameOfSignatureImage = "signature-image"
+
+	// heightOfSignatureImage is the max height of the image of signature in mm.
+	heightOfSignatureImage = 15
+)
+
+// CheckSignatureImage checks the image of signature which is printed on the counter-signature page.
+func CheckSignatureImage(data []byte) error {
+	t, err := imageType(data)
+	if err != nil {
+		return fmt.Errorf("the signature should be a png or jpeg image")
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	info := registerSignatureImage(pdf, data, t)
+	if pdf.Err() {
+		return fmt.Errorf("invalid signature image: %s", pdf.Error().Error())
+	}
+	if info == nil || info.Width() <= 0 || info.Height() <= 0 {
+		return fmt.Errorf("invalid signature image: the size of image is 0")
+	}
+	return nil
+}
+
+// CheckCorpPDF checks whether the counter-signature page can be appended to
+// the pdf uploaded by corporation.
+func CheckCorpPDF(data []byte) error {
+	r, err := newPDFReader(data)
+	if err != nil {
+		return err
+	}
+
+	pages, err := r.pages()
+	if err != nil {
+		return err
+	}
+	if len(pages) == 0 {
+		return fmt.Errorf("there is no page")
+	}
+	return nil
+}
+
+// CounterSignCorporationPDF appends the counter-signature page to the pdf uploaded by corporation.
+// The image of signature is optional, the name of signatory is printed instead if it is empty.
+func (this *pdfGenerator) CounterSignCorporationPDF(linkID string, orgInfo *models.OrgInfo, signing *models.CorporationSigningBasicInfo, cs *models.CounterSignature, signature, doc []byte) ([]byte, error) {
+	if err := CheckCorpPDF(doc); err != nil {
+		return nil, fmt.Errorf("invalid pdf of corporation: %s", err.Error())
+	}
+
+	c, err := this.generatorOfLink(linkID, signing.CLALanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	imgType := ""
+	if len(signature) > 0 {
+		if imgType, err = imageType(signature); err != nil {
+			return nil, err
+		}
+	}
+
+	pdf := c.newPDF()
+	c.setHeader(pdf)
+	c.counterSignaturePage(pdf, orgInfo.OrgAlias, signing, cs, signature, imgType)
+
+	if pdf.Err() {
+		return nil, fmt.Errorf("failed to generate counter-signature page: %s", pdf.Error().Error())
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate counter-signature page: %s", err.Error())
+	}
+
+	page, err := loadSignaturePage(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid counter-signature page: %s", err.Error())
+	}
+
+	v, err := appendSignaturePage(doc, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append counter-signature page: %s", err.Error())
+	}
+	return v, nil
+}
+
+func (this *corpSigningPDF) counterSignaturePage(pdf *gofpdf.Fpdf, org string, signing *models.CorporationSigningBasicInfo, cs *models.CounterSignature, signature []byte, imgType string) {
+	t := &this.counterSign
+
+	pdf.AddPage()
+
+	this.titleWithSubtitle(pdf, org, t.Title)
+
+	setFont(pdf, this.welcomeFont)
+	multlines(pdf, this.gh, t.Statement)
+
+	setFont(pdf, this.contactFont)
+	this.contactItem(pdf, t.Corporation, signing.CorporationName, "")
+	this.contactItem(pdf, t.SigningDate, signing.Date, "")
+	this.contactItem(pdf, t.Digest, cs.Digest, "")
+	pdf.Ln(-1)
+
+	setFont(pdf, this.signatureFont)
+	if len(signature) > 0 {
+		this.signatureImage(pdf, t.Signature, signature, imgType)
+	} else {
+		f := this.signatureFont
+		this.setFontWithStyle(pdf, f.font, "I", f.size)
+		this.contactItem(pdf, t.Signature, cs.Name, "")
+		setFont(pdf, f)
+	}
+
+	this.contactItem(pdf, t.Signatory, cs.Name, "")
+	this.contactItem(pdf, t.Position, cs.Title, "")
+	this.contactItem(pdf, t.Date, cs.Date, "")
+}
+
+// signatureImage prints the image of signature in the place of value of contact item.
+func (this *corpSigningPDF) signatureImage(pdf *gofpdf.Fpdf, title string, img []byte, imgType string) {
+	lm, _, _, _ := pdf.GetMargins()
+	x := lm + widthOfContactTitle + 2
+	y := pdf.GetY()
+	w := contentWidth(pdf) - widthOfContactTitle - 10
+
+	pdf.CellFormat(
+		widthOfContactTitle, heightOfSignatureImage, fmt.Sprintf("%s:", title),
+		"", 0, "RB", false, 0, "",
+	)
+
+	info := registerSignatureImage(pdf, img, imgType)
+	if info != nil && info.Width() > 0 && info.Height() > 0 {
+		h := float64(heightOfSignatureImage)
+		iw := h * info.Width() / info.Height()
+		if iw > w {
+			iw, h = w, w*info.Height()/info.Width()
+		}
+
+		pdf.ImageOptions(
+			nameOfSignatureImage, x, y+heightOfSignatureImage-h, iw, h, false,
+			gofpdf.ImageOptions{ImageType: imgType}, 0, "",
+		)
+	}
+
+	y += heightOfSignatureImage
+	pdf.Line(x, y, x+w, y)
+	pdf.SetY(y)
+	pdf.Ln(-1)
+}
+
+func registerSignatureImage(pdf *gofpdf.Fpdf, img []byte, imgType string) *gofpdf.ImageInfoType {
+	return pdf.RegisterImageOptionsReader(
+		nameOfSignatureImage, gofpdf.ImageOptions{ImageType: imgType},
+		bytes.NewReader(img),
+	)
+}
@@ -0,0 +1,30 @@
+package pdf
+
+import (
+	"testing"
+)
+
+func TestCheckMalformedCorpPDF(t *testing.T) {
+	bomb, err := newTestPDFWithBomb()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := map[string][]byte{
+		"repeated kids": newTestPDFWithRepeatedKids(30),
+		"stream bomb":   bomb,
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			if err := CheckCorpPDF(doc); err == nil {
+				t.Fatal("CheckCorpPDF: expect an error")
+			}
+
+			g := &pdfGenerator{}
+			if _, err := g.CounterSignCorporationPDF("", nil, nil, nil, nil, doc); err == nil {
+				t.Fatal("CounterSignCorporationPDF: expect an error")
+			}
+		})
+	}
+}
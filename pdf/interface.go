@@ -2,7 +2,6 @@ package pdf
 
 import (
 	"fmt"
-	"text/template"
 
 	"github.com/opensourceways/gofpdf"
 
@@ -13,26 +12,34 @@ import (
 type IPDFGenerator interface {
 	LangSupported() map[string]bool
 	GetBlankSignaturePath(string) string
-
-	GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claFields []models.CLAField) (string, error)
+	ValidateLayout(layout, logo []byte) error
+	ContactValue(claLang string, field *models.CLAField, value string) string
+
+	GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo) (string, error)
+	PreviewCorporationSigning(layout, logo []byte, linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo) (string, error)
+	GenPDFForIndividualSigning(linkID, claText string, orgInfo *models.OrgInfo, signing *models.IndividualSigning, claInfo *models.CLAInfo) (string, error)
+	CounterSignCorporationPDF(linkID string, orgInfo *models.OrgInfo, signing *models.CorporationSigningBasicInfo, cs *models.CounterSignature, signature, doc []byte) ([]byte, error)
+	GenPDFForCorpSigningAmendment(linkID string, orgInfo *models.OrgInfo, v *models.AmendedCorpSigning) ([]byte, error)
 }
 
 var generator *pdfGenerator
 
-func InitPDFGenerator(pythonBin, pdfOutDir, pdfOrgSigDir string) error {
+// InitPDFGenerator initializes the generator with the language profiles
+// which are loaded from the config file.
+func InitPDFGenerator(pdfOutDir, pdfOrgSigDir, languagesConfigFile string) error {
+	cfg, err := loadPDFConfig(languagesConfigFile)
+	if err != nil {
+		return err
+	}
+
 	generator = &pdfGenerator{
-		pythonBin:    pythonBin,
 		pdfOutDir:    pdfOutDir,
 		pdfOrgSigDir: pdfOrgSigDir,
 	}
 
 	corp := []*corpSigningPDF{}
-	m := []func() (*corpSigningPDF, error){
-		newGeneratorForEnglish,
-		newGeneratorForChinese,
-	}
-	for _, f := range m {
-		c, err := f()
+	for i := range cfg.Languages {
+		c, err := newCorpSigningPDF(cfg.FontDir, &cfg.Languages[i])
 		if err != nil {
 			return err
 		}
@@ -52,111 +59,72 @@ func GetPDFGenerator() IPDFGenerator {
 	return generator
 }
 
-func newGeneratorForEnglish() (*corpSigningPDF, error) {
-	lang := "english"
-
-	welTemp, err := newWelcomeTmpl(lang)
+func newCorpSigningPDF(fontDir string, p *langProfile) (*corpSigningPDF, error) {
+	welTemp, err := util.NewTemplate("wel", p.Templates.Welcome)
 	if err != nil {
 		return nil, err
 	}
 
-	declTemp, err := newDeclTmpl(lang)
+	declTemp, err := util.NewTemplate("decl", p.Templates.Declaration)
 	if err != nil {
 		return nil, err
 	}
 
+	fontStyles := map[string]map[string]bool{}
+	for _, item := range p.Fonts {
+		if fontStyles[item.Family] == nil {
+			fontStyles[item.Family] = map[string]bool{}
+		}
+		fontStyles[item.Family][item.Style] = true
+	}
+
+	s := &p.Styles
+	page := p.Page
+	fonts := p.Fonts
+	footerNumber := p.Texts.FooterNumber
+
 	return &corpSigningPDF{
-		language:    lang,
+		language:    p.Language,
 		welcomeTemp: welTemp,
 		declaration: declTemp,
-		gh:          5.0,
-
-		footerFont:    fontInfo{font: "Arial", size: 8},
-		titleFont:     fontInfo{font: "Arial", size: 12},
-		welcomeFont:   fontInfo{font: "Times", size: 12},
-		contactFont:   fontInfo{font: "NotoSansSC-Regular", size: 12},
-		declareFont:   fontInfo{font: "Times", size: 12},
-		claFont:       fontInfo{font: "Times", size: 12},
-		urlFont:       fontInfo{font: "Times", size: 12},
-		signatureFont: fontInfo{font: "Arial", size: 12},
-
-		subtitle: "Software Grant and Corporate Contributor License Agreement (\"Agreement\")",
-
-		footerNumber: func(num int) string { return fmt.Sprintf("Page %d", num) },
-
-		signatureItems: [][]string{
-			{"Community Sign", "Corporation Sign"},
-			{"Signature", "Signature and Seal"},
-			{"Title", "Title"},
-			{"Community", "Corporation"},
-		},
-		signatureDate: "Date",
+		gh:          page.LineHeight,
 
-		newPDF: func() *gofpdf.Fpdf {
-			pdf := gofpdf.New("P", "mm", "A4", "./conf/pdf-font") // 210mm x 297mm
-			pdf.AddUTF8Font("NotoSansSC-Regular", "", "NotoSansSC-Regular.ttf")
-			return pdf
-		},
-	}, nil
-}
+		headerFont:    toFontInfo(s.Header),
+		footerFont:    toFontInfo(s.Footer),
+		titleFont:     toFontInfo(s.Title),
+		welcomeFont:   toFontInfo(s.Welcome),
+		contactFont:   toFontInfo(s.Contact),
+		declareFont:   toFontInfo(s.Declaration),
+		claFont:       toFontInfo(s.CLA),
+		urlFont:       toFontInfo(s.URL),
+		signatureFont: toFontInfo(s.Signature),
 
-func newGeneratorForChinese() (*corpSigningPDF, error) {
-	lang := "chinese"
+		fontStyles: fontStyles,
 
-	welTemp, err := newWelcomeTmpl(lang)
-	if err != nil {
-		return nil, err
-	}
+		subtitle: p.Texts.Subtitle,
 
-	declTemp, err := newDeclTmpl(lang)
-	if err != nil {
-		return nil, err
-	}
+		footerNumber: func(num int) string { return fmt.Sprintf(footerNumber, num) },
 
-	return &corpSigningPDF{
-		language:    lang,
-		welcomeTemp: welTemp,
-		declaration: declTemp,
-		gh:          5.0,
-
-		footerFont:    fontInfo{font: "NotoSansSC-Regular", size: 8},
-		titleFont:     fontInfo{font: "NotoSansSC-Regular", size: 12},
-		welcomeFont:   fontInfo{font: "NotoSansSC-Regular", size: 12},
-		contactFont:   fontInfo{font: "NotoSansSC-Regular", size: 12},
-		declareFont:   fontInfo{font: "NotoSansSC-Regular", size: 12},
-		claFont:       fontInfo{font: "NotoSansSC-Regular", size: 12},
-		urlFont:       fontInfo{font: "Times", size: 12},
-		signatureFont: fontInfo{font: "NotoSansSC-Regular", size: 12},
-
-		subtitle: "软件授权和企业贡献者许可协议 (\"协议\")",
-
-		footerNumber: func(num int) string { return fmt.Sprintf("%d 页", num) },
-
-		signatureItems: [][]string{
-			{"社区签署", "企业签署"},
-			{"签名", "签名(加盖公章)"},
-			{"职位", "职位"},
-			{"社区名称", "企业名称"},
-		},
-		signatureDate: "日期",
+		signatureItems: p.Texts.SignatureItems,
+		signatureDate:  p.Texts.SignatureDate,
+
+		checkbox: map[bool]string{true: p.Texts.Checked, false: p.Texts.Unchecked},
+
+		receipt:     p.Texts.Receipt,
+		counterSign: p.Texts.CounterSign,
+		amendment:   p.Texts.Amendment,
 
 		newPDF: func() *gofpdf.Fpdf {
-			pdf := gofpdf.New("P", "mm", "A4", "./conf/pdf-font") // 210mm x 297mm
-			pdf.AddUTF8Font("NotoSansSC-Regular", "", "NotoSansSC-Regular.ttf")
-			pdf.AddUTF8Font("NotoSansSC-Regular", "I", "NotoSansSC-Regular.ttf")
+			pdf := gofpdf.New(page.Orientation, "mm", page.Size, fontDir)
+			pdf.SetMargins(page.LeftMargin, page.TopMargin, page.RightMargin)
+			for _, item := range fonts {
+				pdf.AddUTF8Font(item.Family, item.Style, item.File)
+			}
 			return pdf
 		},
 	}, nil
 }
 
-func newWelcomeTmpl(lang string) (*template.Template, error) {
-	return util.NewTemplate("wel", pathOfTemplate("welcome", lang))
-}
-
-func newDeclTmpl(lang string) (*template.Template, error) {
-	return util.NewTemplate("decl", pathOfTemplate("declaration", lang))
-}
-
-func pathOfTemplate(part, lang string) string {
-	return fmt.Sprintf("./conf/pdf_template_corporation/%s_%s.tmpl", part, lang)
+func toFontInfo(f font) fontInfo {
+	return fontInfo{font: f.Font, size: f.Size}
 }
@@ -0,0 +1,412 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/opensourceways/gofpdf"
+	"sigs.k8s.io/yaml"
+)
+
+// the sections of corporation signing pdf before the signature page.
+const (
+	sectionTitle       = "title"
+	sectionWelcome     = "welcome"
+	sectionContact     = "contact"
+	sectionDeclaration = "declaration"
+	sectionCLA         = "cla"
+	sectionProjectURL  = "project_url"
+)
+
+// the parts of corporation signing pdf whose font can be customized.
+const (
+	partHeader      = "header"
+	partFooter      = "footer"
+	partTitle       = "title"
+	partWelcome     = "welcome"
+	partContact     = "contact"
+	partDeclaration = "declaration"
+	partCLA         = "cla"
+	partURL         = "url"
+	partSignature   = "signature"
+)
+
+const (
+	nameOfLogo = "layout-logo"
+
+	maxLengthOfLayoutText = 200
+	minMarginOfLayout     = 5
+	maxMarginOfLayout     = 50
+	defaultWidthOfLogo    = 30
+	maxWidthOfLogo        = 80
+	minFontSizeOfLayout   = 6
+	maxFontSizeOfLayout   = 36
+)
+
+var defaultSections = []string{
+	sectionTitle, sectionWelcome, sectionContact,
+	sectionDeclaration, sectionCLA, sectionProjectURL,
+}
+
+// pdfLayout is the layout of corporation signing pdf uploaded by the owner of link.
+// The signature page is always the last page, because the org signature is merged into it.
+type pdfLayout struct {
+	// Sections is the order of sections, the one which is not listed is omitted.
+	// The contact and cla are required.
+	Sections []string      `json:"sections"`
+	Header   layoutHeader  `json:"header"`
+	Footer   layoutFooter  `json:"footer"`
+	Margins  layoutMargins `json:"margins"`
+
+	// Fonts is the fonts of parts for each language, such as
+	// fonts.english.title. The font must be supported by the language.
+	Fonts map[string]map[string]font `json:"fonts"`
+
+	logo     []byte
+	logoType string
+}
+
+type layoutHeader struct {
+	Text string `json:"text"`
+	// LogoAlign is one of left, center and right.
+	LogoAlign string `json:"logo_align"`
+	// LogoWidth is the width of logo in mm.
+	LogoWidth float64 `json:"logo_width"`
+}
+
+type layoutFooter struct {
+	Text string `json:"text"`
+}
+
+// layoutMargins overrides the margins of language profile if it is not 0.
+type layoutMargins struct {
+	Left  float64 `json:"left"`
+	Top   float64 `json:"top"`
+	Right float64 `json:"right"`
+}
+
+// parseLayout parses the layout template and checks it without the fonts
+// which depend on the language profiles.
+func parseLayout(layout, logo []byte) (*pdfLayout, error) {
+	v := new(pdfLayout)
+	if err := yaml.UnmarshalStrict(layout, v); err != nil {
+		return nil, fmt.Errorf("invalid yaml: %s", err.Error())
+	}
+
+	if err := v.validate(); err != nil {
+		return nil, err
+	}
+
+	if len(logo) > 0 {
+		if err := v.setLogo(logo); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+func (this *pdfLayout) validate() error {
+	if len(this.Sections) == 0 {
+		this.Sections = defaultSections
+	}
+
+	known := map[string]bool{}
+	for _, item := range defaultSections {
+		known[item] = true
+	}
+
+	m := map[string]bool{}
+	for _, item := range this.Sections {
+		if !known[item] {
+			return fmt.Errorf("unknown section: %s", item)
+		}
+		if m[item] {
+			return fmt.Errorf("duplicate section: %s", item)
+		}
+		m[item] = true
+	}
+
+	for _, item := range []string{sectionContact, sectionCLA} {
+		if !m[item] {
+			return fmt.Errorf("missing section: %s", item)
+		}
+	}
+
+	for _, item := range []string{this.Header.Text, this.Footer.Text} {
+		if len([]rune(item)) > maxLengthOfLayoutText {
+			return fmt.Errorf("the text of header or footer is longer than %d", maxLengthOfLayoutText)
+		}
+	}
+
+	switch this.Header.LogoAlign {
+	case "":
+		this.Header.LogoAlign = "left"
+	case "left", "center", "right":
+	default:
+		return fmt.Errorf("invalid align of logo: %s", this.Header.LogoAlign)
+	}
+
+	if this.Header.LogoWidth == 0 {
+		this.Header.LogoWidth = defaultWidthOfLogo
+	}
+	if this.Header.LogoWidth < 0 || this.Header.LogoWidth > maxWidthOfLogo {
+		return fmt.Errorf("the width of logo should be in (0, %d]", maxWidthOfLogo)
+	}
+
+	for _, item := range []float64{this.Margins.Left, this.Margins.Top, this.Margins.Right} {
+		if item != 0 && (item < minMarginOfLayout || item > maxMarginOfLayout) {
+			return fmt.Errorf(
+				"the margin should be in [%d, %d]", minMarginOfLayout, maxMarginOfLayout,
+			)
+		}
+	}
+
+	for lang, fonts := range this.Fonts {
+		for part, item := range fonts {
+			if !isFontPart(part) {
+				return fmt.Errorf("unknown part: %s of font of language: %s", part, lang)
+			}
+			if item.Font == "" {
+				return fmt.Errorf("missing font of part: %s of language: %s", part, lang)
+			}
+			if item.Size < minFontSizeOfLayout || item.Size > maxFontSizeOfLayout {
+				return fmt.Errorf(
+					"the size of font should be in [%d, %d]", minFontSizeOfLayout, maxFontSizeOfLayout,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (this *pdfLayout) setLogo(logo []byte) error {
+	t, err := imageType(logo)
+	if err != nil {
+		return fmt.Errorf("the logo should be a png or jpeg image")
+	}
+	this.logoType = t
+	this.logo = logo
+
+	// check that the image can be embedded
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	info := this.registerLogo(pdf)
+	if pdf.Err() {
+		return fmt.Errorf("invalid logo: %s", pdf.Error().Error())
+	}
+	if info == nil || info.Width() <= 0 || info.Height() <= 0 {
+		return fmt.Errorf("invalid logo: the size of image is 0")
+	}
+
+	return nil
+}
+
+func (this *pdfLayout) registerLogo(pdf *gofpdf.Fpdf) *gofpdf.ImageInfoType {
+	return pdf.RegisterImageOptionsReader(
+		nameOfLogo, gofpdf.ImageOptions{ImageType: this.logoType},
+		bytes.NewReader(this.logo),
+	)
+}
+
+// imageType returns the type of image which can be embedded in the pdf.
+func imageType(data []byte) (string, error) {
+	switch http.DetectContentType(data) {
+	case "image/png":
+		return "PNG", nil
+	case "image/jpeg":
+		return "JPG", nil
+	default:
+		return "", fmt.Errorf("unsupported image")
+	}
+}
+
+// checkFonts checks that the fonts are supported by the language profiles.
+func (this *pdfLayout) checkFonts(corps []*corpSigningPDF) error {
+	for lang, fonts := range this.Fonts {
+		var c *corpSigningPDF
+		for _, item := range corps {
+			if item.language == strings.ToLower(lang) {
+				c = item
+				break
+			}
+		}
+		if c == nil {
+			return fmt.Errorf("unsupported language: %s", lang)
+		}
+
+		for part, item := range fonts {
+			if !c.isFontSupported(item.Font) {
+				return fmt.Errorf(
+					"the font: %s of part: %s is not supported by language: %s",
+					item.Font, part, lang,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isFontPart(part string) bool {
+	switch part {
+	case partHeader, partFooter, partTitle, partWelcome, partContact,
+		partDeclaration, partCLA, partURL, partSignature:
+		return true
+	}
+	return false
+}
+
+// fontOfPart returns the font of part which can be overridden by layout.
+func (this *corpSigningPDF) fontOfPart(part string) *fontInfo {
+	switch part {
+	case partHeader:
+		return &this.headerFont
+	case partFooter:
+		return &this.footerFont
+	case partTitle:
+		return &this.titleFont
+	case partWelcome:
+		return &this.welcomeFont
+	case partContact:
+		return &this.contactFont
+	case partDeclaration:
+		return &this.declareFont
+	case partCLA:
+		return &this.claFont
+	case partURL:
+		return &this.urlFont
+	case partSignature:
+		return &this.signatureFont
+	}
+	return nil
+}
+
+func (this *corpSigningPDF) isFontSupported(font string) bool {
+	return coreFonts[strings.ToLower(font)] || this.fontStyles[font][""]
+}
+
+// withLayout returns a copy of generator which applies the layout.
+func (this *corpSigningPDF) withLayout(layout *pdfLayout) *corpSigningPDF {
+	c := *this
+	c.layout = layout
+
+	for lang, fonts := range layout.Fonts {
+		if strings.ToLower(lang) != c.language {
+			continue
+		}
+
+		for part, item := range fonts {
+			// the font may be removed from the language profile after the layout was uploaded
+			if f := c.fontOfPart(part); f != nil && c.isFontSupported(item.Font) {
+				*f = toFontInfo(item)
+			}
+		}
+	}
+
+	m := layout.Margins
+	newPDF := this.newPDF
+	c.newPDF = func() *gofpdf.Fpdf {
+		pdf := newPDF()
+
+		lm, tm, rm, _ := pdf.GetMargins()
+		if m.Left > 0 {
+			lm = m.Left
+		}
+		if m.Top > 0 {
+			tm = m.Top
+		}
+		if m.Right > 0 {
+			rm = m.Right
+		}
+		pdf.SetMargins(lm, tm, rm)
+
+		return pdf
+	}
+
+	return &c
+}
+
+func (this *corpSigningPDF) sections() []string {
+	if this.layout == nil {
+		return defaultSections
+	}
+	return this.layout.Sections
+}
+
+// header prints the logo and the text of header of layout on each page.
+func (this *corpSigningPDF) header(pdf *gofpdf.Fpdf) {
+	l := this.layout
+	if l == nil || (l.Header.Text == "" && len(l.logo) == 0) {
+		return
+	}
+
+	lm, tm, rm, _ := pdf.GetMargins()
+	pw, _ := pdf.GetPageSize()
+	gh := this.gh
+
+	h := 0.0
+	textAlign := "C"
+
+	if len(l.logo) > 0 {
+		w := l.Header.LogoWidth
+		x := lm
+		switch l.Header.LogoAlign {
+		case "center":
+			x = (pw - w) / 2
+		case "right":
+			x = pw - rm - w
+			textAlign = "L"
+		default:
+			textAlign = "R"
+		}
+
+		info := pdf.GetImageInfo(nameOfLogo)
+		if info == nil {
+			info = l.registerLogo(pdf)
+		}
+		if info != nil && info.Width() > 0 {
+			h = w * info.Height() / info.Width()
+			pdf.ImageOptions(
+				nameOfLogo, x, tm, w, h, false,
+				gofpdf.ImageOptions{ImageType: l.logoType}, 0, "",
+			)
+		}
+	}
+
+	if l.Header.Text != "" {
+		setFont(pdf, this.headerFont)
+		pdf.SetTextColor(80, 80, 80)
+
+		if textAlign == "C" {
+			// the text is under the logo which is in the center
+			pdf.SetXY(lm, tm+h)
+			pdf.CellFormat(0, gh, l.Header.Text, "", 0, "C", false, 0, "")
+			h += gh
+		} else {
+			if h < gh {
+				h = gh
+			}
+			pdf.SetXY(lm, tm)
+			pdf.CellFormat(0, h, l.Header.Text, "", 0, textAlign+"M", false, 0, "")
+		}
+
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	y := tm + h + 1
+	pdf.SetDrawColor(160, 160, 160)
+	pdf.Line(lm, y, pw-rm, y)
+	pdf.SetDrawColor(0, 0, 0)
+
+	pdf.SetXY(lm, y+gh)
+}
+
+func (this *corpSigningPDF) footerText() string {
+	if this.layout == nil {
+		return ""
+	}
+	return this.layout.Footer.Text
+}
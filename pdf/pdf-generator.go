@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/opensourceways/app-cla-server/dbmodels"
 	"github.com/opensourceways/app-cla-server/models"
 	"github.com/opensourceways/app-cla-server/util"
 )
@@ -16,7 +16,6 @@ import (
 type pdfGenerator struct {
 	pdfOutDir    string
 	pdfOrgSigDir string
-	pythonBin    string
 	corp         []*corpSigningPDF
 }
 
@@ -40,28 +39,84 @@ func (this *pdfGenerator) generator(claLang string) *corpSigningPDF {
 	}
 	return nil
 }
-func (this *pdfGenerator) GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claFields []models.CLAField) (string, error) {
+
+// ValidateLayout checks the layout template and logo uploaded by the owner of link.
+func (this *pdfGenerator) ValidateLayout(layout, logo []byte) error {
+	v, err := parseLayout(layout, logo)
+	if err != nil {
+		return err
+	}
+
+	return v.checkFonts(this.corp)
+}
+
+// generatorOfLink returns the generator which applies the layout of link if it is customized.
+func (this *pdfGenerator) generatorOfLink(linkID, claLang string) (*corpSigningPDF, error) {
+	corp := this.generator(claLang)
+	if corp == nil {
+		return nil, fmt.Errorf("unknown cla language:%s", claLang)
+	}
+
+	v, merr := models.GetPDFLayout(linkID)
+	if merr != nil {
+		if merr.IsErrorOf(models.ErrNoPDFLayout) {
+			return corp, nil
+		}
+		return nil, fmt.Errorf("failed to get pdf layout: %s", merr.Error())
+	}
+
+	layout, err := parseLayout([]byte(v.Layout), v.Logo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pdf layout: %s", err.Error())
+	}
+
+	return corp.withLayout(layout), nil
+}
+
+func (this *pdfGenerator) GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo) (string, error) {
+	corp, err := this.generatorOfLink(linkID, signing.CLALanguage)
+	if err != nil {
+		return "", err
+	}
+
+	return this.genPDFForCorporationSigning(corp, linkID, orgSignatureFile, claFile, orgInfo, signing, claInfo)
+}
+
+// PreviewCorporationSigning generates the pdf of corporation signing with the layout
+// which is not saved yet, so the owner of link can check it before uploading.
+func (this *pdfGenerator) PreviewCorporationSigning(layout, logo []byte, linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo) (string, error) {
 	corp := this.generator(signing.CLALanguage)
 	if corp == nil {
 		return "", fmt.Errorf("unknown cla language:%s", signing.CLALanguage)
 	}
 
+	v, err := parseLayout(layout, logo)
+	if err != nil {
+		return "", fmt.Errorf("invalid pdf layout: %s", err.Error())
+	}
+
+	return this.genPDFForCorporationSigning(
+		corp.withLayout(v), linkID, orgSignatureFile, claFile, orgInfo, signing, claInfo,
+	)
+}
+
+func (this *pdfGenerator) genPDFForCorporationSigning(corp *corpSigningPDF, linkID, orgSignatureFile, claFile string, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo) (string, error) {
 	tempPdf := util.GenFilePath(this.pdfOutDir, genPDFFileName(linkID, signing.AdminEmail, "_missing_sig"))
-	err := genCorporPDFMissingSig(corp, orgInfo, signing, claFields, claFile, tempPdf)
+	err := genCorporPDFMissingSig(corp, orgInfo, signing, claInfo, claFile, tempPdf)
 	if err != nil {
 		return "", err
 	}
 	defer os.Remove(tempPdf)
 
 	outfile := util.GenFilePath(this.pdfOutDir, genPDFFileName(linkID, signing.AdminEmail, ""))
-	if err := mergeCorporPDFSignaturePage(this.pythonBin, tempPdf, orgSignatureFile, outfile); err != nil {
+	if err := mergeCorporPDFSignaturePage(tempPdf, orgSignatureFile, outfile); err != nil {
 		return "", err
 	}
 
 	return outfile, nil
 }
 
-func genCorporPDFMissingSig(c *corpSigningPDF, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claFields []models.CLAField, claFile, outFile string) error {
+func genCorporPDFMissingSig(c *corpSigningPDF, orgInfo *models.OrgInfo, signing *models.CorporationSigning, claInfo *models.CLAInfo, claFile, outFile string) error {
 	text, err := ioutil.ReadFile(claFile)
 	if err != nil {
 		return fmt.Errorf("failed to read cla file(%s): %s", claFile, err.Error())
@@ -70,15 +125,29 @@ func genCorporPDFMissingSig(c *corpSigningPDF, orgInfo *models.OrgInfo, signing
 	pdf := c.begin()
 
 	// first page
-	c.firstPage(pdf, orgInfo.OrgAlias)
-	c.welcome(pdf, orgInfo.OrgAlias, orgInfo.OrgEmail)
+	pdf.AddPage()
+
+	for _, item := range c.sections() {
+		switch item {
+		case sectionTitle:
+			c.title(pdf, orgInfo.OrgAlias)
 
-	orders, titles := BuildCorpContact(claFields)
-	c.contact(pdf, signing.Info, orders, titles)
+		case sectionWelcome:
+			c.welcome(pdf, orgInfo.OrgAlias, orgInfo.OrgEmail)
 
-	c.declare(pdf)
-	c.cla(pdf, string(text))
-	c.projectURL(pdf, fmt.Sprintf("[1]. %s", orgInfo.ProjectURL()))
+		case sectionContact:
+			c.contact(pdf, signing.Info, BuildCorpContact(claInfo.Fields))
+
+		case sectionDeclaration:
+			c.declare(pdf)
+
+		case sectionCLA:
+			c.cla(pdf, claInfo.CLAFormat, string(text))
+
+		case sectionProjectURL:
+			c.projectURL(pdf, fmt.Sprintf("[1]. %s", orgInfo.ProjectURL()))
+		}
+	}
 
 	// second page
 	c.secondPage(pdf, signing.Date)
@@ -92,24 +161,88 @@ func genCorporPDFMissingSig(c *corpSigningPDF, orgInfo *models.OrgInfo, signing
 	return nil
 }
 
-func mergeCorporPDFSignaturePage(pythonBin, pdfFile, sigFile, outfile string) error {
+// GenPDFForIndividualSigning generates the receipt of individual or employee signing.
+// It applies the layout of link, but the sections of it are fixed.
+func (this *pdfGenerator) GenPDFForIndividualSigning(linkID, claText string, orgInfo *models.OrgInfo, signing *models.IndividualSigning, claInfo *models.CLAInfo) (string, error) {
+	c, err := this.generatorOfLink(linkID, signing.CLALanguage)
+	if err != nil {
+		return "", err
+	}
+
+	outfile := util.GenFilePath(
+		this.pdfOutDir, fmt.Sprintf("%s_%s_receipt.pdf", linkID, signing.SigningID),
+	)
+	if err := genIndividualSigningPDF(c, orgInfo, signing, claInfo, claText, outfile); err != nil {
+		return "", err
+	}
+
+	return outfile, nil
+}
+
+func genIndividualSigningPDF(c *corpSigningPDF, orgInfo *models.OrgInfo, signing *models.IndividualSigning, claInfo *models.CLAInfo, claText, outFile string) error {
+	pdf := c.begin()
+	pdf.AddPage()
+
+	c.titleWithSubtitle(pdf, orgInfo.OrgAlias, c.receipt.Title)
+
+	setFont(pdf, c.contactFont)
+	c.contactItem(pdf, c.receipt.SigningID, signing.SigningID, "")
+	c.contactItem(pdf, c.receipt.Date, signing.Date, "")
+	c.contactItem(pdf, c.receipt.Platform, orgInfo.Platform, "")
+	c.contactItem(pdf, c.receipt.Account, signing.ID, "")
+
+	c.contact(pdf, signing.Info, BuildCorpContact(claInfo.Fields))
+	pdf.Ln(-1)
+
+	c.cla(pdf, claInfo.CLAFormat, claText)
+
+	c.projectURL(pdf, orgInfo.ProjectURL())
+
+	if !util.IsFileNotExist(outFile) {
+		os.Remove(outFile)
+	}
+	if err := c.end(pdf, outFile); err != nil {
+		return fmt.Errorf("generate signing pdf of individual failed: %s", err.Error())
+	}
+	return nil
+}
+
+func mergeCorporPDFSignaturePage(pdfFile, sigFile, outfile string) error {
 	if util.IsFileNotExist(sigFile) {
 		return fmt.Errorf("org signature file(%s) is not exist", sigFile)
 	}
 
-	// merge file
-	cmd := exec.Command(pythonBin, "./util/merge-signature.py", pdfFile, sigFile, outfile)
-	if _, err := cmd.Output(); err != nil {
-		return fmt.Errorf("merge signature page of pdf failed: %s", err.Error())
+	data, err := ioutil.ReadFile(sigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read org signature file(%s): %s", sigFile, err.Error())
+	}
+
+	sig, err := loadSignaturePage(data)
+	if err != nil {
+		return fmt.Errorf("invalid org signature file(%s): %s", sigFile, err.Error())
+	}
+
+	doc, err := ioutil.ReadFile(pdfFile)
+	if err != nil {
+		return fmt.Errorf("failed to read pdf file(%s): %s", pdfFile, err.Error())
+	}
+
+	v, err := mergeSignaturePage(doc, sig)
+	if err != nil {
+		return fmt.Errorf("failed to merge org signature into pdf file(%s): %s", pdfFile, err.Error())
+	}
+
+	if err := ioutil.WriteFile(outfile, v, 0644); err != nil {
+		return fmt.Errorf("failed to write pdf file(%s): %s", outfile, err.Error())
 	}
 
 	return nil
 }
 
-func BuildCorpContact(fields []models.CLAField) ([]string, map[string]string) {
+// BuildCorpContact returns the fields in the order of their ids.
+func BuildCorpContact(fields []models.CLAField) []models.CLAField {
 	ids := make(sort.IntSlice, 0, len(fields))
-	m := map[int]string{}
-	mk := map[string]string{}
+	m := map[int]*models.CLAField{}
 
 	for i := range fields {
 		item := &fields[i]
@@ -119,17 +252,29 @@ func BuildCorpContact(fields []models.CLAField) ([]string, map[string]string) {
 		}
 
 		ids = append(ids, v)
-		m[v] = item.ID
-		mk[item.ID] = item.Title
+		m[v] = item
 	}
 
 	ids.Sort()
 
-	r := make([]string, 0, len(ids))
+	r := make([]models.CLAField, 0, len(ids))
 	for _, k := range ids {
-		r = append(r, m[k])
+		r = append(r, *m[k])
+	}
+	return r
+}
+
+// ContactValue returns the text of the value of contact item in the language of cla,
+// which is the same as the one in the pdf.
+func (this *pdfGenerator) ContactValue(claLang string, field *models.CLAField, value string) string {
+	if field.Type != dbmodels.FieldTypeCheckbox {
+		return value
+	}
+
+	if corp := this.generator(claLang); corp != nil {
+		return corp.checkbox[models.IsFieldChecked(value)]
 	}
-	return r, mk
+	return value
 }
 
 func genPDFFileName(linkID, email, other string) string {
@@ -0,0 +1,475 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// The objects of pdf which are used to merge the pdf files. Only the subset
+// of pdf syntax needed to copy the objects between files is supported.
+type (
+	pdfObject interface{}
+	pdfName   string
+	pdfString []byte
+	// pdfReal keeps the text of real number to write it back as it was.
+	pdfReal  string
+	pdfArray []pdfObject
+	pdfDict  map[pdfName]pdfObject
+)
+
+type pdfRef struct {
+	num int
+	gen int
+}
+
+type pdfStream struct {
+	dict pdfDict
+	// data is the raw data which is not decoded.
+	data []byte
+}
+
+func (d pdfDict) name(key pdfName) pdfName {
+	v, _ := d[key].(pdfName)
+	return v
+}
+
+func (d pdfDict) int(key pdfName) (int, bool) {
+	v, ok := d[key].(int64)
+	return int(v), ok
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isPDFSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+// pdfLexer parses the objects from the data of pdf.
+type pdfLexer struct {
+	data []byte
+	pos  int
+
+	// length resolves the length of stream which may be an indirect object.
+	length func(pdfObject) (int, error)
+}
+
+func (this *pdfLexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("offset %d: %s", this.pos, fmt.Sprintf(format, args...))
+}
+
+func (this *pdfLexer) skipSpace() {
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		if c == '%' {
+			for this.pos < len(this.data) && this.data[this.pos] != '\n' && this.data[this.pos] != '\r' {
+				this.pos++
+			}
+			continue
+		}
+		if !isPDFSpace(c) {
+			return
+		}
+		this.pos++
+	}
+}
+
+// token reads the next regular token, such as number and keyword.
+func (this *pdfLexer) token() string {
+	this.skipSpace()
+
+	start := this.pos
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		if isPDFSpace(c) || isPDFDelimiter(c) {
+			break
+		}
+		this.pos++
+	}
+	return string(this.data[start:this.pos])
+}
+
+func (this *pdfLexer) expect(keyword string) error {
+	if t := this.token(); t != keyword {
+		return this.errorf("expect %q, but got %q", keyword, t)
+	}
+	return nil
+}
+
+// readInt reads an integer and restores the position if it is not.
+func (this *pdfLexer) readInt() (int, bool) {
+	pos := this.pos
+	v, err := strconv.Atoi(this.token())
+	if err != nil {
+		this.pos = pos
+		return 0, false
+	}
+	return v, true
+}
+
+func (this *pdfLexer) readObject() (pdfObject, error) {
+	this.skipSpace()
+	if this.pos >= len(this.data) {
+		return nil, this.errorf("unexpected end of data")
+	}
+
+	switch c := this.data[this.pos]; c {
+	case '/':
+		this.pos++
+		return this.readName()
+
+	case '(':
+		this.pos++
+		return this.readLiteralString()
+
+	case '<':
+		if this.pos+1 < len(this.data) && this.data[this.pos+1] == '<' {
+			this.pos += 2
+			d, err := this.readDict()
+			if err != nil {
+				return nil, err
+			}
+			return this.readStreamIfAny(d)
+		}
+		this.pos++
+		return this.readHexString()
+
+	case '[':
+		this.pos++
+		return this.readArray()
+
+	case ')', '>', ']', '{', '}':
+		return nil, this.errorf("unexpected delimiter %q", c)
+	}
+
+	t := this.token()
+	switch t {
+	case "":
+		return nil, this.errorf("empty token")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		if _, err := strconv.ParseFloat(t, 64); err != nil {
+			return nil, this.errorf("unknown token %q", t)
+		}
+		return pdfReal(t), nil
+	}
+
+	// it may be a reference, such as "3 0 R"
+	pos := this.pos
+	if gen, ok := this.readInt(); ok && n >= 0 {
+		if this.token() == "R" {
+			return pdfRef{num: int(n), gen: gen}, nil
+		}
+	}
+	this.pos = pos
+
+	return n, nil
+}
+
+func (this *pdfLexer) readName() (pdfName, error) {
+	var b []byte
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		if isPDFSpace(c) || isPDFDelimiter(c) {
+			break
+		}
+		this.pos++
+
+		if c == '#' && this.pos+2 <= len(this.data) {
+			if v, err := hex.DecodeString(string(this.data[this.pos : this.pos+2])); err == nil {
+				b = append(b, v[0])
+				this.pos += 2
+				continue
+			}
+		}
+		b = append(b, c)
+	}
+	return pdfName(b), nil
+}
+
+func (this *pdfLexer) readLiteralString() (pdfString, error) {
+	var b []byte
+	depth := 1
+
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		this.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return pdfString(b), nil
+			}
+		case '\\':
+			if this.pos >= len(this.data) {
+				continue
+			}
+			c = this.data[this.pos]
+			this.pos++
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// line continuation
+				if this.pos < len(this.data) && this.data[this.pos] == '\n' {
+					this.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && this.pos < len(this.data); i++ {
+						d := this.data[this.pos]
+						if d < '0' || d > '7' {
+							break
+						}
+						v = v*8 + int(d-'0')
+						this.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		b = append(b, c)
+	}
+
+	return nil, this.errorf("unterminated string")
+}
+
+func (this *pdfLexer) readHexString() (pdfString, error) {
+	var digits []byte
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		this.pos++
+
+		if c == '>' {
+			if len(digits)%2 == 1 {
+				digits = append(digits, '0')
+			}
+			v, err := hex.DecodeString(string(digits))
+			if err != nil {
+				return nil, this.errorf("invalid hex string")
+			}
+			return pdfString(v), nil
+		}
+		if !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+
+	return nil, this.errorf("unterminated hex string")
+}
+
+func (this *pdfLexer) readArray() (pdfArray, error) {
+	a := pdfArray{}
+	for {
+		this.skipSpace()
+		if this.pos >= len(this.data) {
+			return nil, this.errorf("unterminated array")
+		}
+		if this.data[this.pos] == ']' {
+			this.pos++
+			return a, nil
+		}
+
+		v, err := this.readObject()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+	}
+}
+
+func (this *pdfLexer) readDict() (pdfDict, error) {
+	d := pdfDict{}
+	for {
+		this.skipSpace()
+		if this.pos+1 >= len(this.data) {
+			return nil, this.errorf("unterminated dictionary")
+		}
+		if this.data[this.pos] == '>' && this.data[this.pos+1] == '>' {
+			this.pos += 2
+			return d, nil
+		}
+
+		k, err := this.readObject()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(pdfName)
+		if !ok {
+			return nil, this.errorf("the key of dictionary is not a name")
+		}
+
+		v, err := this.readObject()
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			d[key] = v
+		}
+	}
+}
+
+func (this *pdfLexer) readStreamIfAny(d pdfDict) (pdfObject, error) {
+	pos := this.pos
+	if this.token() != "stream" {
+		this.pos = pos
+		return d, nil
+	}
+
+	// the keyword of stream is followed by CRLF or LF
+	if this.pos < len(this.data) && this.data[this.pos] == '\r' {
+		this.pos++
+	}
+	if this.pos < len(this.data) && this.data[this.pos] == '\n' {
+		this.pos++
+	}
+	start := this.pos
+
+	n := -1
+	if this.length != nil {
+		if v, err := this.length(d["Length"]); err == nil {
+			n = v
+		}
+	}
+
+	end := start + n
+	if n < 0 || n > len(this.data)-start || !hasEndStream(this.data[end:]) {
+		// the length is wrong, find the end of stream instead
+		i := bytes.Index(this.data[start:], []byte("endstream"))
+		if i < 0 {
+			return nil, this.errorf("missing endstream")
+		}
+		end = start + i
+		for end > start && (this.data[end-1] == '\n' || this.data[end-1] == '\r') {
+			end--
+		}
+	}
+
+	this.pos = end
+	if err := this.expect("endstream"); err != nil {
+		return nil, err
+	}
+
+	return &pdfStream{dict: d, data: this.data[start:end]}, nil
+}
+
+func hasEndStream(b []byte) bool {
+	b = bytes.TrimLeft(b, "\r\n \t")
+	return bytes.HasPrefix(b, []byte("endstream"))
+}
+
+// writePDFObject writes the object in the syntax of pdf.
+func writePDFObject(buf *bytes.Buffer, obj pdfObject) {
+	switch v := obj.(type) {
+	case nil:
+		buf.WriteString("null")
+
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+
+	case pdfReal:
+		buf.WriteString(string(v))
+
+	case pdfName:
+		writePDFName(buf, v)
+
+	case pdfString:
+		buf.WriteByte('<')
+		buf.WriteString(hex.EncodeToString(v))
+		buf.WriteByte('>')
+
+	case pdfRef:
+		fmt.Fprintf(buf, "%d %d R", v.num, v.gen)
+
+	case pdfArray:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			writePDFObject(buf, item)
+		}
+		buf.WriteByte(']')
+
+	case pdfDict:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+
+		buf.WriteString("<<")
+		for _, k := range keys {
+			writePDFName(buf, pdfName(k))
+			buf.WriteByte(' ')
+			writePDFObject(buf, v[pdfName(k)])
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(">>")
+
+	case *pdfStream:
+		d := pdfDict{}
+		for k, item := range v.dict {
+			d[k] = item
+		}
+		d["Length"] = int64(len(v.data))
+
+		writePDFObject(buf, d)
+		buf.WriteString("\nstream\n")
+		buf.Write(v.data)
+		buf.WriteString("\nendstream")
+	}
+}
+
+func writePDFName(buf *bytes.Buffer, name pdfName) {
+	buf.WriteByte('/')
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < 0x21 || c > 0x7e || c == '#' || isPDFDelimiter(c) {
+			fmt.Fprintf(buf, "#%02X", c)
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,710 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// maxDepthOfObjects avoids the endless loop caused by the malformed objects.
+	maxDepthOfObjects = 32
+
+	// maxPagesOfPDF and maxNodesOfPageTree bound the work of walking the page tree.
+	maxPagesOfPDF      = 1000
+	maxNodesOfPageTree = 2 * maxPagesOfPDF
+
+	// maxSizeOfDecodedStream avoids the small stream which is decompressed into huge data.
+	maxSizeOfDecodedStream = 32 << 20
+)
+
+type xrefEntry struct {
+	offset int
+	gen    int
+
+	// the object is compressed in the object stream at the index
+	inStream bool
+	stream   int
+	index    int
+}
+
+// pdfReader reads the objects of pdf by the cross reference.
+type pdfReader struct {
+	data    []byte
+	xref    map[int]xrefEntry
+	trailer pdfDict
+
+	// startxref is the offset of the last cross reference.
+	startxref int
+
+	// xrefStream is true if the last cross reference is a stream,
+	// then the update should be written as a stream too.
+	xrefStream bool
+
+	objects map[int]pdfObject
+	loading map[int]bool
+
+	// streams caches the decoded object streams, so that each is decoded once.
+	streams map[int][]byte
+}
+
+type pdfPage struct {
+	ref  pdfRef
+	dict pdfDict
+
+	// the attributes which are inherited from the ancestors if the page doesn't have.
+	resources pdfDict
+	mediaBox  pdfObject
+}
+
+func newPDFReader(data []byte) (*pdfReader, error) {
+	r := &pdfReader{
+		data:    data,
+		xref:    map[int]xrefEntry{},
+		objects: map[int]pdfObject{},
+		loading: map[int]bool{},
+		streams: map[int][]byte{},
+	}
+
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return nil, fmt.Errorf("missing the header of pdf")
+	}
+
+	offset, err := r.findStartXref()
+	if err != nil {
+		return nil, err
+	}
+	r.startxref = offset
+	r.xrefStream = r.newLexer(data, offset).token() != "xref"
+
+	if err := r.loadXref(offset); err != nil {
+		return nil, fmt.Errorf("invalid cross reference: %s", err.Error())
+	}
+
+	if _, ok := r.trailer["Encrypt"]; ok {
+		return nil, fmt.Errorf("the encrypted pdf is not supported")
+	}
+
+	return r, nil
+}
+
+func (this *pdfReader) findStartXref() (int, error) {
+	tail := this.data
+	if n := len(tail) - 1024; n > 0 {
+		tail = tail[n:]
+	}
+
+	i := bytes.LastIndex(tail, []byte("startxref"))
+	if i < 0 {
+		return 0, fmt.Errorf("missing startxref")
+	}
+
+	lex := &pdfLexer{data: tail, pos: i + len("startxref")}
+	offset, ok := lex.readInt()
+	if !ok || offset < 0 || offset >= len(this.data) {
+		return 0, fmt.Errorf("invalid startxref")
+	}
+	return offset, nil
+}
+
+// loadXref loads the cross references from the newest to the oldest.
+// The entry loaded first wins since it is newer.
+func (this *pdfReader) loadXref(offset int) error {
+	visited := map[int]bool{}
+
+	for {
+		if visited[offset] {
+			return fmt.Errorf("the cross references are in loop")
+		}
+		visited[offset] = true
+
+		trailer, err := this.loadXrefSection(offset)
+		if err != nil {
+			return err
+		}
+
+		if this.trailer == nil {
+			this.trailer = trailer
+		}
+
+		// the hybrid file has a cross reference stream as well
+		if v, ok := trailer.int("XRefStm"); ok && !visited[v] {
+			visited[v] = true
+			if _, err := this.loadXrefSection(v); err != nil {
+				return err
+			}
+		}
+
+		v, ok := trailer.int("Prev")
+		if !ok {
+			break
+		}
+		offset = v
+	}
+
+	if _, ok := this.trailer["Root"].(pdfRef); !ok {
+		return fmt.Errorf("missing root in trailer")
+	}
+	return nil
+}
+
+func (this *pdfReader) loadXrefSection(offset int) (pdfDict, error) {
+	if offset < 0 || offset >= len(this.data) {
+		return nil, fmt.Errorf("invalid offset of cross reference: %d", offset)
+	}
+
+	lex := this.newLexer(this.data, offset)
+	if lex.token() == "xref" {
+		return this.loadXrefTable(lex)
+	}
+
+	lex.pos = offset
+	return this.loadXrefStream(lex)
+}
+
+func (this *pdfReader) loadXrefTable(lex *pdfLexer) (pdfDict, error) {
+	for {
+		pos := lex.pos
+		if lex.token() == "trailer" {
+			break
+		}
+		lex.pos = pos
+
+		start, ok1 := lex.readInt()
+		count, ok2 := lex.readInt()
+		if !ok1 || !ok2 || start < 0 || count < 0 {
+			return nil, lex.errorf("invalid subsection of cross reference")
+		}
+
+		for i := 0; i < count; i++ {
+			offset, ok1 := lex.readInt()
+			gen, ok2 := lex.readInt()
+			t := lex.token()
+			if !ok1 || !ok2 || (t != "n" && t != "f") {
+				return nil, lex.errorf("invalid entry of cross reference")
+			}
+
+			num := start + i
+			if _, ok := this.xref[num]; ok {
+				continue
+			}
+			if t == "n" {
+				this.xref[num] = xrefEntry{offset: offset, gen: gen}
+			} else {
+				// the free entry shadows the older ones
+				this.xref[num] = xrefEntry{offset: -1}
+			}
+		}
+	}
+
+	v, err := lex.readObject()
+	if err != nil {
+		return nil, err
+	}
+	trailer, ok := v.(pdfDict)
+	if !ok {
+		return nil, lex.errorf("the trailer is not a dictionary")
+	}
+	return trailer, nil
+}
+
+func (this *pdfReader) loadXrefStream(lex *pdfLexer) (pdfDict, error) {
+	offset := lex.pos
+	_, obj, err := this.readIndirectObject(lex)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := obj.(*pdfStream)
+	if !ok || s.dict.name("Type") != "XRef" {
+		return nil, fmt.Errorf("no cross reference stream at offset %d", offset)
+	}
+
+	data, err := decodeStream(s)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := this.intArray(s.dict["W"])
+	if err != nil || len(w) != 3 {
+		return nil, fmt.Errorf("invalid W of cross reference stream")
+	}
+	// the field of more than 8 bytes overflows
+	for _, item := range w {
+		if item < 0 || item > 8 {
+			return nil, fmt.Errorf("invalid W of cross reference stream")
+		}
+	}
+
+	size, _ := s.dict.int("Size")
+	index := []int{0, size}
+	if v, ok := s.dict["Index"]; ok {
+		if index, err = this.intArray(v); err != nil || len(index)%2 != 0 {
+			return nil, fmt.Errorf("invalid Index of cross reference stream")
+		}
+	}
+	for _, item := range index {
+		if item < 0 {
+			return nil, fmt.Errorf("invalid Index of cross reference stream")
+		}
+	}
+
+	field := func(b []byte, def int) int {
+		if len(b) == 0 {
+			return def
+		}
+		v := 0
+		for _, c := range b {
+			v = v<<8 | int(c)
+		}
+		return v
+	}
+
+	n := w[0] + w[1] + w[2]
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid W of cross reference stream")
+	}
+
+	for i := 0; i < len(index); i += 2 {
+		for j := 0; j < index[i+1]; j++ {
+			if len(data) < n {
+				return nil, fmt.Errorf("the cross reference stream is too short")
+			}
+			b := data[:n]
+			data = data[n:]
+
+			num := index[i] + j
+			if _, ok := this.xref[num]; ok {
+				continue
+			}
+
+			f2 := field(b[w[0]:w[0]+w[1]], 0)
+			f3 := field(b[w[0]+w[1]:], 0)
+
+			switch field(b[:w[0]], 1) {
+			case 0:
+				this.xref[num] = xrefEntry{offset: -1}
+			case 1:
+				this.xref[num] = xrefEntry{offset: f2, gen: f3}
+			case 2:
+				this.xref[num] = xrefEntry{inStream: true, stream: f2, index: f3}
+			}
+		}
+	}
+
+	return s.dict, nil
+}
+
+func (this *pdfReader) intArray(obj pdfObject) ([]int, error) {
+	a, ok := this.resolve(obj).(pdfArray)
+	if !ok {
+		return nil, fmt.Errorf("not an array")
+	}
+
+	r := make([]int, 0, len(a))
+	for _, item := range a {
+		v, ok := this.resolve(item).(int64)
+		if !ok {
+			return nil, fmt.Errorf("not an integer")
+		}
+		r = append(r, int(v))
+	}
+	return r, nil
+}
+
+func (this *pdfReader) newLexer(data []byte, pos int) *pdfLexer {
+	return &pdfLexer{
+		data: data,
+		pos:  pos,
+		length: func(obj pdfObject) (int, error) {
+			v, ok := this.resolve(obj).(int64)
+			if !ok {
+				return 0, fmt.Errorf("invalid length of stream")
+			}
+			return int(v), nil
+		},
+	}
+}
+
+// readIndirectObject reads the object such as "3 0 obj ... endobj".
+func (this *pdfReader) readIndirectObject(lex *pdfLexer) (int, pdfObject, error) {
+	num, ok1 := lex.readInt()
+	_, ok2 := lex.readInt()
+	if !ok1 || !ok2 {
+		return 0, nil, lex.errorf("invalid indirect object")
+	}
+	if err := lex.expect("obj"); err != nil {
+		return 0, nil, err
+	}
+
+	v, err := lex.readObject()
+	return num, v, err
+}
+
+// object returns the object of the number, it is nil if the object doesn't exist.
+func (this *pdfReader) object(num int) (pdfObject, error) {
+	if v, ok := this.objects[num]; ok {
+		return v, nil
+	}
+
+	e, ok := this.xref[num]
+	if !ok || (!e.inStream && e.offset < 0) {
+		return nil, nil
+	}
+	if !e.inStream && e.offset >= len(this.data) {
+		return nil, fmt.Errorf("the offset of object %d is out of the file", num)
+	}
+
+	if this.loading[num] {
+		return nil, fmt.Errorf("the object %d refers to itself", num)
+	}
+	this.loading[num] = true
+	defer delete(this.loading, num)
+
+	var v pdfObject
+	var err error
+	if e.inStream {
+		v, err = this.objectInStream(e.stream, e.index)
+	} else {
+		var n int
+		n, v, err = this.readIndirectObject(this.newLexer(this.data, e.offset))
+		if err == nil && n != num {
+			err = fmt.Errorf("expect object %d, but got %d", num, n)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %d: %s", num, err.Error())
+	}
+
+	this.objects[num] = v
+	return v, nil
+}
+
+func (this *pdfReader) objectInStream(stream, index int) (pdfObject, error) {
+	obj, err := this.object(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := obj.(*pdfStream)
+	if !ok || s.dict.name("Type") != "ObjStm" {
+		return nil, fmt.Errorf("the object %d is not an object stream", stream)
+	}
+
+	n, _ := s.dict.int("N")
+	first, _ := s.dict.int("First")
+	if index < 0 || index >= n {
+		return nil, fmt.Errorf("the index %d is out of the object stream %d", index, stream)
+	}
+
+	data, ok := this.streams[stream]
+	if !ok {
+		if data, err = decodeStream(s); err != nil {
+			return nil, err
+		}
+		this.streams[stream] = data
+	}
+
+	lex := &pdfLexer{data: data}
+	offset := -1
+	for i := 0; i <= index; i++ {
+		_, ok1 := lex.readInt()
+		v, ok2 := lex.readInt()
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("invalid header of object stream %d", stream)
+		}
+		offset = v
+	}
+
+	if first < 0 || offset < 0 || first+offset < 0 || first+offset >= len(data) {
+		return nil, fmt.Errorf("invalid offset in object stream %d", stream)
+	}
+
+	lex = this.newLexer(data, first+offset)
+	return lex.readObject()
+}
+
+// resolve returns the object which the reference refers to.
+func (this *pdfReader) resolve(obj pdfObject) pdfObject {
+	for i := 0; i < maxDepthOfObjects; i++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+
+		v, err := this.object(ref.num)
+		if err != nil {
+			return nil
+		}
+		obj = v
+	}
+	return nil
+}
+
+func (this *pdfReader) dict(obj pdfObject) pdfDict {
+	v, _ := this.resolve(obj).(pdfDict)
+	return v
+}
+
+func (this *pdfReader) size() int {
+	n, _ := this.trailer.int("Size")
+	for k := range this.xref {
+		if k >= n {
+			n = k + 1
+		}
+	}
+	return n
+}
+
+// generation returns the generation of the object which is updated.
+func (this *pdfReader) generation(num int) int {
+	if e, ok := this.xref[num]; ok && !e.inStream && e.offset >= 0 {
+		return e.gen
+	}
+	return 0
+}
+
+// pages returns the pages in order.
+func (this *pdfReader) pages() ([]pdfPage, error) {
+	root := this.dict(this.trailer["Root"])
+	if root == nil {
+		return nil, fmt.Errorf("missing document catalog")
+	}
+
+	ref, ok := root["Pages"].(pdfRef)
+	if !ok {
+		return nil, fmt.Errorf("missing page tree")
+	}
+
+	var r []pdfPage
+	err := this.walkPages(ref, nil, nil, 0, map[int]bool{}, &r)
+	return r, err
+}
+
+// walkPages walks the page tree in which each node should be visited once.
+func (this *pdfReader) walkPages(ref pdfRef, resources pdfDict, mediaBox pdfObject, depth int, visited map[int]bool, r *[]pdfPage) error {
+	if depth > maxDepthOfObjects {
+		return fmt.Errorf("the page tree is too deep")
+	}
+
+	if visited[ref.num] {
+		return fmt.Errorf("the page tree node %d is referred to more than once", ref.num)
+	}
+	visited[ref.num] = true
+	if len(visited) > maxNodesOfPageTree {
+		return fmt.Errorf("the page tree has too many nodes")
+	}
+
+	node := this.dict(ref)
+	if node == nil {
+		return fmt.Errorf("the page tree node %d is not a dictionary", ref.num)
+	}
+
+	if v := this.dict(node["Resources"]); v != nil {
+		resources = v
+	}
+	if v, ok := node["MediaBox"]; ok {
+		mediaBox = v
+	}
+
+	if node.name("Type") == "Page" {
+		if len(*r) >= maxPagesOfPDF {
+			return fmt.Errorf("the pdf has more than %d pages", maxPagesOfPDF)
+		}
+
+		*r = append(*r, pdfPage{
+			ref:       ref,
+			dict:      node,
+			resources: resources,
+			mediaBox:  mediaBox,
+		})
+		return nil
+	}
+
+	kids, ok := this.resolve(node["Kids"]).(pdfArray)
+	if !ok {
+		return fmt.Errorf("the page tree node %d has no kids", ref.num)
+	}
+
+	for _, item := range kids {
+		kid, ok := item.(pdfRef)
+		if !ok {
+			return fmt.Errorf("the kid of page tree node %d is not a reference", ref.num)
+		}
+		if err := this.walkPages(kid, resources, mediaBox, depth+1, visited, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contents returns the decoded content of page.
+func (this *pdfReader) contents(page *pdfPage) ([]byte, error) {
+	var streams []pdfObject
+
+	switch v := this.resolve(page.dict["Contents"]).(type) {
+	case nil:
+		// the blank page
+	case *pdfStream:
+		streams = append(streams, v)
+	case pdfArray:
+		for _, item := range v {
+			streams = append(streams, this.resolve(item))
+		}
+	default:
+		return nil, fmt.Errorf("invalid contents of page")
+	}
+
+	buf := new(bytes.Buffer)
+	for _, item := range streams {
+		s, ok := item.(*pdfStream)
+		if !ok {
+			return nil, fmt.Errorf("the contents of page is not a stream")
+		}
+
+		data, err := decodeStream(s)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(data)
+		buf.WriteByte('\n')
+
+		if buf.Len() > maxSizeOfDecodedStream {
+			return nil, fmt.Errorf("the contents of page are too large")
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeStream decodes the stream which is not compressed or compressed by flate.
+func decodeStream(s *pdfStream) ([]byte, error) {
+	var filter pdfName
+	var parms pdfDict
+
+	switch v := s.dict["Filter"].(type) {
+	case nil:
+		return s.data, nil
+	case pdfName:
+		filter = v
+		parms, _ = s.dict["DecodeParms"].(pdfDict)
+	case pdfArray:
+		if len(v) == 0 {
+			return s.data, nil
+		}
+		if len(v) > 1 {
+			return nil, fmt.Errorf("the multiple filters of stream are not supported")
+		}
+		filter, _ = v[0].(pdfName)
+		if a, ok := s.dict["DecodeParms"].(pdfArray); ok && len(a) > 0 {
+			parms, _ = a[0].(pdfDict)
+		}
+	}
+
+	if filter != "FlateDecode" {
+		return nil, fmt.Errorf("the filter %q of stream is not supported", string(filter))
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(s.data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the stream: %s", err.Error())
+	}
+	defer zr.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(zr, maxSizeOfDecodedStream+1))
+	if err != nil && len(data) == 0 {
+		return nil, fmt.Errorf("failed to decode the stream: %s", err.Error())
+	}
+	if len(data) > maxSizeOfDecodedStream {
+		return nil, fmt.Errorf("the decoded stream is larger than %d bytes", maxSizeOfDecodedStream)
+	}
+
+	return applyPredictor(data, parms)
+}
+
+// applyPredictor reverses the PNG predictors which are used by the cross reference stream.
+func applyPredictor(data []byte, parms pdfDict) ([]byte, error) {
+	predictor, _ := parms.int("Predictor")
+	if predictor <= 1 {
+		return data, nil
+	}
+	if predictor < 10 {
+		return nil, fmt.Errorf("the predictor %d is not supported", predictor)
+	}
+
+	columns, ok := parms.int("Columns")
+	if !ok {
+		columns = 1
+	}
+	colors, ok := parms.int("Colors")
+	if !ok {
+		colors = 1
+	}
+	bits, ok := parms.int("BitsPerComponent")
+	if !ok {
+		bits = 8
+	}
+
+	bpp := (colors*bits + 7) / 8
+	rowSize := (columns*colors*bits + 7) / 8
+	if rowSize <= 0 {
+		return nil, fmt.Errorf("invalid columns of predictor")
+	}
+
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowSize)
+
+	for len(data) >= rowSize+1 {
+		t, row := data[0], append([]byte{}, data[1:rowSize+1]...)
+		data = data[rowSize+1:]
+
+		for i := range row {
+			var left, upleft byte
+			if i >= bpp {
+				left, upleft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+
+			switch t {
+			case 0:
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upleft)
+			default:
+				return nil, fmt.Errorf("invalid png filter %d", t)
+			}
+		}
+
+		out = append(out, row...)
+		prev = row
+	}
+
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+	return c
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
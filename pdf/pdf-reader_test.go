@@ -0,0 +1,300 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testContents = "0 0 m 100 100 l S"
+
+// testPDF builds the pdf whose objects are numbered from 1 in order.
+type testPDF struct {
+	objects []string
+}
+
+func (this *testPDF) add(obj string) int {
+	this.objects = append(this.objects, obj)
+	return len(this.objects)
+}
+
+func testStream(dict, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+// newTestPDF returns the pdf with pages of which each draws a line.
+func newTestPDF(pages int) *testPDF {
+	p := &testPDF{}
+	p.add("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, 0, pages)
+	for i := 0; i < pages; i++ {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 3+2*i))
+	}
+	p.add(fmt.Sprintf(
+		"<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 612 792] >>",
+		strings.Join(kids, " "), pages,
+	))
+
+	for i := 0; i < pages; i++ {
+		p.add(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>", 4+2*i))
+		p.add(testStream("", testContents))
+	}
+	return p
+}
+
+func (this *testPDF) body() (*bytes.Buffer, []int) {
+	buf := bytes.NewBufferString("%PDF-1.7\n")
+
+	offsets := make([]int, len(this.objects))
+	for i, item := range this.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", i+1, item)
+	}
+	return buf, offsets
+}
+
+// newTestPDFWithRepeatedKids returns the pdf whose page tree nodes list the same kid
+// twice, so that the page tree grows exponentially with the levels.
+func newTestPDFWithRepeatedKids(levels int) []byte {
+	p := &testPDF{}
+	p.add("<< /Type /Catalog /Pages 2 0 R >>")
+
+	for i := 0; i < levels; i++ {
+		kid := 3 + i
+		p.add(fmt.Sprintf(
+			"<< /Type /Pages /Kids [%d 0 R %d 0 R] /Count %d /MediaBox [0 0 612 792] >>",
+			kid, kid, 1<<uint(levels-i),
+		))
+	}
+	p.add("<< /Type /Page >>")
+
+	return p.withXrefTable()
+}
+
+// newTestPDFWithBomb returns the pdf whose page is in the object stream which
+// is decompressed into more than maxSizeOfDecodedStream bytes.
+func newTestPDFWithBomb() ([]byte, error) {
+	p := newTestPDF(1)
+
+	page := p.objects[2]
+	p.objects[2] = "null"
+	header := "3 0 "
+
+	data := append([]byte(header+page), bytes.Repeat([]byte{' '}, maxSizeOfDecodedStream)...)
+	zdata, err := compress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stm := p.add(testStream(
+		fmt.Sprintf("/Type /ObjStm /N 1 /First %d /Filter /FlateDecode", len(header)),
+		string(zdata),
+	))
+
+	return p.withXrefStream("/W [1 4 2]", func(num int, offsets []int) []byte {
+		if num == 3 {
+			return []byte{2, 0, 0, 0, byte(stm), 0, 0}
+		}
+		return entryOfXrefStream(num, offsets)
+	}), nil
+}
+
+// withXrefTable writes the classic cross reference table.
+func (this *testPDF) withXrefTable() []byte {
+	buf, offsets := this.body()
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f\r\n", len(offsets)+1)
+	for _, item := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n\r\n", item)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// withXrefStream writes the cross reference stream whose entries are
+// generated by entry with the offsets of objects.
+func (this *testPDF) withXrefStream(dict string, entry func(num int, offsets []int) []byte) []byte {
+	buf, offsets := this.body()
+
+	num := len(offsets) + 1
+	var data []byte
+	for i := 0; i <= num; i++ {
+		data = append(data, entry(i, append(offsets, buf.Len()))...)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "%d 0 obj\n", num)
+	fmt.Fprintf(buf, "<< /Type /XRef /Size %d /Root 1 0 R %s /Length %d >>\nstream\n", num+1, dict, len(data))
+	buf.Write(data)
+	fmt.Fprintf(buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", xref)
+
+	return buf.Bytes()
+}
+
+// entryOfXrefStream generates the entries for W [1 4 2].
+func entryOfXrefStream(num int, offsets []int) []byte {
+	if num == 0 {
+		return []byte{0, 0, 0, 0, 0, 0xff, 0xff}
+	}
+
+	o := offsets[num-1]
+	return []byte{1, byte(o >> 24), byte(o >> 16), byte(o >> 8), byte(o), 0, 0}
+}
+
+func TestReadPDFWithXrefTable(t *testing.T) {
+	r, err := newPDFReader(newTestPDF(2).withXrefTable())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := r.pages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expect 2 pages, got %d", len(pages))
+	}
+
+	// the media box is inherited from the page tree
+	if _, ok := r.resolve(pages[1].mediaBox).(pdfArray); !ok {
+		t.Fatal("missing the inherited media box")
+	}
+
+	contents, err := r.contents(&pages[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(contents)) != testContents {
+		t.Fatalf("unexpected contents: %q", contents)
+	}
+}
+
+func TestReadPDFWithXrefStream(t *testing.T) {
+	data := newTestPDF(1).withXrefStream("/W [1 4 2]", entryOfXrefStream)
+
+	r, err := newPDFReader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.xrefStream {
+		t.Fatal("the cross reference should be a stream")
+	}
+
+	if pages, err := r.pages(); err != nil || len(pages) != 1 {
+		t.Fatalf("expect 1 page, got %d, %v", len(pages), err)
+	}
+}
+
+func TestReadObjectInStream(t *testing.T) {
+	p := newTestPDF(1)
+
+	// move the page into the object stream
+	page := p.objects[2]
+	p.objects[2] = "null"
+	header := "3 0 "
+	stm := p.add(testStream(
+		fmt.Sprintf("/Type /ObjStm /N 1 /First %d", len(header)), header+page,
+	))
+
+	data := p.withXrefStream("/W [1 4 2]", func(num int, offsets []int) []byte {
+		if num == 3 {
+			return []byte{2, 0, 0, 0, byte(stm), 0, 0}
+		}
+		return entryOfXrefStream(num, offsets)
+	})
+
+	r, err := newPDFReader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pages, err := r.pages(); err != nil || len(pages) != 1 {
+		t.Fatalf("expect 1 page, got %d, %v", len(pages), err)
+	}
+}
+
+func TestReadMalformedPDF(t *testing.T) {
+	objStm := func(dict string) []byte {
+		p := newTestPDF(1)
+		stm := p.add(testStream("/Type /ObjStm /N 1 "+dict, "3 0 << >>"))
+
+		return p.withXrefStream("/W [1 4 2]", func(num int, offsets []int) []byte {
+			if num == 3 {
+				return []byte{2, 0, 0, 0, byte(stm), 0, 0}
+			}
+			return entryOfXrefStream(num, offsets)
+		})
+	}
+
+	cases := map[string][]byte{
+		"negative W": newTestPDF(1).withXrefStream("/W [1 -1 6]", entryOfXrefStream),
+		"too wide W": newTestPDF(1).withXrefStream("/W [1 9 -3]", entryOfXrefStream),
+		"empty W":    newTestPDF(1).withXrefStream("/W [0 0 0]", entryOfXrefStream),
+		"negative Index": newTestPDF(1).withXrefStream(
+			"/W [1 4 2] /Index [-1 7]", entryOfXrefStream,
+		),
+		"short stream": newTestPDF(1).withXrefStream("/W [1 4 2]", func(num int, offsets []int) []byte {
+			return entryOfXrefStream(num, offsets)[:3]
+		}),
+		"negative First":       objStm("/First -9"),
+		"out of object stream": objStm("/First 100"),
+		"offset out of file": newTestPDF(1).withXrefStream("/W [1 4 2]", func(num int, offsets []int) []byte {
+			if num == 3 {
+				return []byte{1, 0x7f, 0, 0, 0, 0, 0}
+			}
+			return entryOfXrefStream(num, offsets)
+		}),
+		"pages is not a reference": func() []byte {
+			p := newTestPDF(1)
+			p.objects[0] = "<< /Type /Catalog /Pages << >> >>"
+			return p.withXrefTable()
+		}(),
+		"missing header": []byte("1 0 obj\n<< >>\nendobj\nstartxref\n0\n%%EOF\n"),
+		"repeated kids":  newTestPDFWithRepeatedKids(30),
+		"too many pages": newTestPDF(maxPagesOfPDF + 1).withXrefTable(),
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := newPDFReader(data)
+			if err == nil {
+				_, err = r.pages()
+			}
+			if err == nil {
+				t.Fatal("expect an error")
+			}
+		})
+	}
+}
+
+func TestDecodeStreamBomb(t *testing.T) {
+	data, err := compress(make([]byte, maxSizeOfDecodedStream+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &pdfStream{
+		dict: pdfDict{"Filter": pdfName("FlateDecode")},
+		data: data,
+	}
+	if _, err := decodeStream(s); err == nil {
+		t.Fatal("expect an error")
+	}
+
+	doc, err := newTestPDFWithBomb()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := newPDFReader(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.pages(); err == nil {
+		t.Fatal("expect an error")
+	}
+}
@@ -0,0 +1,451 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+)
+
+// nameOfSignaturePage is the name of the org signature page in the resources of the last page.
+const nameOfSignaturePage = "OrgSignaturePage"
+
+// signaturePage is the first page of org signature pdf.
+type signaturePage struct {
+	reader *pdfReader
+	page   pdfPage
+
+	// contents is the decoded content of page.
+	contents []byte
+}
+
+// CheckOrgSignature checks whether the org signature pdf can be merged
+// into the pdf of corporation signing.
+func CheckOrgSignature(data []byte) error {
+	_, err := loadSignaturePage(data)
+	return err
+}
+
+func loadSignaturePage(data []byte) (*signaturePage, error) {
+	r, err := newPDFReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := r.pages()
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("there is no page")
+	}
+
+	page := pages[0]
+	if _, ok := r.resolve(page.mediaBox).(pdfArray); !ok {
+		return nil, fmt.Errorf("missing the media box of the first page")
+	}
+
+	contents, err := r.contents(&page)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contents of the first page: %s", err.Error())
+	}
+
+	return &signaturePage{reader: r, page: page, contents: contents}, nil
+}
+
+// mergeSignaturePage puts the org signature page under the last page of doc,
+// in the way of incremental update, so the objects of doc are unchanged.
+func mergeSignaturePage(doc []byte, sig *signaturePage) ([]byte, error) {
+	r, err := newPDFReader(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := r.pages()
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("there is no page")
+	}
+	last := pages[len(pages)-1]
+
+	w := &pdfUpdateWriter{
+		objects: map[int]pdfObject{},
+		next:    r.size(),
+	}
+
+	form, err := w.addSignatureForm(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := w.overlay(r, &last, form)
+	if err != nil {
+		return nil, err
+	}
+	w.objects[last.ref.num] = page
+
+	return w.write(doc, r)
+}
+
+// appendSignaturePage appends the signature page as the new last page of doc,
+// in the way of incremental update, so the objects of doc are unchanged.
+func appendSignaturePage(doc []byte, sig *signaturePage) ([]byte, error) {
+	r, err := newPDFReader(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.pages(); err != nil {
+		return nil, err
+	}
+
+	root := r.dict(r.trailer["Root"])
+	ref, ok := root["Pages"].(pdfRef)
+	if !ok {
+		return nil, fmt.Errorf("missing page tree")
+	}
+	node := r.dict(ref)
+
+	w := &pdfUpdateWriter{
+		objects: map[int]pdfObject{},
+		next:    r.size(),
+	}
+
+	form, err := w.addSignatureForm(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaBox, err := (&pdfCopier{reader: sig.reader, writer: w, refs: map[int]pdfRef{}}).copy(
+		sig.reader.resolve(sig.page.mediaBox),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("q\n")
+	writePDFName(buf, nameOfSignaturePage)
+	buf.WriteString(" Do\nQ\n")
+
+	page := w.add(pdfDict{
+		"Type":     pdfName("Page"),
+		"Parent":   ref,
+		"MediaBox": mediaBox,
+		// the page should not inherit the rotation of page tree
+		"Rotate":    int64(0),
+		"Resources": pdfDict{"XObject": pdfDict{nameOfSignaturePage: form}},
+		"Contents":  w.add(&pdfStream{dict: pdfDict{}, data: buf.Bytes()}),
+	})
+
+	kids, _ := r.resolve(node["Kids"]).(pdfArray)
+	count, _ := node.int("Count")
+
+	pages := pdfDict{}
+	for k, v := range node {
+		pages[k] = v
+	}
+	pages["Kids"] = append(append(pdfArray{}, kids...), page)
+	pages["Count"] = int64(count + 1)
+	w.objects[ref.num] = pages
+
+	return w.write(doc, r)
+}
+
+// pdfUpdateWriter writes the objects which are appended to the original pdf.
+type pdfUpdateWriter struct {
+	objects map[int]pdfObject
+	next    int
+}
+
+func (this *pdfUpdateWriter) add(obj pdfObject) pdfRef {
+	ref := pdfRef{num: this.next}
+	this.next++
+	this.objects[ref.num] = obj
+	return ref
+}
+
+// addSignatureForm copies the signature page as a form xobject.
+func (this *pdfUpdateWriter) addSignatureForm(sig *signaturePage) (pdfRef, error) {
+	c := &pdfCopier{
+		reader: sig.reader,
+		writer: this,
+		refs:   map[int]pdfRef{},
+	}
+
+	resources, err := c.copy(pdfDict(sig.page.resources))
+	if err != nil {
+		return pdfRef{}, err
+	}
+	if resources == nil {
+		resources = pdfDict{}
+	}
+
+	bbox, err := c.copy(sig.reader.resolve(sig.page.mediaBox))
+	if err != nil {
+		return pdfRef{}, err
+	}
+
+	data, err := compress(sig.contents)
+	if err != nil {
+		return pdfRef{}, err
+	}
+
+	return this.add(&pdfStream{
+		dict: pdfDict{
+			"Type":      pdfName("XObject"),
+			"Subtype":   pdfName("Form"),
+			"BBox":      bbox,
+			"Resources": resources,
+			"Filter":    pdfName("FlateDecode"),
+		},
+		data: data,
+	}), nil
+}
+
+// overlay returns the new last page which draws the signature page before its own contents.
+func (this *pdfUpdateWriter) overlay(r *pdfReader, last *pdfPage, form pdfRef) (pdfDict, error) {
+	page := pdfDict{}
+	for k, v := range last.dict {
+		page[k] = v
+	}
+
+	resources := pdfDict{}
+	for k, v := range last.resources {
+		resources[k] = v
+	}
+
+	xobjects := pdfDict{}
+	for k, v := range r.dict(resources["XObject"]) {
+		xobjects[k] = v
+	}
+
+	name := pdfName(nameOfSignaturePage)
+	for i := 1; xobjects[name] != nil; i++ {
+		name = pdfName(fmt.Sprintf("%s%d", nameOfSignaturePage, i))
+	}
+	xobjects[name] = form
+	resources["XObject"] = xobjects
+	page["Resources"] = resources
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("q\n")
+	writePDFName(buf, name)
+	buf.WriteString(" Do\nQ\n")
+	contents := pdfArray{this.add(&pdfStream{dict: pdfDict{}, data: buf.Bytes()})}
+
+	switch v := page["Contents"].(type) {
+	case nil:
+	case pdfRef:
+		if a, ok := r.resolve(v).(pdfArray); ok {
+			contents = append(contents, a...)
+		} else {
+			contents = append(contents, v)
+		}
+	case pdfArray:
+		contents = append(contents, v...)
+	default:
+		return nil, fmt.Errorf("invalid contents of the last page")
+	}
+	page["Contents"] = contents
+
+	return page, nil
+}
+
+// write appends the objects and the cross reference of them to the original pdf.
+func (this *pdfUpdateWriter) write(doc []byte, r *pdfReader) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(doc)+4096))
+	buf.Write(doc)
+	if !bytes.HasSuffix(doc, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	nums := make([]int, 0, len(this.objects))
+	for k := range this.objects {
+		nums = append(nums, k)
+	}
+	sort.Ints(nums)
+
+	offsets := map[int]int{}
+	for _, num := range nums {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(buf, "%d %d obj\n", num, r.generation(num))
+		writePDFObject(buf, this.objects[num])
+		buf.WriteString("\nendobj\n")
+	}
+
+	if r.xrefStream {
+		this.writeXrefStream(buf, r, nums, offsets)
+	} else {
+		this.writeXrefTable(buf, r, nums, offsets)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (this *pdfUpdateWriter) writeXrefTable(buf *bytes.Buffer, r *pdfReader, nums []int, offsets map[int]int) {
+	xref := buf.Len()
+	buf.WriteString("xref\n")
+	for i := 0; i < len(nums); {
+		j := i + 1
+		for j < len(nums) && nums[j] == nums[j-1]+1 {
+			j++
+		}
+
+		fmt.Fprintf(buf, "%d %d\n", nums[i], j-i)
+		for _, num := range nums[i:j] {
+			fmt.Fprintf(buf, "%010d %05d n\r\n", offsets[num], r.generation(num))
+		}
+		i = j
+	}
+
+	buf.WriteString("trailer\n")
+	writePDFObject(buf, this.trailer(r, this.next))
+	fmt.Fprintf(buf, "\nstartxref\n%d\n%%%%EOF\n", xref)
+}
+
+// writeXrefStream writes the cross reference as a stream which is
+// required if the original pdf uses the cross reference stream.
+func (this *pdfUpdateWriter) writeXrefStream(buf *bytes.Buffer, r *pdfReader, nums []int, offsets map[int]int) {
+	// the stream itself is the last object
+	num := this.next
+	offsets[num] = buf.Len()
+	nums = append(nums, num)
+
+	var index pdfArray
+	data := make([]byte, 0, len(nums)*7)
+	for i := 0; i < len(nums); {
+		j := i + 1
+		for j < len(nums) && nums[j] == nums[j-1]+1 {
+			j++
+		}
+
+		index = append(index, int64(nums[i]), int64(j-i))
+		for _, n := range nums[i:j] {
+			o, g := offsets[n], r.generation(n)
+			data = append(
+				data, 1, byte(o>>24), byte(o>>16), byte(o>>8), byte(o),
+				byte(g>>8), byte(g),
+			)
+		}
+		i = j
+	}
+
+	dict := this.trailer(r, num+1)
+	dict["Type"] = pdfName("XRef")
+	dict["W"] = pdfArray{int64(1), int64(4), int64(2)}
+	dict["Index"] = index
+
+	fmt.Fprintf(buf, "%d 0 obj\n", num)
+	writePDFObject(buf, &pdfStream{dict: dict, data: data})
+	fmt.Fprintf(buf, "\nendobj\nstartxref\n%d\n%%%%EOF\n", offsets[num])
+}
+
+func (this *pdfUpdateWriter) trailer(r *pdfReader, size int) pdfDict {
+	trailer := pdfDict{
+		"Size": int64(size),
+		"Root": r.trailer["Root"],
+		"Prev": int64(r.startxref),
+	}
+	for _, k := range []pdfName{"Info", "ID"} {
+		if v, ok := r.trailer[k]; ok {
+			trailer[k] = v
+		}
+	}
+	return trailer
+}
+
+// pdfCopier copies the objects from another pdf and renumbers them.
+type pdfCopier struct {
+	reader *pdfReader
+	writer *pdfUpdateWriter
+	refs   map[int]pdfRef
+}
+
+func (this *pdfCopier) copy(obj pdfObject) (pdfObject, error) {
+	switch v := obj.(type) {
+	case pdfRef:
+		if ref, ok := this.refs[v.num]; ok {
+			return ref, nil
+		}
+
+		o, err := this.reader.object(v.num)
+		if err != nil {
+			return nil, err
+		}
+
+		// reserve the number before copying to handle the cycle of references
+		ref := this.writer.add(nil)
+		this.refs[v.num] = ref
+
+		c, err := this.copy(o)
+		if err != nil {
+			return nil, err
+		}
+		this.writer.objects[ref.num] = c
+		return ref, nil
+
+	case pdfArray:
+		a := make(pdfArray, 0, len(v))
+		for _, item := range v {
+			c, err := this.copy(item)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, c)
+		}
+		return a, nil
+
+	case pdfDict:
+		if v == nil {
+			return nil, nil
+		}
+
+		d := pdfDict{}
+		for k, item := range v {
+			// the parent is the page tree or the page which should not be copied
+			if k == "Parent" || k == "P" {
+				continue
+			}
+
+			c, err := this.copy(item)
+			if err != nil {
+				return nil, err
+			}
+			d[k] = c
+		}
+		return d, nil
+
+	case *pdfStream:
+		// the length will be rewritten according to the data
+		d := pdfDict{}
+		for k, item := range v.dict {
+			if k != "Length" {
+				d[k] = item
+			}
+		}
+
+		c, err := this.copy(d)
+		if err != nil {
+			return nil, err
+		}
+		return &pdfStream{dict: c.(pdfDict), data: v.data}, nil
+
+	default:
+		return obj, nil
+	}
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zw := zlib.NewWriter(buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
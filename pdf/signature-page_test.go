@@ -0,0 +1,102 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendSignaturePage(t *testing.T) {
+	sig, err := loadSignaturePage(newTestPDF(1).withXrefTable())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := map[string][]byte{
+		"xref table":  newTestPDF(2).withXrefTable(),
+		"xref stream": newTestPDF(2).withXrefStream("/W [1 4 2]", entryOfXrefStream),
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			v, err := appendSignaturePage(doc, sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// it is an incremental update
+			if !bytes.HasPrefix(v, doc) {
+				t.Fatal("the original pdf is changed")
+			}
+
+			r, err := newPDFReader(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pages, err := r.pages()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pages) != 3 {
+				t.Fatalf("expect 3 pages, got %d", len(pages))
+			}
+
+			checkSignaturePage(t, r, &pages[2])
+		})
+	}
+}
+
+func TestMergeSignaturePage(t *testing.T) {
+	sig, err := loadSignaturePage(newTestPDF(1).withXrefTable())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := newTestPDF(2).withXrefStream("/W [1 4 2]", entryOfXrefStream)
+
+	v, err := mergeSignaturePage(doc, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := newPDFReader(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := r.pages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expect 2 pages, got %d", len(pages))
+	}
+
+	checkSignaturePage(t, r, &pages[1])
+
+	// the own contents of the last page are kept after the signature page
+	contents, err := r.contents(&pages[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(contents, []byte(testContents)) {
+		t.Fatalf("the contents of the last page are lost: %q", contents)
+	}
+}
+
+func checkSignaturePage(t *testing.T, r *pdfReader, page *pdfPage) {
+	xobjects := r.dict(page.resources["XObject"])
+
+	form, ok := r.resolve(xobjects[nameOfSignaturePage]).(*pdfStream)
+	if !ok || form.dict.name("Subtype") != "Form" {
+		t.Fatal("missing the form of signature page")
+	}
+
+	data, err := decodeStream(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(testContents)) {
+		t.Fatalf("unexpected contents of signature page: %q", data)
+	}
+}
@@ -30,16 +30,36 @@ func init() {
 				&controllers.IndividualSigningController{},
 			),
 		),
+		beego.NSNamespace("/dco",
+			beego.NSInclude(
+				&controllers.DCOController{},
+			),
+		),
 		beego.NSNamespace("/employee-signing",
 			beego.NSInclude(
 				&controllers.EmployeeSigningController{},
 			),
 		),
+		beego.NSNamespace("/employee-allow-list",
+			beego.NSInclude(
+				&controllers.EmployeeAllowListController{},
+			),
+		),
+		beego.NSNamespace("/employee-approval",
+			beego.NSInclude(
+				&controllers.EmployeeApprovalController{},
+			),
+		),
 		beego.NSNamespace("/employee-manager",
 			beego.NSInclude(
 				&controllers.EmployeeManagerController{},
 			),
 		),
+		beego.NSNamespace("/cla-template",
+			beego.NSInclude(
+				&controllers.CLATemplateController{},
+			),
+		),
 		beego.NSNamespace("/corporation-signing",
 			beego.NSInclude(
 				&controllers.CorporationSigningController{},
@@ -55,6 +75,16 @@ func init() {
 				&controllers.CorporationPDFController{},
 			),
 		),
+		beego.NSNamespace("/counter-signature",
+			beego.NSInclude(
+				&controllers.CounterSignatureController{},
+			),
+		),
+		beego.NSNamespace("/corp-signing-amendment",
+			beego.NSInclude(
+				&controllers.CorpSigningAmendmentController{},
+			),
+		),
 		beego.NSNamespace("/email",
 			beego.NSInclude(
 				&controllers.EmailController{},
@@ -80,6 +110,26 @@ func init() {
 				&controllers.VerificationCodeController{},
 			),
 		),
+		beego.NSNamespace("/access-token",
+			beego.NSInclude(
+				&controllers.AccessTokenController{},
+			),
+		),
+		beego.NSNamespace("/session",
+			beego.NSInclude(
+				&controllers.SessionController{},
+			),
+		),
+		beego.NSNamespace("/personal-data",
+			beego.NSInclude(
+				&controllers.PersonalDataController{},
+			),
+		),
+		beego.NSNamespace("/privacy-policy",
+			beego.NSInclude(
+				&controllers.PrivacyPolicyController{},
+			),
+		),
 	)
 	beego.AddNamespace(ns)
 }
@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+type approvalReminder struct {
+	cfg              config.ApprovalReminder
+	urlOfCLAPlatform string
+}
+
+// StartApprovalReminder reminds the employee managers of the employee signings which
+// wait for the activation, and escalates them to the corporation administrator.
+func StartApprovalReminder(cfg config.ApprovalReminder, urlOfCLAPlatform string) {
+	if !cfg.Enabled() {
+		return
+	}
+
+	r := &approvalReminder{
+		cfg:              cfg,
+		urlOfCLAPlatform: urlOfCLAPlatform,
+	}
+
+	go func() {
+		interval := time.Duration(cfg.CheckInterval) * time.Second
+		for {
+			r.run()
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func (this *approvalReminder) run() {
+	v, merr := models.ListPendingEmployeeSignings("")
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	now := time.Now().Unix()
+	links := map[string]*models.LinkInfo{}
+
+	for i := range v {
+		item := &v[i]
+
+		old := item.EmployeeApprovalState
+		remind := this.needRemind(&old, now)
+		escalate := this.needEscalate(&old, now)
+		if !remind && !escalate {
+			continue
+		}
+
+		link, ok := links[item.LinkID]
+		if !ok {
+			if link, merr = models.GetLink(item.LinkID); merr != nil {
+				beego.Error(merr)
+				continue
+			}
+			links[item.LinkID] = link
+		}
+		if link.IsSuspended() {
+			continue
+		}
+
+		state := old
+		if remind {
+			state.Reminders++
+			state.RemindedAt = now
+		}
+		if escalate {
+			state.EscalatedAt = now
+		}
+
+		// take the stage before sending, so that the reminder is sent only once
+		// even if the workers run concurrently.
+		done, merr := models.UpdateEmployeeApprovalState(item.LinkID, item.Email, &old, &state)
+		if merr != nil {
+			beego.Error(merr)
+			continue
+		}
+		if !done {
+			continue
+		}
+
+		if err := this.notify(item, &link.OrgInfo, remind, escalate, now); err != nil {
+			beego.Error(err)
+		}
+	}
+}
+
+func (this *approvalReminder) needRemind(state *models.EmployeeApprovalState, now int64) bool {
+	if this.cfg.MaxReminders > 0 && state.Reminders >= this.cfg.MaxReminders {
+		return false
+	}
+
+	last := state.RemindedAt
+	if last == 0 {
+		last = state.PendingSince
+	}
+	return now-last >= this.cfg.Interval
+}
+
+func (this *approvalReminder) needEscalate(state *models.EmployeeApprovalState, now int64) bool {
+	return this.cfg.EscalateAfter > 0 && state.EscalatedAt == 0 &&
+		now-state.PendingSince >= this.cfg.EscalateAfter
+}
+
+func (this *approvalReminder) notify(item *models.PendingEmployeeSigning, orgInfo *models.OrgInfo, remind, escalate bool, now int64) error {
+	managers, merr := models.ListCorporationManagers(item.LinkID, item.Email, dbmodels.RoleManager)
+	if merr != nil {
+		return merr
+	}
+
+	ms := make([]string, 0, len(managers))
+	to := make([]string, 0, len(managers))
+	for _, m := range managers {
+		to = append(to, m.Email)
+		ms = append(ms, fmt.Sprintf("%s: %s", m.Name, m.Email))
+	}
+
+	msg := email.EmployeeApprovalReminder{
+		Name:             item.Name,
+		EmployeeEmail:    item.Email,
+		PendingDays:      models.PendingDays(item.PendingSince, now),
+		Managers:         "  " + strings.Join(ms, "\n  "),
+		Org:              orgInfo.OrgAlias,
+		ProjectURL:       orgInfo.ProjectURL(),
+		URLOfCLAPlatform: this.urlOfCLAPlatform,
+	}
+
+	if remind && len(to) > 0 {
+		this.send(item.LinkID, to, "Reminder: an employee is waiting for activation", msg)
+	}
+
+	if !escalate {
+		return nil
+	}
+
+	admins, merr := models.ListCorporationManagers(item.LinkID, item.Email, dbmodels.RoleAdmin)
+	if merr != nil {
+		return merr
+	}
+
+	to = make([]string, 0, len(admins))
+	for _, m := range admins {
+		to = append(to, m.Email)
+	}
+
+	if len(to) > 0 {
+		msg.Escalation = true
+		this.send(item.LinkID, to, "An employee has waited for activation for a long time", msg)
+		msg.Escalation = false
+	}
+
+	if this.cfg.NotifyEmployee {
+		msg.Delay = true
+		this.send(
+			item.LinkID, []string{item.Email},
+			fmt.Sprintf("Activation of CLA signing on project of \"%s\" is delayed", msg.Org), msg,
+		)
+	}
+
+	return nil
+}
+
+func (this *approvalReminder) send(linkID string, to []string, subject string, builder email.IEmailMessageBulder) {
+	msg, err := builder.GenEmailMsg()
+	if err != nil {
+		beego.Error(err)
+		return
+	}
+
+	msg.To = to
+	msg.Subject = subject
+
+	GetEmailWorker().SendSimpleMessage(linkID, msg)
+}
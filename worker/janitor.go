@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/models"
+	"github.com/opensourceways/app-cla-server/util"
+)
+
+// PurgeReport records what the janitor purged in one run.
+type PurgeReport struct {
+	StartedAt         string   `json:"started_at"`
+	Duration          string   `json:"duration"`
+	VerificationCodes int64    `json:"verification_codes"`
+	DeletedSignings   int64    `json:"deleted_signings"`
+	UnlinkedLinks     []string `json:"unlinked_links"`
+	LocalFiles        int      `json:"local_files"`
+	TempPDFs          int      `json:"temp_pdfs"`
+	AuthRecords       int64    `json:"auth_records"`
+	HoldLease         bool     `json:"hold_lease"`
+	Errors            []string `json:"errors,omitempty"`
+}
+
+func (r *PurgeReport) addError(err error) {
+	if err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+const (
+	leaseOfJanitor = "janitor"
+
+	// minAgeOfOrphanedFiles keeps the files of the link which is being created,
+	// since they are saved before the link.
+	minAgeOfOrphanedFiles = time.Hour
+)
+
+type janitor struct {
+	cfg             config.Retention
+	pdfOutDir       string
+	orgSignatureDir string
+	holder          string
+}
+
+// StartJanitor purges the data which exceeds the retention periodically.
+// Every instance purges its local files, but only the instance which holds
+// the lease purges the shared data.
+func StartJanitor(cfg config.Retention, pdfOutDir, orgSignatureDir string) {
+	j := &janitor{
+		cfg:             cfg,
+		pdfOutDir:       pdfOutDir,
+		orgSignatureDir: orgSignatureDir,
+		holder:          genHolderOfLease(),
+	}
+
+	go func() {
+		interval := time.Duration(cfg.Interval) * time.Second
+		for {
+			j.report(j.run(j.acquireLease()))
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// acquireLease holds the lease until the next run, so the others can take
+// it over only if this instance stops.
+func (this *janitor) acquireLease() bool {
+	b, merr := models.AcquireLease(
+		leaseOfJanitor, this.holder, util.Now()+2*this.cfg.Interval,
+	)
+	if merr != nil {
+		beego.Error(fmt.Sprintf("acquire the lease of janitor failed, %s", merr.Error()))
+	}
+	return b
+}
+
+func (this *janitor) run(holdLease bool) *PurgeReport {
+	start := time.Now()
+	cfg := &this.cfg
+
+	r := &PurgeReport{
+		StartedAt: start.Format(time.RFC3339),
+		HoldLease: holdLease,
+	}
+
+	if holdLease {
+		this.purgeSharedData(start.Unix(), r)
+	}
+
+	if cfg.UnlinkedLinks > 0 {
+		n, err := this.purgeLocalFilesOfPurgedLinks(start.Add(-minAgeOfOrphanedFiles))
+		r.LocalFiles = n
+		r.addError(err)
+	}
+
+	if cfg.TempPDFs > 0 {
+		n, err := purgeOldFiles(this.pdfOutDir, start.Add(-time.Duration(cfg.TempPDFs)*time.Second))
+		r.TempPDFs = n
+		r.addError(err)
+	}
+
+	r.Duration = time.Since(start).String()
+	return r
+}
+
+// purgeSharedData purges the data in the db and the object storage.
+func (this *janitor) purgeSharedData(now int64, r *PurgeReport) {
+	cfg := &this.cfg
+
+	if cfg.VerificationCodes > 0 {
+		n, merr := models.PurgeVerificationCodes(now - cfg.VerificationCodes)
+		r.VerificationCodes = n
+		r.addError(toError("verification codes", merr))
+	}
+
+	if cfg.DeletedSignings > 0 {
+		n, merr := models.PurgeDeletedCorpSignings(now - cfg.DeletedSignings)
+		r.DeletedSignings = n
+		r.addError(toError("deleted signings", merr))
+	}
+
+	if cfg.UnlinkedLinks > 0 {
+		this.purgeUnlinkedLinks(now-cfg.UnlinkedLinks, r)
+	}
+
+	if cfg.AuthRecords > 0 {
+		n, merr := models.PurgeAuthRecords(now - cfg.AuthRecords)
+		r.AuthRecords = n
+		r.addError(toError("auth records", merr))
+	}
+}
+
+func (this *janitor) purgeUnlinkedLinks(unlinkedBefore int64, r *PurgeReport) {
+	links, merr := models.ListUnlinkedLinks(unlinkedBefore)
+	if merr != nil {
+		r.addError(toError("unlinked links", merr))
+		return
+	}
+
+	for _, linkID := range links {
+		if merr := models.PurgeDataOfLink(linkID); merr != nil {
+			r.addError(toError("link "+linkID, merr))
+			continue
+		}
+		r.UnlinkedLinks = append(r.UnlinkedLinks, linkID)
+	}
+}
+
+// purgeLocalFilesOfPurgedLinks removes the cla and signature files which are saved
+// for the links having been purged and are modified before the time.
+func (this *janitor) purgeLocalFilesOfPurgedLinks(before time.Time) (int, error) {
+	links, merr := models.ListLinkIDs()
+	if merr != nil {
+		return 0, toError("local files", merr)
+	}
+
+	files, err := ioutil.ReadDir(this.orgSignatureDir)
+	if err != nil {
+		return 0, err
+	}
+
+	isFileOfLink := func(name string) bool {
+		for _, linkID := range links {
+			if strings.Contains(name, "_"+linkID+"_") {
+				return true
+			}
+		}
+		return false
+	}
+
+	n := 0
+	for _, item := range files {
+		name := item.Name()
+		if item.IsDir() || !item.ModTime().Before(before) {
+			continue
+		}
+		if !strings.HasPrefix(name, "cla_") && !strings.HasPrefix(name, "signature_") {
+			continue
+		}
+		if isFileOfLink(name) {
+			continue
+		}
+
+		if err := os.Remove(util.GenFilePath(this.orgSignatureDir, name)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (this *janitor) report(r *PurgeReport) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		beego.Error(err)
+		return
+	}
+
+	if len(r.Errors) > 0 {
+		beego.Warn(fmt.Sprintf("janitor purged with errors: %s", string(b)))
+	} else {
+		beego.Info(fmt.Sprintf("janitor purged: %s", string(b)))
+	}
+}
+
+func genHolderOfLease() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// purgeOldFiles removes the files under dir which are modified before the time.
+func purgeOldFiles(dir string, before time.Time) (int, error) {
+	n := 0
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !info.ModTime().Before(before) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+
+	return n, err
+}
+
+func toError(class string, merr models.IModelError) error {
+	if merr == nil {
+		return nil
+	}
+	return fmt.Errorf("purge %s failed, %s", class, merr.Error())
+}
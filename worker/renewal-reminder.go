@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/astaxie/beego"
+
+	"github.com/opensourceways/app-cla-server/config"
+	"github.com/opensourceways/app-cla-server/dbmodels"
+	"github.com/opensourceways/app-cla-server/email"
+	"github.com/opensourceways/app-cla-server/models"
+)
+
+const (
+	// the stages of renewal reminders recorded on the corporation signing
+	renewalRemindedBeforeExpiry = 1
+	renewalRemindedAfterExpiry  = 2
+)
+
+type renewalReminder struct {
+	urlOfCLAPlatform string
+}
+
+// StartRenewalReminder reminds the corporation administrators to renew the
+// signings of the links which have a validity period.
+func StartRenewalReminder(cfg config.RenewalReminder, urlOfCLAPlatform string) {
+	if !cfg.Enabled() {
+		return
+	}
+
+	r := &renewalReminder{urlOfCLAPlatform: urlOfCLAPlatform}
+
+	go func() {
+		interval := time.Duration(cfg.CheckInterval) * time.Second
+		for {
+			r.run()
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func (this *renewalReminder) run() {
+	links, merr := models.ListLinksWithCorpSigningValidity()
+	if merr != nil {
+		beego.Error(merr)
+		return
+	}
+
+	for i := range links {
+		if err := this.checkLink(&links[i]); err != nil {
+			beego.Error(err)
+		}
+	}
+}
+
+func (this *renewalReminder) checkLink(link *models.LinkInfo) error {
+	if link.IsSuspended() {
+		return nil
+	}
+
+	signings, merr := models.ListCorpSignings(link.LinkID, "")
+	if merr != nil {
+		return merr
+	}
+
+	for i := range signings {
+		item := &signings[i].CorporationSigningBasicInfo
+
+		stage := 0
+		switch item.ExpiryStatus {
+		case dbmodels.CorpSigningExpiring:
+			stage = renewalRemindedBeforeExpiry
+		case dbmodels.CorpSigningInGrace, dbmodels.CorpSigningExpired:
+			stage = renewalRemindedAfterExpiry
+		}
+		if stage <= item.RenewalReminders {
+			continue
+		}
+
+		// take the stage before notifying, so that the reminder is sent only once
+		// even if the workers run concurrently.
+		done, merr := models.UpdateCorpSigningRenewalReminders(
+			link.LinkID, item.AdminEmail, item.RenewalReminders, stage,
+		)
+		if merr != nil {
+			beego.Error(merr)
+			continue
+		}
+
+		if done {
+			this.notify(link, item, stage == renewalRemindedAfterExpiry)
+		}
+	}
+
+	return nil
+}
+
+func (this *renewalReminder) notify(link *models.LinkInfo, signing *models.CorporationSigningBasicInfo, expired bool) {
+	graceEnd := signing.ExpiresAt + int64(link.CorpSigningValidity.GraceDays)*86400
+
+	data := email.CorpSigningRenewal{
+		Expired:          expired,
+		AdminName:        signing.AdminName,
+		Corporation:      signing.CorporationName,
+		ExpiryDate:       time.Unix(signing.ExpiresAt, 0).Format("2006-01-02"),
+		GraceEndDate:     time.Unix(graceEnd, 0).Format("2006-01-02"),
+		Org:              link.OrgAlias,
+		ProjectURL:       link.ProjectURL(),
+		URLOfCLAPlatform: this.urlOfCLAPlatform,
+	}
+
+	msg, err := data.GenEmailMsg()
+	if err != nil {
+		beego.Error(err)
+		return
+	}
+
+	msg.To = []string{signing.AdminEmail}
+	if expired {
+		msg.Subject = fmt.Sprintf("The CLA signed on project of \"%s\" is expired", link.OrgAlias)
+	} else {
+		msg.Subject = fmt.Sprintf("Please renew the CLA signed on project of \"%s\"", link.OrgAlias)
+	}
+
+	GetEmailWorker().SendSimpleMessage(link.LinkID, msg)
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"sync"
@@ -18,8 +19,10 @@ import (
 var worker IEmailWorker
 
 type IEmailWorker interface {
-	GenCLAPDFForCorporationAndSendIt(string, string, string, models.OrgInfo, models.CorporationSigning, []models.CLAField)
+	GenCLAPDFForCorporationAndSendIt(string, string, string, models.OrgInfo, models.CorporationSigning, *models.CLAInfo)
+	GenCLAPDFForIndividualAndSendIt(string, string, models.OrgInfo, models.IndividualSigning, *models.CLAInfo, string, email.IEmailMessageBulder)
 	SendSimpleMessage(string, *email.EmailMessage)
+	SendMessageWithAttachment(string, string, *email.EmailMessage)
 }
 
 func GetEmailWorker() IEmailWorker {
@@ -43,7 +46,7 @@ func (this *emailWorker) Shutdown() {
 	this.wg.Wait()
 }
 
-func (this *emailWorker) GenCLAPDFForCorporationAndSendIt(linkID, orgSignatureFile, claFile string, orgInfo models.OrgInfo, signing models.CorporationSigning, claFields []models.CLAField) {
+func (this *emailWorker) GenCLAPDFForCorporationAndSendIt(linkID, orgSignatureFile, claFile string, orgInfo models.OrgInfo, signing models.CorporationSigning, claInfo *models.CLAInfo) {
 	f := func() {
 		defer func() {
 			this.wg.Done()
@@ -59,7 +62,7 @@ func (this *emailWorker) GenCLAPDFForCorporationAndSendIt(linkID, orgSignatureFi
 			Date:        signing.Date,
 			AdminName:   signing.AdminName,
 			ProjectURL:  orgInfo.ProjectURL(),
-			SigningInfo: buildCorpSigningInfo(&signing, claFields),
+			SigningInfo: this.buildCorpSigningInfo(&signing, claInfo.Fields),
 		}
 
 		var msg *email.EmailMessage
@@ -89,7 +92,7 @@ func (this *emailWorker) GenCLAPDFForCorporationAndSendIt(linkID, orgSignatureFi
 			}
 
 			if file == "" || util.IsFileNotExist(file) {
-				file, err = this.pdfGenerator.GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile, &orgInfo, &signing, claFields)
+				file, err = this.pdfGenerator.GenPDFForCorporationSigning(linkID, orgSignatureFile, claFile, &orgInfo, &signing, claInfo)
 				if err != nil {
 					next(fmt.Errorf(
 						"Failed to generate pdf for corp signing(%s:%s:%s/%s): %s",
@@ -112,6 +115,96 @@ func (this *emailWorker) GenCLAPDFForCorporationAndSendIt(linkID, orgSignatureFi
 	go f()
 }
 
+// GenCLAPDFForIndividualAndSendIt generates the receipt of individual or employee signing,
+// saves it and sends it to the signer with the email built by builder.
+func (this *emailWorker) GenCLAPDFForIndividualAndSendIt(linkID, claText string, orgInfo models.OrgInfo, signing models.IndividualSigning, claInfo *models.CLAInfo, subject string, builder email.IEmailMessageBulder) {
+	f := func() {
+		defer func() {
+			this.wg.Done()
+		}()
+
+		var msg *email.EmailMessage
+		var emailCfg *models.OrgEmail
+		var ec email.IEmail
+		file := ""
+		uploaded := false
+
+		defer func() {
+			if !util.IsFileNotExist(file) {
+				os.Remove(file)
+			}
+		}()
+
+		for i := 0; i < 10; i++ {
+			if this.shutdown {
+				beego.Info("email worker exits forcedly")
+				break
+			}
+
+			var err error
+
+			if file == "" || util.IsFileNotExist(file) {
+				file, err = this.pdfGenerator.GenPDFForIndividualSigning(linkID, claText, &orgInfo, &signing, claInfo)
+				if err != nil {
+					next(fmt.Errorf(
+						"Failed to generate pdf for individual signing(%s:%s:%s/%s): %s",
+						orgInfo.Platform, orgInfo.OrgID, orgInfo.RepoID, signing.SigningID,
+						err.Error()))
+					continue
+				}
+			}
+
+			// save the receipt before sending it, so it can be downloaded even if the email fails.
+			if !uploaded {
+				if err := uploadIndividualSigningPDF(linkID, signing.SigningID, file); err != nil {
+					next(err)
+					continue
+				}
+				uploaded = true
+			}
+
+			if ec == nil {
+				if emailCfg, ec, err = getEmailClient(linkID); err != nil {
+					break
+				}
+			}
+
+			if msg == nil {
+				if msg, err = builder.GenEmailMsg(); err != nil {
+					next(err)
+					continue
+				}
+				msg.Subject = subject
+				msg.To = []string{signing.Email}
+			}
+			msg.Attachment = file
+
+			if err := ec.SendEmail(emailCfg.Token, msg); err != nil {
+				next(err)
+			} else {
+				break
+			}
+		}
+	}
+
+	this.wg.Add(1)
+	go f()
+}
+
+func uploadIndividualSigningPDF(linkID, signingID, file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if merr := models.UploadIndividualSigningPDF(linkID, signingID, data); merr != nil {
+		return fmt.Errorf(
+			"Failed to save pdf of individual signing(%s/%s): %s", linkID, signingID, merr.Error(),
+		)
+	}
+	return nil
+}
+
 func (this *emailWorker) SendSimpleMessage(linkID string, msg *email.EmailMessage) {
 	f := func() {
 		defer func() {
@@ -142,6 +235,46 @@ func (this *emailWorker) SendSimpleMessage(linkID string, msg *email.EmailMessag
 	go f()
 }
 
+// SendMessageWithAttachment sends the message with the file attached
+// and removes the file after that.
+func (this *emailWorker) SendMessageWithAttachment(linkID, file string, msg *email.EmailMessage) {
+	f := func() {
+		defer func() {
+			this.wg.Done()
+		}()
+
+		defer func() {
+			if !util.IsFileNotExist(file) {
+				os.Remove(file)
+			}
+		}()
+
+		emailCfg, ec, err := getEmailClient(linkID)
+		if err != nil {
+			return
+		}
+
+		msg.Attachment = file
+
+		for i := 0; i < 10; i++ {
+			if this.shutdown {
+				beego.Info("email worker exits forcedly")
+				break
+			}
+
+			if err := ec.SendEmail(emailCfg.Token, msg); err != nil {
+				next(err)
+				continue
+			}
+
+			break
+		}
+	}
+
+	this.wg.Add(1)
+	go f()
+}
+
 func next(err error) {
 	beego.Info(err.Error())
 	time.Sleep(time.Minute * time.Duration(1))
@@ -164,12 +297,13 @@ func getEmailClient(linkID string) (*models.OrgEmail, email.IEmail, error) {
 	return emailCfg, ec, nil
 }
 
-func buildCorpSigningInfo(signing *models.CorporationSigning, claFields []models.CLAField) string {
-	orders, titles := pdf.BuildCorpContact(claFields)
+func (this *emailWorker) buildCorpSigningInfo(signing *models.CorporationSigning, claFields []models.CLAField) string {
+	fields := pdf.BuildCorpContact(claFields)
 
-	v := make([]string, 0, len(orders))
-	for _, i := range orders {
-		v = append(v, fmt.Sprintf("%s: %s", titles[i], signing.Info[i]))
+	v := make([]string, 0, len(fields))
+	for i := range fields {
+		item := &fields[i]
+		v = append(v, fmt.Sprintf("%s: %s", item.Title, this.pdfGenerator.ContactValue(signing.CLALanguage, item, signing.Info[item.ID])))
 	}
 	v = append(v, fmt.Sprintf("Date: %s", signing.Date))
 